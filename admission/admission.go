@@ -0,0 +1,185 @@
+// Package admission Kubernetes validating admission webhook for custom resources whose spec is a proto message
+package admission
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"io"
+	"net/http"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// maxReviewBytes max AdmissionReview body read, the api server limits requests to 3MiB
+const maxReviewBytes = 3 << 20
+
+// GroupVersionKind kind of a custom resource
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// Review admission.k8s.io/v1 AdmissionReview, only the fields used by the webhook
+type Review struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Request    *Request  `json:"request,omitempty"`
+	Response   *Response `json:"response,omitempty"`
+}
+
+// Request admission request
+type Request struct {
+	UID       string           `json:"uid"`
+	Kind      GroupVersionKind `json:"kind"`
+	Operation string           `json:"operation"`
+	Object    json.RawMessage  `json:"object,omitempty"`
+}
+
+// Response admission response
+type Response struct {
+	UID     string  `json:"uid"`
+	Allowed bool    `json:"allowed"`
+	Result  *Status `json:"status,omitempty"`
+}
+
+// Status meta/v1 Status of a denial
+type Status struct {
+	Code    int32          `json:"code"`
+	Message string         `json:"message"`
+	Reason  string         `json:"reason"`
+	Details *StatusDetails `json:"details,omitempty"`
+}
+
+// StatusDetails per field causes of a denial
+type StatusDetails struct {
+	Causes []StatusCause `json:"causes"`
+}
+
+// StatusCause violation of a field
+type StatusCause struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	Field   string `json:"field"`
+}
+
+// Webhook validating admission webhook handler
+type Webhook struct {
+	validateAll func(msg proto.Message) error
+	kinds       map[GroupVersionKind]protoreflect.MessageType
+}
+
+// NewWebhook create a webhook validating the spec of the custom resources of kinds, v may be nil to use the default validator.
+// Objects of other kinds are allowed.
+func NewWebhook(v *validator.Validator, kinds map[GroupVersionKind]protoreflect.MessageType) *Webhook {
+	h := &Webhook{
+		validateAll: validator.ValidateAll,
+		kinds:       kinds,
+	}
+	if v != nil {
+		h.validateAll = v.ValidateAll
+	}
+	return h
+}
+
+// ServeHTTP implement http.Handler
+func (h *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxReviewBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("[proto valid]read admission review: %s", err), http.StatusBadRequest)
+		return
+	}
+	review := &Review{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, "[proto valid]invalid admission review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.Review(review.Request)
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(review)
+}
+
+// Review decide an admission request
+func (h *Webhook) Review(req *Request) *Response {
+	resp := &Response{
+		UID:     req.UID,
+		Allowed: true,
+	}
+	mt, ok := h.kinds[req.Kind]
+	if !ok || len(req.Object) == 0 || req.Operation == "DELETE" {
+		return resp
+	}
+
+	var object struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(req.Object, &object); err != nil {
+		return deny(fmt.Sprintf("[proto valid]decode object: %s", err), nil, resp)
+	}
+	spec := mt.New().Interface()
+	if len(object.Spec) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(object.Spec, spec); err != nil {
+			return deny(fmt.Sprintf("[proto valid]decode spec as %s: %s", mt.Descriptor().FullName(), err), nil, resp)
+		}
+	}
+
+	err := h.validateAll(spec)
+	if err == nil {
+		return resp
+	}
+	var causes []StatusCause
+	for _, e := range unwrap(err) {
+		var violation *validator.ValidError
+		if !errors.As(e, &violation) {
+			continue
+		}
+		causes = append(causes, StatusCause{
+			Reason:  "FieldValueInvalid",
+			Message: violation.Error(),
+			Field:   fieldPath(violation),
+		})
+	}
+	return deny(fmt.Sprintf("[proto valid]spec of %s is invalid: %d violations", req.Kind.Kind, len(causes)), causes, resp)
+}
+
+// deny turn a response into a denial
+func deny(message string, causes []StatusCause, resp *Response) *Response {
+	resp.Allowed = false
+	resp.Result = &Status{
+		Code:    http.StatusUnprocessableEntity,
+		Message: message,
+		Reason:  "Invalid",
+	}
+	if len(causes) > 0 {
+		resp.Result.Details = &StatusDetails{Causes: causes}
+	}
+	return resp
+}
+
+// fieldPath path of a violating field in the object, using the JSON field names
+func fieldPath(violation *validator.ValidError) string {
+	path := "spec"
+	for _, elem := range violation.PathElements() {
+		path += "." + elem.Field.JSONName()
+		if elem.Index >= 0 {
+			path = fmt.Sprintf("%s[%d]", path, elem.Index)
+		} else if elem.Key.IsValid() {
+			path = fmt.Sprintf("%s[%v]", path, elem.Key.Interface())
+		}
+	}
+	return path
+}
+
+// unwrap errors joined by ValidateAll
+func unwrap(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
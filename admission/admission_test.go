@@ -0,0 +1,161 @@
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// testKind kind of the test custom resource
+var testKind = GroupVersionKind{Group: "example.com", Version: "v1", Kind: "App"}
+
+// testField field of a test message with a rule
+func testField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, repeated bool, rule *validator.FieldValidator) *descriptorpb.FieldDescriptorProto {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   typ.Enum(),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if typeName != "" {
+		field.TypeName = proto.String(typeName)
+	}
+	if repeated {
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	}
+	if rule != nil {
+		field.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(field.Options, validator.E_Field, rule)
+	}
+	return field
+}
+
+// testSpec type of the spec of the test custom resource:
+// App {string name (length_gt 1); repeated Container containers; Container main}, Container {string image_ref (length_gt 3)}
+func testSpec(t *testing.T) protoreflect.MessageType {
+	t.Helper()
+	gt1, gt3 := int64(1), int64(3)
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("admission_test.proto"),
+		Package: proto.String("admission.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("App"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false, &validator.FieldValidator{LengthGt: &gt1}),
+				testField("containers", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".admission.test.Container", true, nil),
+				testField("main", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".admission.test.Container", false, nil),
+			},
+		}, {
+			Name: proto.String("Container"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				testField("image_ref", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", false, &validator.FieldValidator{LengthGt: &gt3}),
+			},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dynamicpb.NewMessageType(fd.Messages().ByName("App"))
+}
+
+func TestReview(t *testing.T) {
+	webhook := NewWebhook(nil, map[GroupVersionKind]protoreflect.MessageType{testKind: testSpec(t)})
+	for _, c := range []struct {
+		name    string
+		req     *Request
+		allowed bool
+		message string
+		causes  []string
+	}{
+		{"legal", &Request{Kind: testKind, Operation: "CREATE", Object: json.RawMessage(`{"spec":{"name":"web","containers":[{"imageRef":"nginx"}]}}`)}, true, "", nil},
+		{"other kind", &Request{Kind: GroupVersionKind{Group: "example.com", Version: "v2", Kind: "App"}, Operation: "CREATE", Object: json.RawMessage(`{"spec":{}}`)}, true, "", nil},
+		{"delete", &Request{Kind: testKind, Operation: "DELETE", Object: json.RawMessage(`{"spec":{}}`)}, true, "", nil},
+		{"no object", &Request{Kind: testKind, Operation: "CREATE"}, true, "", nil},
+		{"unknown spec field", &Request{Kind: testKind, Operation: "UPDATE", Object: json.RawMessage(`{"spec":{"name":"web","replicas":3}}`)}, true, "", nil},
+		{"no spec", &Request{Kind: testKind, Operation: "CREATE", Object: json.RawMessage(`{"metadata":{"name":"web"}}`)}, false, "1 violations", []string{"spec.name"}},
+		{"violations", &Request{Kind: testKind, Operation: "UPDATE", Object: json.RawMessage(`{"spec":{"name":"w","containers":[{"imageRef":"nginx"},{"imageRef":"a"}],"main":{"imageRef":""}}}`)},
+			false, "3 violations", []string{"spec.name", "spec.containers[1].imageRef", "spec.main.imageRef"}},
+		{"malformed object", &Request{Kind: testKind, Operation: "CREATE", Object: json.RawMessage(`[]`)}, false, "decode object", nil},
+		{"mistyped spec", &Request{Kind: testKind, Operation: "CREATE", Object: json.RawMessage(`{"spec":{"name":1}}`)}, false, "decode spec as admission.test.App", nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			c.req.UID = "uid-1"
+			resp := webhook.Review(c.req)
+			if resp.UID != "uid-1" || resp.Allowed != c.allowed {
+				t.Fatalf("%+v", resp)
+			}
+			if c.allowed {
+				if resp.Result != nil {
+					t.Fatalf("%+v", resp.Result)
+				}
+				return
+			}
+			if resp.Result.Code != http.StatusUnprocessableEntity || resp.Result.Reason != "Invalid" || !strings.Contains(resp.Result.Message, c.message) {
+				t.Fatalf("%+v", resp.Result)
+			}
+			var fields []string
+			if resp.Result.Details != nil {
+				for _, cause := range resp.Result.Details.Causes {
+					if cause.Reason != "FieldValueInvalid" {
+						t.Fatalf("%+v", cause)
+					}
+					fields = append(fields, cause.Field)
+				}
+			}
+			if !reflect.DeepEqual(fields, c.causes) {
+				t.Fatal(fields)
+			}
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	webhook := NewWebhook(validator.New(), map[GroupVersionKind]protoreflect.MessageType{testKind: testSpec(t)})
+	for _, c := range []struct {
+		name    string
+		body    string
+		status  int
+		allowed bool
+	}{
+		{"allowed", `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"u","kind":{"group":"example.com","version":"v1","kind":"App"},"operation":"CREATE","object":{"spec":{"name":"web"}}}}`, http.StatusOK, true},
+		{"denied", `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"u","kind":{"group":"example.com","version":"v1","kind":"App"},"operation":"CREATE","object":{"spec":{"name":"w"}}}}`, http.StatusOK, false},
+		{"no request", `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview"}`, http.StatusBadRequest, false},
+		{"malformed", `{`, http.StatusBadRequest, false},
+		{"too large", `{"request":{"uid":"` + strings.Repeat("u", maxReviewBytes) + `"}}`, http.StatusBadRequest, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			webhook.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewBufferString(c.body)))
+			if rec.Code != c.status {
+				t.Fatalf("%d %s", rec.Code, rec.Body)
+			}
+			if c.status != http.StatusOK {
+				return
+			}
+			review := &Review{}
+			if err := json.Unmarshal(rec.Body.Bytes(), review); err != nil {
+				t.Fatal(err)
+			}
+			if review.APIVersion != "admission.k8s.io/v1" || review.Kind != "AdmissionReview" || review.Request != nil ||
+				review.Response == nil || review.Response.UID != "u" || review.Response.Allowed != c.allowed {
+				t.Fatalf("%s", rec.Body)
+			}
+			if rec.Header().Get("Content-Type") != "application/json" {
+				t.Fatal(rec.Header())
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Aggregation how many violations a validation reports
+type Aggregation int
+
+const (
+	// AggregateFirst stop at the first violation (default)
+	AggregateFirst Aggregation = iota
+	// AggregateField evaluate every rule of the first failing field, then stop,
+	// e.g. to report both "too short" and "bad pattern" of a form field
+	AggregateField
+	// AggregateAll collect every violation, as ValidateAll
+	AggregateAll
+)
+
+// String implement fmt.Stringer
+func (a Aggregation) String() string {
+	switch a {
+	case AggregateFirst:
+		return "first"
+	case AggregateField:
+		return "field"
+	case AggregateAll:
+		return "all"
+	}
+	return fmt.Sprintf("Aggregation(%d)", int(a))
+}
+
+// WithAggregation report the violations of Validate and ValidateContext at level,
+// the returned error joins the *ValidError of every reported violation unless level is AggregateFirst
+func WithAggregation(level Aggregation) Option {
+	return func(v *Validator) {
+		v.aggregation = level
+	}
+}
+
+// ErrViolationsTruncated joined to the collected violations when WithMaxViolations dropped some of them
+var ErrViolationsTruncated = errors.New("[proto valid]violations truncated")
+
+// WithMaxViolations stop collecting violations after n of them, the walk stops at the next violation
+// and ErrViolationsTruncated is joined to the returned error. 0 collects every violation.
+func WithMaxViolations(n int) Option {
+	return func(v *Validator) {
+		v.maxViolations = n
+	}
+}
+
+// CallMaxViolations override WithMaxViolations for the call
+func CallMaxViolations(n int) CallOption {
+	return func(c *callOptions) {
+		c.maxViolations = &n
+	}
+}
+
+// stop whether a field failed while aggregating per field, the collected violations are truncated
+// or the validation ran out of its budget
+func (v *validator) stop() bool {
+	return v.perField && len(*v.errs) > 0 || v.truncated != nil && *v.truncated || v.overBudget()
+}
+
+// full whether the collected violations reached the WithMaxViolations cap, marking them truncated
+func (v *validator) full() bool {
+	if v.truncated == nil || len(*v.errs) < v.maxViolations {
+		return false
+	}
+	*v.truncated = true
+	return true
+}
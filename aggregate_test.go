@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testAggregateProto message whose fields may fail several rules each
+const testAggregateProto = `syntax = "proto3"; package aggregate; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 3, regex: "^[A-Z]+$"}]; }
+message Form {
+  string name = 1 [(validator.field) = {length_gt: 3, regex: "^[a-z]+$"}];
+  string email = 2 [(validator.field) = {string_not_empty: true}];
+  repeated Item items = 3;
+}`
+
+// violationKeys "path:rule" of every violation held by err
+func violationKeys(err error) []string {
+	var keys []string
+	for _, e := range ValidErrors(err) {
+		keys = append(keys, e.Path()+":"+e.Rule())
+	}
+	return keys
+}
+
+func TestAggregation(t *testing.T) {
+	fd := compileProto(t, testAggregateProto)
+	for _, c := range []struct {
+		name, json string
+		level      Aggregation
+		keys       []string
+	}{
+		{"legal", `{"name":"abcd","email":"a"}`, AggregateAll, nil},
+		{"first", `{"name":"A1","email":""}`, AggregateFirst, []string{"name:LengthGt"}},
+		{"field", `{"name":"A1","email":""}`, AggregateField, []string{"name:LengthGt", "name:Regex"}},
+		{"field with one rule failing", `{"name":"abc","email":""}`, AggregateField, []string{"name:LengthGt"}},
+		{"field of a later field", `{"name":"abcd","email":""}`, AggregateField, []string{"email:StringNotEmpty"}},
+		{"field of an element", `{"name":"abcd","email":"a","items":[{"sku":"a"},{"sku":"b"}]}`, AggregateField, []string{"items[0].sku:LengthGt", "items[0].sku:Regex"}},
+		{"all", `{"name":"A1","email":"","items":[{"sku":"ABCD"},{"sku":"b"}]}`, AggregateAll,
+			[]string{"name:LengthGt", "name:Regex", "email:StringNotEmpty", "items[1].sku:LengthGt", "items[1].sku:Regex"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			msg := newMsg(t, fd, "Form", c.json)
+			if keys := violationKeys(New(WithAggregation(c.level)).Validate(msg)); !reflect.DeepEqual(keys, c.keys) {
+				t.Fatalf("option: %v", keys)
+			}
+			//the call option overrides the level of the validator
+			ctx := ContextWithCallOptions(context.Background(), CallAggregation(c.level))
+			if keys := violationKeys(New(WithAggregation(AggregateFirst)).ValidateContext(ctx, msg)); !reflect.DeepEqual(keys, c.keys) {
+				t.Fatalf("call option: %v", keys)
+			}
+		})
+	}
+}
+
+func TestAggregationString(t *testing.T) {
+	for level, s := range map[Aggregation]string{AggregateFirst: "first", AggregateField: "field", AggregateAll: "all", -1: "Aggregation(-1)"} {
+		if level.String() != s {
+			t.Errorf("%d: %s", int(level), level)
+		}
+	}
+}
+
+func TestMaxViolations(t *testing.T) {
+	fd := compileProto(t, testAggregateProto)
+	//4 violations: name LengthGt and Regex, email StringNotEmpty, items[0].sku LengthGt
+	msg := newMsg(t, fd, "Form", `{"name":"A1","email":"","items":[{"sku":"ABC"}]}`)
+	all := []string{"name:LengthGt", "name:Regex", "email:StringNotEmpty", "items[0].sku:LengthGt"}
+	for _, c := range []struct {
+		name      string
+		max       int
+		call      []CallOption
+		level     Aggregation
+		keys      []string
+		truncated bool
+	}{
+		{"no cap", 0, nil, AggregateAll, all, false},
+		{"negative cap", -1, nil, AggregateAll, all, false},
+		{"cap of 1", 1, nil, AggregateAll, all[:1], true},
+		{"cap below the count", 3, nil, AggregateAll, all[:3], true},
+		{"cap at the count", 4, nil, AggregateAll, all, false},
+		{"cap above the count", 5, nil, AggregateAll, all, false},
+		{"call cap", 0, []CallOption{CallMaxViolations(2)}, AggregateAll, all[:2], true},
+		{"call cap lifted", 2, []CallOption{CallMaxViolations(0)}, AggregateAll, all, false},
+		{"per field", 1, nil, AggregateField, all[:1], true},
+		//not collected
+		{"first violation", 1, nil, AggregateFirst, all[:1], false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(WithAggregation(c.level), WithMaxViolations(c.max))
+			err := v.ValidateContext(ContextWithCallOptions(context.Background(), c.call...), msg)
+			if keys := violationKeys(err); !reflect.DeepEqual(keys, c.keys) {
+				t.Fatalf("got %v, want %v", keys, c.keys)
+			}
+			if errors.Is(err, ErrViolationsTruncated) != c.truncated {
+				t.Fatal(err)
+			}
+		})
+	}
+	//ValidateAll honors the cap as well
+	if err := New(WithMaxViolations(2)).ValidateAll(msg); !reflect.DeepEqual(violationKeys(err), all[:2]) || !errors.Is(err, ErrViolationsTruncated) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord evidence of a violation
+type AuditRecord struct {
+	// Time time of the violation, read from the validator clock
+	Time time.Time `json:"time"`
+	// Message full name of the message holding the field
+	Message string `json:"message"`
+	// Path path of the field from the validated message, e.g. "items[2].sku"
+	Path string `json:"path"`
+	// Rule rule key, e.g. "Regex"
+	Rule string `json:"rule"`
+	// Value redacted field value, strings and bytes are replaced by their length
+	Value string `json:"value"`
+	// Shadow whether the violation comes from a shadow rule
+	Shadow bool `json:"shadow,omitempty"`
+	// Metadata caller metadata, see ContextWithAuditMetadata
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// AuditSink receive a record of every violation, e.g. to retain rejected-input evidence for compliance.
+// Audit is called synchronously during the validation, errors are logged.
+type AuditSink interface {
+	Audit(ctx context.Context, record *AuditRecord) error
+}
+
+// WithAuditSink send a record of every violation to sink
+func WithAuditSink(sink AuditSink) Option {
+	return func(v *Validator) {
+		v.auditSink = sink
+	}
+}
+
+// auditMetadataKey context key of the caller metadata
+type auditMetadataKey struct{}
+
+// ContextWithAuditMetadata attach caller metadata (e.g. user or client id) to the audit records of ValidateContext
+func ContextWithAuditMetadata(ctx context.Context, md map[string]string) context.Context {
+	return context.WithValue(ctx, auditMetadataKey{}, md)
+}
+
+// audit send a violation to the audit sink
+func (v *validator) audit(err *ValidError) {
+	ctx := v.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	record := &AuditRecord{
+		Time:    v.now(),
+		Message: string(err.field.ContainingMessage().FullName()),
+		Path:    err.Path(),
+		Rule:    err.validKey,
+		Value:   redact(err.fieldValue),
+		Shadow:  err.shadow,
+	}
+	record.Metadata, _ = ctx.Value(auditMetadataKey{}).(map[string]string)
+	if auditErr := v.auditSink.Audit(ctx, record); auditErr != nil {
+		v.warnf("[pb valid]audit violation of msg[%s] err: %s", record.Message, auditErr)
+	}
+}
+
+// redact redact a field value, strings and bytes may hold personal data
+func redact(value interface{}) string {
+	switch x := value.(type) {
+	case string:
+		return fmt.Sprintf("<redacted %d bytes>", len(x))
+	case []byte:
+		return fmt.Sprintf("<redacted %d bytes>", len(x))
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// JSONLAuditSink write audit records as JSON lines
+type JSONLAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLAuditSink create a sink writing to w
+func NewJSONLAuditSink(w io.Writer) *JSONLAuditSink {
+	return &JSONLAuditSink{w: w}
+}
+
+// OpenJSONLAuditSink create a sink appending to the file at path
+func OpenJSONLAuditSink(path string) (*JSONLAuditSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONLAuditSink(f), nil
+}
+
+// Audit implement AuditSink
+func (s *JSONLAuditSink) Audit(_ context.Context, record *AuditRecord) error {
+	var line bytes.Buffer
+	enc := json.NewEncoder(&line)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(record); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.w.Write(line.Bytes())
+	return err
+}
+
+// Close close the underlying writer if it is an io.Closer
+func (s *JSONLAuditSink) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SQLAuditSink insert audit records into a table with the columns
+// (time, message, path, rule, value, shadow, metadata), metadata holding a JSON object or NULL
+type SQLAuditSink struct {
+	db     *sql.DB
+	insert string
+}
+
+// NewSQLAuditSink create a sink inserting into table.
+// dollar selects $1 placeholders (e.g. PostgreSQL) instead of ? (e.g. MySQL, SQLite).
+func NewSQLAuditSink(db *sql.DB, table string, dollar bool) *SQLAuditSink {
+	placeholders := make([]string, 7)
+	for i := range placeholders {
+		placeholders[i] = "?"
+		if dollar {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		}
+	}
+	return &SQLAuditSink{
+		db: db,
+		insert: fmt.Sprintf("INSERT INTO %s (time, message, path, rule, value, shadow, metadata) VALUES (%s)",
+			table, strings.Join(placeholders, ", ")),
+	}
+}
+
+// Audit implement AuditSink
+func (s *SQLAuditSink) Audit(ctx context.Context, record *AuditRecord) error {
+	var metadata interface{}
+	if len(record.Metadata) > 0 {
+		data, err := json.Marshal(record.Metadata)
+		if err != nil {
+			return err
+		}
+		metadata = string(data)
+	}
+	_, err := s.db.ExecContext(ctx, s.insert,
+		record.Time, record.Message, record.Path, record.Rule, record.Value, record.Shadow, metadata)
+	return err
+}
@@ -0,0 +1,125 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testExec statement executed by the test driver
+type testExec struct {
+	query string
+	args  []driver.Value
+}
+
+// testDriver database driver recording the executed statements
+type testDriver struct {
+	execs []testExec
+	err   error
+}
+
+// Open implement driver.Driver
+func (d *testDriver) Open(string) (driver.Conn, error) {
+	return &testConn{d: d}, nil
+}
+
+// Connect implement driver.Connector
+func (d *testDriver) Connect(context.Context) (driver.Conn, error) {
+	return &testConn{d: d}, nil
+}
+
+// Driver implement driver.Connector
+func (d *testDriver) Driver() driver.Driver {
+	return d
+}
+
+// testConn connection of the test driver, only executing statements
+type testConn struct {
+	d *testDriver
+}
+
+// Prepare implement driver.Conn
+func (c *testConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+// Close implement driver.Conn
+func (c *testConn) Close() error {
+	return nil
+}
+
+// Begin implement driver.Conn
+func (c *testConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+// ExecContext implement driver.ExecerContext
+func (c *testConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.d.execs = append(c.d.execs, testExec{query: query, args: values})
+	return driver.RowsAffected(1), c.d.err
+}
+
+func TestSQLAuditSink(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		name   string
+		dollar bool
+		record *AuditRecord
+		exec   testExec
+	}{
+		{"question marks", false, &AuditRecord{Time: now, Message: "audit.Order", Path: "email", Rule: "Regex", Value: "<redacted 1 bytes>"}, testExec{
+			"INSERT INTO audit (time, message, path, rule, value, shadow, metadata) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			[]driver.Value{now, "audit.Order", "email", "Regex", "<redacted 1 bytes>", false, nil},
+		}},
+		{"dollars", true, &AuditRecord{Time: now, Message: "audit.Order", Path: "limit", Rule: "IntLt", Value: "5", Shadow: true}, testExec{
+			"INSERT INTO audit (time, message, path, rule, value, shadow, metadata) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+			[]driver.Value{now, "audit.Order", "limit", "IntLt", "5", true, nil},
+		}},
+		{"empty metadata", false, &AuditRecord{Time: now, Rule: "Regex", Metadata: map[string]string{}}, testExec{
+			"INSERT INTO audit (time, message, path, rule, value, shadow, metadata) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			[]driver.Value{now, "", "", "Regex", "", false, nil},
+		}},
+		{"metadata", false, &AuditRecord{Time: now, Rule: "Regex", Metadata: map[string]string{"client": "web"}}, testExec{
+			"INSERT INTO audit (time, message, path, rule, value, shadow, metadata) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			[]driver.Value{now, "", "", "Regex", "", false, `{"client":"web"}`},
+		}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			d := &testDriver{}
+			db := sql.OpenDB(d)
+			defer db.Close()
+			if err := NewSQLAuditSink(db, "audit", c.dollar).Audit(context.Background(), c.record); err != nil {
+				t.Fatal(err)
+			}
+			if len(d.execs) != 1 || !reflect.DeepEqual(d.execs[0], c.exec) {
+				t.Fatalf("%+v", d.execs)
+			}
+		})
+	}
+}
+
+func TestSQLAuditSinkError(t *testing.T) {
+	fd := compileProto(t, testAuditProto)
+	d := &testDriver{err: errors.New("table not found")}
+	db := sql.OpenDB(d)
+	defer db.Close()
+	logger := &testLogger{}
+	v := New(WithAuditSink(NewSQLAuditSink(db, "audit", false)), WithLogger(logger))
+	//a failing sink does not change the result of the validation
+	if err := v.Validate(newMsg(t, fd, "Order", `{"email":"A"}`)); !MatchViolation(err, "email", "Regex") {
+		t.Fatal(err)
+	}
+	if len(d.execs) != 1 || len(logger.warnings) != 1 {
+		t.Fatal(logger.warnings)
+	}
+}
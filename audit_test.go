@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testAuditSink audit sink recording the records
+type testAuditSink struct {
+	records []*AuditRecord
+	err     error
+}
+
+// Audit implement AuditSink
+func (s *testAuditSink) Audit(_ context.Context, record *AuditRecord) error {
+	s.records = append(s.records, record)
+	return s.err
+}
+
+// testAuditProto message with a string, a repeated and a shadow rule
+const testAuditProto = `syntax = "proto3"; package audit; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 3}]; }
+message Order {
+  string email = 1 [(validator.field) = {regex: "^[a-z]+@[a-z]+$"}];
+  repeated Item items = 2;
+  int64 count = 3 [(validator.field) = {int_lt: 10}];
+  int64 limit = 4 [(validator.field) = {int_lt: 5, shadow: true}];
+}`
+
+func TestAuditSink(t *testing.T) {
+	fd := compileProto(t, testAuditProto)
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	metadata := map[string]string{"client": "web"}
+	for _, c := range []struct {
+		name, json string
+		ctx        context.Context
+		records    []*AuditRecord
+	}{
+		{"legal", `{"email":"a@b"}`, context.Background(), nil},
+		{"redacted string", `{"email":"Jane.Doe@example.com"}`, context.Background(), []*AuditRecord{
+			{Time: now, Message: "audit.Order", Path: "email", Rule: "Regex", Value: "<redacted 20 bytes>"},
+		}},
+		{"nested path", `{"email":"a@b","items":[{"sku":"abcd"},{"sku":"ab"}]}`, context.Background(), []*AuditRecord{
+			{Time: now, Message: "audit.Item", Path: "items[1].sku", Rule: "LengthGt", Value: "2"},
+		}},
+		{"number", `{"email":"a@b","count":"10"}`, context.Background(), []*AuditRecord{
+			{Time: now, Message: "audit.Order", Path: "count", Rule: "IntLt", Value: "10"},
+		}},
+		{"shadow", `{"email":"a@b","limit":"5"}`, context.Background(), []*AuditRecord{
+			{Time: now, Message: "audit.Order", Path: "limit", Rule: "IntLt", Value: "5", Shadow: true},
+		}},
+		{"metadata", `{"email":"A"}`, ContextWithAuditMetadata(context.Background(), metadata), []*AuditRecord{
+			{Time: now, Message: "audit.Order", Path: "email", Rule: "Regex", Value: "<redacted 1 bytes>", Metadata: metadata},
+		}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			sink := &testAuditSink{}
+			v := New(WithAuditSink(sink), WithClock(func() time.Time { return now }))
+			_ = v.ValidateContext(c.ctx, newMsg(t, fd, "Order", c.json))
+			if !reflect.DeepEqual(sink.records, c.records) {
+				for _, record := range sink.records {
+					t.Errorf("%+v", record)
+				}
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestAuditSinkError(t *testing.T) {
+	fd := compileProto(t, testAuditProto)
+	logger := &testLogger{}
+	sink := &testAuditSink{err: errors.New("disk full")}
+	err := New(WithAuditSink(sink), WithLogger(logger)).Validate(newMsg(t, fd, "Order", `{"email":"A"}`))
+	if !MatchViolation(err, "email", "Regex") {
+		t.Fatal(err)
+	}
+	if len(sink.records) != 1 || len(logger.warnings) != 1 || !strings.Contains(logger.warnings[0], "disk full") {
+		t.Fatal(logger.warnings)
+	}
+}
+
+func TestJSONLAuditSink(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		name   string
+		record *AuditRecord
+		line   string
+	}{
+		{"record", &AuditRecord{Time: now, Message: "audit.Order", Path: "email", Rule: "Regex", Value: "<redacted 1 bytes>"},
+			`{"time":"2026-10-17T12:00:00Z","message":"audit.Order","path":"email","rule":"Regex","value":"<redacted 1 bytes>"}`},
+		{"shadow and metadata", &AuditRecord{Time: now, Message: "audit.Order", Path: `by_key["<k>"]`, Rule: "IntLt", Value: "5", Shadow: true, Metadata: map[string]string{"client": "web"}},
+			`{"time":"2026-10-17T12:00:00Z","message":"audit.Order","path":"by_key[\"<k>\"]","rule":"IntLt","value":"5","shadow":true,"metadata":{"client":"web"}}`},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			sink := NewJSONLAuditSink(&buf)
+			for i := 0; i < 2; i++ {
+				if err := sink.Audit(context.Background(), c.record); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if want := c.line + "\n" + c.line + "\n"; buf.String() != want {
+				t.Fatalf("got %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestOpenJSONLAuditSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	record := &AuditRecord{Message: "audit.Order", Path: "email", Rule: "Regex"}
+	//the file is appended to, not truncated, when opened again
+	for i := 0; i < 2; i++ {
+		sink, err := OpenJSONLAuditSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Audit(context.Background(), record); err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for _, line := range lines {
+		var got AuditRecord
+		if err := json.Unmarshal([]byte(line), &got); err != nil || got.Path != "email" {
+			t.Fatalf("%s: %v", line, err)
+		}
+	}
+	if len(lines) != 2 {
+		t.Fatal(lines)
+	}
+	if _, err := OpenJSONLAuditSink(filepath.Join(path, "not a dir")); err == nil {
+		t.Fatal("want an error opening a file below a file")
+	}
+}
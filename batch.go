@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"sync"
+)
+
+// BatchReport result of a batch validation
+type BatchReport struct {
+	// Errors validation error per index, nil if the message is legal
+	Errors []error
+	// Total number of messages
+	Total int
+	// Valid number of legal messages
+	Valid int
+	// Invalid number of illegal messages
+	Invalid int
+}
+
+// OK whether all messages are legal
+func (r *BatchReport) OK() bool {
+	return r.Invalid == 0
+}
+
+// Failed indexes of the illegal messages
+func (r *BatchReport) Failed() []int {
+	failed := make([]int, 0, r.Invalid)
+	for i, err := range r.Errors {
+		if err != nil {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
+
+// ValidateBatch verify a batch of generated proto messages
+func (v *Validator) ValidateBatch(msgs []proto.Message) *BatchReport {
+	return v.batch(len(msgs), func(i int) error {
+		return v.Validate(msgs[i])
+	})
+}
+
+// batch run valid on every index, in parallel if batch workers are configured
+func (v *Validator) batch(n int, valid func(i int) error) *BatchReport {
+	report := &BatchReport{
+		Errors: make([]error, n),
+		Total:  n,
+	}
+
+	workers := v.batchWorkers
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			report.Errors[i] = valid(i)
+		}
+	} else {
+		idx := make(chan int)
+		wg := sync.WaitGroup{}
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+				for i := range idx {
+					report.Errors[i] = valid(i)
+				}
+			}()
+		}
+		for i := 0; i < n; i++ {
+			idx <- i
+		}
+		close(idx)
+		wg.Wait()
+	}
+
+	for _, err := range report.Errors {
+		if err != nil {
+			report.Invalid++
+		}
+	}
+	report.Valid = report.Total - report.Invalid
+	return report
+}
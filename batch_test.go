@@ -0,0 +1,36 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"reflect"
+	"testing"
+)
+
+func TestValidateBatch(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package batch; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 1}]; }`)
+	legal, illegal := newMsg(t, fd, "Item", `{"sku":"ab"}`), newMsg(t, fd, "Item", `{"sku":"a"}`)
+	for _, c := range []struct {
+		name   string
+		msgs   []proto.Message
+		failed []int
+	}{
+		{"empty", nil, []int{}},
+		{"legal", []proto.Message{legal, legal}, []int{}},
+		{"mixed", []proto.Message{illegal, legal, illegal, legal}, []int{0, 2}},
+		{"illegal", []proto.Message{illegal, illegal, illegal}, []int{0, 1, 2}},
+	} {
+		for _, workers := range []int{-1, 0, 1, 2, 8} {
+			report := New(WithBatchWorkers(workers)).ValidateBatch(c.msgs)
+			if report.Total != len(c.msgs) || report.Invalid != len(c.failed) || report.Valid != len(c.msgs)-len(c.failed) ||
+				report.OK() != (len(c.failed) == 0) || !reflect.DeepEqual(report.Failed(), c.failed) {
+				t.Fatalf("%s with %d workers: %+v", c.name, workers, report)
+			}
+			for _, i := range c.failed {
+				if !MatchViolation(report.Errors[i], "sku", "LengthGt") {
+					t.Fatalf("%s with %d workers: %v", c.name, workers, report.Errors[i])
+				}
+			}
+		}
+	}
+}
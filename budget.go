@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrBudgetExceeded returned, or joined to the collected violations, when a validation ran out of its WithBudget.
+// The fields left are not verified, so the message must be treated as invalid.
+var ErrBudgetExceeded = errors.New("[proto valid]validation budget exceeded")
+
+// WithBudget stop a validation running longer than budget, the walk stops at the next field or element
+// and ErrBudgetExceeded is returned. 0 disables the budget.
+func WithBudget(budget time.Duration) Option {
+	return func(v *Validator) {
+		v.budget = budget
+	}
+}
+
+// CallBudget override WithBudget for the call, e.g. a tighter budget for external traffic
+func CallBudget(budget time.Duration) CallOption {
+	return func(c *callOptions) {
+		c.budget = &budget
+	}
+}
+
+// budgetState deadline of a validation with a budget
+type budgetState struct {
+	at       time.Time
+	exceeded bool
+}
+
+// overBudget whether the validation ran out of its budget
+func (v *validator) overBudget() bool {
+	if v.deadline == nil {
+		return false
+	}
+	if !v.deadline.exceeded && time.Now().After(v.deadline.at) {
+		v.deadline.exceeded = true
+	}
+	return v.deadline.exceeded
+}
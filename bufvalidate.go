@@ -0,0 +1,309 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BufExport buf.validate annotations equivalent to the rule of a field, to migrate a schema to protovalidate
+type BufExport struct {
+	// Field annotated field
+	Field protoreflect.FieldDescriptor
+	// Options field options in .proto syntax, e.g. `(buf.validate.field).string.pattern = "^a+$"`
+	Options []string
+	// Unsupported rule keys without buf.validate equivalent, e.g. runtime features like "Shadow"
+	Unsupported []string
+}
+
+// ExportBufValidate export the rules of every message of a file, including nested messages, as buf.validate annotations.
+// Fields without rules are skipped.
+func ExportBufValidate(fd protoreflect.FileDescriptor) []*BufExport {
+	return exportBufMessages(fd.Messages(), nil)
+}
+
+// exportBufMessages export messages and their nested messages
+func exportBufMessages(messages protoreflect.MessageDescriptors, out []*BufExport) []*BufExport {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			if rule := getRule(field, protoregistry.GlobalTypes); rule != nil {
+				out = append(out, exportBufField(field, rule))
+			}
+		}
+		out = exportBufMessages(md.Messages(), out)
+	}
+	return out
+}
+
+// exportBufField export the rule of a field
+func exportBufField(field protoreflect.FieldDescriptor, rule *FieldValidator) *BufExport {
+	export := &BufExport{Field: field}
+	const prefix = "(buf.validate.field)."
+
+	//rules apply to the elements of a list and to the keys of a map
+	elem, elemPrefix := field, prefix
+	switch {
+	case field.IsMap():
+		elem, elemPrefix = field.MapKey(), prefix+"map.keys."
+		if rule.RepeatedCountMin != nil {
+			export.add(prefix+"map.min_pairs", *rule.RepeatedCountMin)
+		}
+		if rule.RepeatedCountMax != nil {
+			export.add(prefix+"map.max_pairs", *rule.RepeatedCountMax)
+		}
+		//pattern and in may already be taken by the rules of the keys, the key shorthands are exported as CEL
+		if rule.MapKeyRegex != nil && elem.Kind() == protoreflect.StringKind {
+			expr := fmt.Sprintf("this.matches(%q)", *rule.MapKeyRegex)
+			export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "map_key_regex", message: "key must match the pattern", expression: %q}`, elemPrefix, expr))
+		}
+		if len(rule.MapKeyIn) > 0 {
+			quoted := make([]string, len(rule.MapKeyIn))
+			for i, key := range rule.MapKeyIn {
+				quoted[i] = strconv.Quote(key)
+			}
+			expr := fmt.Sprintf("string(this) in [%s]", strings.Join(quoted, ", "))
+			export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "map_key_in", message: "key must be one of the allowed keys", expression: %q}`, elemPrefix, expr))
+		}
+	case field.IsList():
+		elemPrefix = prefix + "repeated.items."
+		if rule.RepeatedCountMin != nil {
+			export.add(prefix+"repeated.min_items", *rule.RepeatedCountMin)
+		}
+		if rule.RepeatedCountMax != nil {
+			export.add(prefix+"repeated.max_items", *rule.RepeatedCountMax)
+		}
+		if rule.RepeatedUniqueBy != nil && field.Kind() == protoreflect.MessageKind {
+			expr := fmt.Sprintf("this.filter(e, has(e.%[1]s)).map(e, e.%[1]s).unique()", *rule.RepeatedUniqueBy)
+			export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "repeated_unique_by", message: "%s must be unique", expression: %q}`, prefix, *rule.RepeatedUniqueBy, expr))
+		}
+	}
+	kind := elemPrefix + elem.Kind().String() + "."
+
+	switch {
+	case isInt(elem):
+		unsigned := strings.HasPrefix(elem.Kind().String(), "uint") || strings.HasPrefix(elem.Kind().String(), "fixed")
+		if rule.IntGt != nil && !(unsigned && *rule.IntGt < 0) {
+			export.add(kind+"gt", *rule.IntGt)
+		}
+		if rule.IntLt != nil {
+			if unsigned && *rule.IntLt <= 0 {
+				export.Unsupported = append(export.Unsupported, "IntLt")
+			} else {
+				export.add(kind+"lt", *rule.IntLt)
+			}
+		}
+		if rule.IntGte != nil && !(unsigned && *rule.IntGte <= 0) {
+			export.add(kind+"gte", *rule.IntGte)
+		}
+		if rule.IntLte != nil {
+			if unsigned && *rule.IntLte < 0 {
+				export.Unsupported = append(export.Unsupported, "IntLte")
+			} else {
+				export.add(kind+"lte", *rule.IntLte)
+			}
+		}
+		if unsigned {
+			for key, value := range map[string]*uint64{"gt": rule.UintGt, "lt": rule.UintLt, "gte": rule.UintGte, "lte": rule.UintLte} {
+				if value != nil {
+					export.add(kind+key, *value)
+				}
+			}
+		}
+		if rule.GetIntPort() {
+			expr := "this >= 1 && this <= 65535"
+			if rule.GetIntPortAllowZero() {
+				expr = "this >= 0 && this <= 65535"
+			}
+			export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "int_port", message: "value must be a valid port", expression: %q}`, elemPrefix, expr))
+		}
+	case isFloat(elem):
+		//float_epsilon widens the bounds
+		eps := rule.GetFloatEpsilon()
+		if rule.FloatGt != nil {
+			export.add(kind+"gt", *rule.FloatGt-eps)
+		}
+		if rule.FloatLt != nil {
+			export.add(kind+"lt", *rule.FloatLt+eps)
+		}
+		if rule.FloatGte != nil {
+			export.add(kind+"gte", *rule.FloatGte-eps)
+		}
+		if rule.FloatLte != nil {
+			export.add(kind+"lte", *rule.FloatLte+eps)
+		}
+	case elem.Kind() == protoreflect.StringKind:
+		//buf.validate counts lengths in bytes or in code points, grapheme lengths have no equivalent
+		minName, maxName, lenName := "min_bytes", "max_bytes", "len_bytes"
+		lengths := true
+		switch rule.GetLengthUnit() {
+		case LengthUnit_LENGTH_UNIT_RUNES:
+			minName, maxName, lenName = "min_len", "max_len", "len"
+		case LengthUnit_LENGTH_UNIT_GRAPHEMES:
+			lengths = false
+			export.Unsupported = append(export.Unsupported, "LengthUnit")
+		}
+		var minLen int64
+		if rule.GetStringNotEmpty() {
+			minLen = 1
+		}
+		if lengths && rule.LengthGt != nil && *rule.LengthGt+1 > minLen {
+			minLen = *rule.LengthGt + 1
+		}
+		if minLen > 0 {
+			export.add(kind+minName, minLen)
+		}
+		if lengths && rule.LengthLt != nil {
+			export.addLengthLt(kind+maxName, elemPrefix, *rule.LengthLt)
+		}
+		if lengths && rule.LengthEq != nil {
+			export.add(kind+lenName, *rule.LengthEq)
+		}
+		if rule.Regex != nil {
+			export.add(kind+"pattern", *rule.Regex)
+		}
+		if rule.GetIp() {
+			export.add(kind+"ip", true)
+		}
+		if rule.GetIpv4() {
+			export.add(kind+"ipv4", true)
+		}
+		if rule.GetIpv6() {
+			export.add(kind+"ipv6", true)
+		}
+		if rule.StringPrefix != nil {
+			export.add(kind+"prefix", *rule.StringPrefix)
+		}
+		if rule.StringSuffix != nil {
+			export.add(kind+"suffix", *rule.StringSuffix)
+		}
+		if rule.StringContains != nil {
+			export.add(kind+"contains", *rule.StringContains)
+		}
+		if rule.StringNotContains != nil {
+			export.add(kind+"not_contains", *rule.StringNotContains)
+		}
+		//pattern is already taken by regex, the equivalent patterns are exported as CEL
+		if rule.GetStringTimeOfDay() {
+			expr := fmt.Sprintf("this.matches(%q)", `^([01][0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9])?$`)
+			export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "string_time_of_day", message: "value must be a time of day", expression: %q}`, elemPrefix, expr))
+		}
+		if rule.GetStringDayOfWeek() {
+			expr := fmt.Sprintf("this.matches(%q)", `(?i)^(sun|mon|tue|wed|thu|fri|sat|sunday|monday|tuesday|wednesday|thursday|friday|saturday)$`)
+			export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "string_day_of_week", message: "value must be a day of the week", expression: %q}`, elemPrefix, expr))
+		}
+		//the country of a postal code read from a sibling field has no equivalent
+		if rule.StringPostalCode != nil && rule.PostalCodeCountryField == nil {
+			if exp := postalCodes[strings.ToUpper(*rule.StringPostalCode)]; exp != nil {
+				expr := fmt.Sprintf("this.matches(%q)", exp.String())
+				export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "string_postal_code", message: "value must be a %s postal code", expression: %q}`, elemPrefix, *rule.StringPostalCode, expr))
+			}
+		}
+		if len(rule.HashFormat) > 0 {
+			var matches []string
+			for _, format := range rule.HashFormat {
+				if exp := hashPatterns[format]; exp != nil {
+					matches = append(matches, fmt.Sprintf("this.matches(%q)", exp.String()))
+				}
+			}
+			export.Options = append(export.Options, fmt.Sprintf(`%scel = {id: "hash_format", message: "value must be a hash", expression: %q}`, elemPrefix, strings.Join(matches, " || ")))
+		}
+	case elem.Kind() == protoreflect.BytesKind:
+		if rule.LengthGt != nil && *rule.LengthGt >= 0 {
+			export.add(kind+"min_len", *rule.LengthGt+1)
+		}
+		if rule.LengthLt != nil {
+			export.addLengthLt(kind+"max_len", elemPrefix, *rule.LengthLt)
+		}
+		if rule.LengthEq != nil {
+			export.add(kind+"len", *rule.LengthEq)
+		}
+		if rule.Regex != nil {
+			export.add(kind+"pattern", *rule.Regex)
+		}
+		if rule.StringPrefix != nil {
+			export.add(kind+"prefix", *rule.StringPrefix)
+		}
+		if rule.StringSuffix != nil {
+			export.add(kind+"suffix", *rule.StringSuffix)
+		}
+	case elem.Kind() == protoreflect.EnumKind:
+		if rule.GetIsInEnum() {
+			export.add(kind+"defined_only", true)
+		}
+		for _, n := range rule.EnumIn {
+			export.add(kind+"in", n)
+		}
+		for _, n := range rule.EnumNotIn {
+			export.add(kind+"not_in", n)
+		}
+	}
+
+	for key, set := range map[string]bool{
+		"IpPrivate":              rule.GetIpPrivate(),
+		"IpPublic":               rule.GetIpPublic(),
+		"FieldMask":              rule.GetFieldMask(),
+		"FieldMaskTarget":        rule.FieldMaskTarget != nil,
+		"LatLng":                 rule.GetLatLng(),
+		"Date":                   rule.Date != nil,
+		"TimeOfDay":              rule.TimeOfDay != nil,
+		"SinceVersion":           rule.SinceVersion != nil,
+		"UntilVersion":           rule.UntilVersion != nil,
+		"Versioned":              len(rule.Versioned) > 0,
+		"Shadow":                 rule.GetShadow(),
+		"GoFunc":                 rule.GoFunc != nil,
+		"PreValidated":           rule.GetPreValidated(),
+		"Groups":                 len(rule.Groups) > 0,
+		"Priority":               rule.Priority != nil,
+		"NumericString":          rule.NumericString != nil,
+		"StringChecksum":         rule.StringChecksum != nil,
+		"RepeatedMonotonic":      rule.RepeatedMonotonic != nil,
+		"StringPostalCode":       rule.StringPostalCode != nil && rule.PostalCodeCountryField != nil,
+		"PostalCodeCountryField": rule.PostalCodeCountryField != nil,
+		"Nested":                 len(rule.Nested) > 0,
+		"MsgMaxBytes":            rule.MsgMaxBytes != nil,
+		"StringNotSimilar":       rule.StringNotSimilar != nil,
+		"Money":                  rule.Money != nil,
+		"ErrorMessage":           rule.ErrorMessage != nil,
+		"HumanError":             rule.HumanError != nil,
+	} {
+		if set {
+			export.Unsupported = append(export.Unsupported, key)
+		}
+	}
+	sort.Strings(export.Unsupported)
+	return export
+}
+
+// add add an option
+func (e *BufExport) add(name string, value interface{}) {
+	var literal string
+	switch x := value.(type) {
+	case string:
+		literal = strconv.Quote(x)
+	case float64:
+		literal = strconv.FormatFloat(x, 'g', -1, 64)
+	default:
+		literal = fmt.Sprint(x)
+	}
+	e.Options = append(e.Options, fmt.Sprintf("%s = %s", name, literal))
+}
+
+// addLengthLt add the max length option of length_lt. A length_lt of 0 or less fails every value and has no max length
+// equivalent (max 0 still allows the empty value), it is exported as the equivalent CEL rule.
+func (e *BufExport) addLengthLt(name, elemPrefix string, lengthLt int64) {
+	if lengthLt > 0 {
+		e.add(name, lengthLt-1)
+		return
+	}
+	expr := fmt.Sprintf("size(this) < %d", lengthLt)
+	e.Options = append(e.Options, fmt.Sprintf(`%scel = {id: "length_lt", message: "value length must be less than %d", expression: %q}`, elemPrefix, lengthLt, expr))
+}
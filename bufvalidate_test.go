@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExportBufValidate(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package bufexport; import "validator.proto";
+message Rules {
+  string name = 1 [(validator.field) = {length_gt: 2, length_lt: 10}];
+  string runes = 2 [(validator.field) = {length_unit: LENGTH_UNIT_RUNES, length_lt: 5}];
+  string never = 3 [(validator.field) = {length_lt: 0}];
+  bytes blob = 4 [(validator.field) = {length_gt: -1, length_lt: 4}];
+  bytes empty = 5 [(validator.field) = {length_lt: -2}];
+  repeated string tags = 6 [(validator.field) = {repeated_count_max: 3, length_lt: 0}];
+  uint32 count = 7 [(validator.field) = {int_lt: 0}];
+  string note = 8 [(validator.field) = {shadow: true, length_gt: 1}];
+}`)
+	want := map[string]struct {
+		options, unsupported string
+	}{
+		"name":  {`[(buf.validate.field).string.min_bytes = 3 (buf.validate.field).string.max_bytes = 9]`, `[]`},
+		"runes": {`[(buf.validate.field).string.max_len = 4]`, `[]`},
+		"never": {`[(buf.validate.field).cel = {id: "length_lt", message: "value length must be less than 0", expression: "size(this) < 0"}]`, `[]`},
+		"blob":  {`[(buf.validate.field).bytes.max_len = 3]`, `[]`},
+		"empty": {`[(buf.validate.field).cel = {id: "length_lt", message: "value length must be less than -2", expression: "size(this) < -2"}]`, `[]`},
+		"tags": {`[(buf.validate.field).repeated.max_items = 3 (buf.validate.field).repeated.items.cel = {id: "length_lt", message: "value length must be less than 0", expression: "size(this) < 0"}]`,
+			`[]`},
+		"count": {`[]`, `[IntLt]`},
+		"note":  {`[(buf.validate.field).string.min_bytes = 2]`, `[Shadow]`},
+	}
+	exports := ExportBufValidate(fd)
+	if len(exports) != len(want) {
+		t.Fatalf("want %d exports, got %d", len(want), len(exports))
+	}
+	for _, export := range exports {
+		name := string(export.Field.Name())
+		if got := fmt.Sprint(export.Options); got != want[name].options {
+			t.Errorf("%s options: %s", name, got)
+		}
+		if got := fmt.Sprint(export.Unsupported); got != want[name].unsupported {
+			t.Errorf("%s unsupported: %s", name, got)
+		}
+	}
+}
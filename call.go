@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"context"
+	"time"
+)
+
+// callOptions per-call overrides of the validator options
+type callOptions struct {
+	aggregation   *Aggregation
+	pathFormat    *PathFormat
+	groups        *groupSet
+	maxViolations *int
+	locale        *string
+	budget        *time.Duration
+}
+
+// CallOption per-call override of a validator option, attached to a context with ContextWithCallOptions
+type CallOption func(*callOptions)
+
+// callOptionsKey context key of the per-call options
+type callOptionsKey struct{}
+
+// ContextWithCallOptions override validator options for the validations using ctx, e.g. for a middleware
+// to aggregate violations of external traffic without constructing another validator.
+// Options already attached to ctx are kept unless overridden.
+func ContextWithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	calls := &callOptions{}
+	if prev, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok {
+		*calls = *prev
+	}
+	for _, opt := range opts {
+		opt(calls)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, calls)
+}
+
+// callOptionsFrom get the per-call options attached to ctx, nil if none
+func callOptionsFrom(ctx context.Context) *callOptions {
+	if ctx == nil {
+		return nil
+	}
+	calls, _ := ctx.Value(callOptionsKey{}).(*callOptions)
+	return calls
+}
+
+// CallAggregation override WithAggregation for the call
+func CallAggregation(level Aggregation) CallOption {
+	return func(c *callOptions) {
+		c.aggregation = &level
+	}
+}
+
+// CallPathFormat override WithPathFormat for the call
+func CallPathFormat(format PathFormat) CallOption {
+	return func(c *callOptions) {
+		c.pathFormat = &format
+	}
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+	"time"
+)
+
+const callProto = `syntax = "proto3"; package call; import "validator.proto";
+message Req {
+  string name = 1 [(validator.field) = {length_gt: 1}];
+  string slow = 2 [(validator.field) = {go_func: "slow"}];
+  string code = 3 [(validator.field) = {length_gt: 1}];
+}`
+
+func TestCallLocale(t *testing.T) {
+	fd := compileProto(t, callProto)
+	slow := func(ctx context.Context, field protoreflect.FieldDescriptor, value protoreflect.Value) error {
+		return nil
+	}
+	v := New(WithFunc("slow", slow), WithMessages("fr", map[string]string{"LengthGt": "trop court: {{value}}"}))
+	msg := newMsg(t, fd, "Req", `{"name":"x","code":"ok"}`)
+	for _, c := range []struct {
+		name   string
+		opts   []CallOption
+		french bool
+	}{
+		{"no locale", nil, false},
+		{"locale", []CallOption{CallLocale("fr")}, true},
+		{"language of locale", []CallOption{CallLocale("fr-CA")}, true},
+		{"unknown locale", []CallOption{CallLocale("de")}, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := v.ValidateContext(ContextWithCallOptions(context.Background(), c.opts...), msg)
+			var verr *ValidError
+			if !errors.As(err, &verr) {
+				t.Fatal(err)
+			}
+			if french := verr.Message() == "trop court: 1"; french != c.french {
+				t.Fatal(verr.Message())
+			}
+		})
+	}
+}
+
+func TestCallBudget(t *testing.T) {
+	fd := compileProto(t, callProto)
+	slow := func(ctx context.Context, field protoreflect.FieldDescriptor, value protoreflect.Value) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}
+	msg := newMsg(t, fd, "Req", `{"name":"ok","slow":"x","code":"x"}`)
+	for _, c := range []struct {
+		name      string
+		opts      []Option
+		calls     []CallOption
+		exceeded  bool
+		violation bool
+	}{
+		{"no budget", nil, nil, false, true},
+		{"budget", []Option{WithBudget(time.Millisecond)}, nil, true, false},
+		{"call budget", nil, []CallOption{CallBudget(time.Millisecond)}, true, false},
+		{"call budget lifted", []Option{WithBudget(time.Millisecond)}, []CallOption{CallBudget(0)}, false, true},
+		{"aggregated", []Option{WithBudget(time.Millisecond), WithAggregation(AggregateAll)}, nil, true, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(append(c.opts, WithFunc("slow", slow))...)
+			err := v.ValidateContext(ContextWithCallOptions(context.Background(), c.calls...), msg)
+			if errors.Is(err, ErrBudgetExceeded) != c.exceeded || MatchViolation(err, "code", "LengthGt") != c.violation {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"bytes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"math"
+)
+
+// ValidateChanged verify only the fields of msg changed since old with the default validator
+func ValidateChanged(old, msg proto.Message) error {
+	return std().ValidateChanged(old, msg)
+}
+
+// ValidateChanged verify only the fields of msg changed since old.
+// Unchanged fields are skipped, changed sub-messages are checked with the rules of their field
+// (e.g. msg_max_bytes or nested rules) and diffed recursively.
+// A postal code is verified again when its postal_code_country_field changed.
+// Message-level rules are always verified.
+// msg is fully verified if old is nil or of another message type.
+func (v *Validator) ValidateChanged(old, msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	m := msg.ProtoReflect()
+	if old == nil || old.ProtoReflect().Descriptor() != m.Descriptor() {
+		return v.valid(m)
+	}
+	return v.run(&validator{
+		Validator: v,
+		msg:       m,
+		old:       old.ProtoReflect(),
+	})
+}
+
+// validChanged skip an unchanged field, or diff a changed singular sub-message against its previous version.
+// done is false if the field has to be verified as usual.
+func (v *validator) validChanged(field protoreflect.FieldDescriptor, rule *FieldValidator, prev, value protoreflect.Value) (done bool, err error) {
+	if equalValue(field, prev, value) && !v.postalCodeCountryChanged(field, rule) {
+		return true, nil
+	}
+	//a sub-message added or cleared since old is verified as usual
+	if !hasMessage(field) || !v.old.Has(field) || !v.msg.Has(field) {
+		return false, nil
+	}
+	if field.IsMap() {
+		if field.MapValue().Message().FullName() == anyFullName {
+			return false, nil
+		}
+		return true, v.validChangedMap(field, rule, prev.Map(), value.Map())
+	}
+	if field.Message().FullName() == anyFullName {
+		return false, nil
+	}
+	if field.IsList() {
+		return true, v.validChangedList(field, rule, prev.List(), value.List())
+	}
+	return true, v.validChangedMessage(field, rule, prev.Message(), value.Message())
+}
+
+// validChangedList verify a changed list of messages, element by element
+func (v *validator) validChangedList(field protoreflect.FieldDescriptor, rule *FieldValidator, prev, list protoreflect.List) error {
+	if err := v.checkRepeated(field, list, rule); err != nil {
+		return err
+	}
+	for i := 0; i < list.Len() && !v.stop(); i++ {
+		if i >= prev.Len() {
+			if err := v.validElem(field, i, list.Get(i), rule); err != nil {
+				return err
+			}
+			continue
+		}
+		v.elem = PathElement{Field: field, Index: i}
+		err := v.validChangedMessage(field, rule, prev.Get(i).Message(), list.Get(i).Message())
+		v.elem = PathElement{}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validChangedMap verify a changed map of messages, entry by entry
+func (v *validator) validChangedMap(field protoreflect.FieldDescriptor, rule *FieldValidator, prev, m protoreflect.Map) (err error) {
+	if err = v.checkCount(field, int64(m.Len()), rule); err != nil {
+		return err
+	}
+	m.Range(func(key protoreflect.MapKey, item protoreflect.Value) bool {
+		old := prev.Get(key)
+		if !old.IsValid() {
+			err = v.validEntry(field, key, item, rule)
+			return err == nil && !v.stop()
+		}
+		v.elem = PathElement{Field: field, Index: -1, Key: key}
+		//the rule of a map applies to its keys, not to its values
+		err = v.validChangedMessage(field, nil, old.Message(), item.Message())
+		v.elem = PathElement{}
+		return err == nil && !v.stop()
+	})
+	return err
+}
+
+// validChangedMessage verify a changed sub-message of field against its previous version,
+// with the same rules of the field as validField
+func (v *validator) validChangedMessage(field protoreflect.FieldDescriptor, rule *FieldValidator, prev, m protoreflect.Message) error {
+	if proto.Equal(prev.Interface(), m.Interface()) {
+		return nil
+	}
+	if err := v.checkMessageRules(field, m, rule); err != nil {
+		return err
+	}
+	sub := *v
+	sub.msg, sub.old = m, prev
+	sub.path, sub.elem = appendPath(v.path, v.step(field)), PathElement{}
+	if err := sub.Valid(); err != nil {
+		return err
+	}
+	sub.old = nil
+	if err := sub.checkNested(field, rule); err != nil {
+		return err
+	}
+	return v.checkFunc(field, protoreflect.ValueOfMessage(m), rule)
+}
+
+// equalValue whether two values of a field are equal
+func equalValue(field protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	switch {
+	case field.IsMap():
+		ma, mb := a.Map(), b.Map()
+		if ma.Len() != mb.Len() {
+			return false
+		}
+		equal := true
+		ma.Range(func(key protoreflect.MapKey, va protoreflect.Value) bool {
+			vb := mb.Get(key)
+			equal = vb.IsValid() && equalScalar(field.MapValue(), va, vb)
+			return equal
+		})
+		return equal
+	case field.IsList():
+		la, lb := a.List(), b.List()
+		if la.Len() != lb.Len() {
+			return false
+		}
+		for i := 0; i < la.Len(); i++ {
+			if !equalScalar(field, la.Get(i), lb.Get(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return equalScalar(field, a, b)
+}
+
+// equalScalar whether two singular values of a field kind are equal
+func equalScalar(field protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return proto.Equal(a.Message().Interface(), b.Message().Interface())
+	case protoreflect.BytesKind:
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return math.Float64bits(a.Float()) == math.Float64bits(b.Float())
+	}
+	return a.Interface() == b.Interface()
+}
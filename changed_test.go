@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+	"time"
+)
+
+const changedProto = `syntax = "proto3"; package changed; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 0}]; string note = 2; }
+message Order {
+  Item item = 1 [(validator.field) = {msg_max_bytes: 12, go_func: "noted"}];
+  repeated Item items = 2 [(validator.field) = {msg_max_bytes: 12, repeated_count_max: 2, go_func: "noted"}];
+  map<string, Item> by_key = 3;
+  string name = 4 [(validator.field) = {length_gt: 1}];
+  map<string, Item> limited = 5 [(validator.field) = {repeated_count_min: 1, repeated_count_max: 3}];
+}`
+
+func TestValidateChanged(t *testing.T) {
+	fd := compileProto(t, changedProto)
+	old := newMsg(t, fd, "Order", `{"item":{"sku":"a"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`)
+	noted := func(_ context.Context, _ protoreflect.FieldDescriptor, value protoreflect.Value) error {
+		if note := value.Message().Descriptor().Fields().ByName("note"); value.Message().Get(note).String() != "" {
+			return errors.New("has a note")
+		}
+		return nil
+	}
+	v := New(WithFunc("noted", noted), WithDefaultMaxRepeated(2))
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"unchanged illegal field", `{"item":{"sku":"a"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "", ""},
+		{"changed field", `{"item":{"sku":"a"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"}},"name":"y"}`, "name", "LengthGt"},
+		{"changed sub-message field", `{"item":{"sku":""},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "item.sku", "LengthGt"},
+		{"changed sub-message rule", `{"item":{"sku":"a","note":"too long"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "item", "MsgMaxBytes"},
+		{"changed element rule", `{"item":{"sku":"a"},"items":[{"sku":"a","note":"too long"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "items[0]", "MsgMaxBytes"},
+		{"added element", `{"item":{"sku":"a"},"items":[{"sku":"a"},{"sku":""}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "items[1].sku", "LengthGt"},
+		{"changed list count", `{"item":{"sku":"a"},"items":[{"sku":"a"},{"sku":"b"},{"sku":"c"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "items", "RepeatedCountMax"},
+		{"changed map value", `{"item":{"sku":"a"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":""}},"name":"x"}`, `by_key["k"].sku`, "LengthGt"},
+		{"changed sub-message go_func", `{"item":{"sku":"a","note":"x"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "item", "GoFunc"},
+		{"changed element go_func", `{"item":{"sku":"a"},"items":[{"sku":"a","note":"x"}],"byKey":{"k":{"sku":"a"}},"name":"x"}`, "items[0]", "GoFunc"},
+		{"map at the default limit", `{"item":{"sku":"a"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"},"l":{"sku":"b"}},"name":"x"}`, "", ""},
+		{"map over the default limit", `{"item":{"sku":"a"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"a"},"l":{"sku":"b"},"m":{"sku":"c"}},"name":"x"}`, "by_key", "DefaultMaxRepeated"},
+		{"changed map over the default limit", `{"item":{"sku":"a"},"items":[{"sku":"a"}],"byKey":{"k":{"sku":"b"},"l":{"sku":"b"},"m":{"sku":"c"}},"name":"x"}`, "by_key", "DefaultMaxRepeated"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := v.ValidateChanged(old, newMsg(t, fd, "Order", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestValidateChangedMapCount(t *testing.T) {
+	fd := compileProto(t, changedProto)
+	old := newMsg(t, fd, "Order", `{"name":"xy","limited":{"k":{"sku":"a"}}}`)
+	//the explicit repeated_count_max exempts the map from the default limit
+	v := New(WithDefaultMaxRepeated(2))
+	for _, c := range []struct {
+		name, json, rule string
+	}{
+		{"unchanged", `{"name":"xy","limited":{"k":{"sku":"a"}}}`, ""},
+		{"changed entry", `{"name":"xy","limited":{"k":{"sku":"b"}}}`, ""},
+		{"changed map at the max", `{"name":"xy","limited":{"k":{"sku":"b"},"l":{"sku":"b"},"m":{"sku":"c"}}}`, ""},
+		{"changed map over the max", `{"name":"xy","limited":{"k":{"sku":"a"},"l":{"sku":"b"},"m":{"sku":"c"},"n":{"sku":"d"}}}`, "RepeatedCountMax"},
+		{"cleared map", `{"name":"xy"}`, "RepeatedCountMin"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := v.ValidateChanged(old, newMsg(t, fd, "Order", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, "limited", c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestValidateChangedNoOld(t *testing.T) {
+	fd := compileProto(t, changedProto)
+	if err := New(WithFunc("noted", func(context.Context, protoreflect.FieldDescriptor, protoreflect.Value) error { return nil })).
+		ValidateChanged(nil, newMsg(t, fd, "Order", `{"name":"x"}`)); !MatchViolation(err, "name", "LengthGt") {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateChangedPostalCountry(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package changed; import "validator.proto";
+message Address {
+  string zip = 1 [(validator.field) = {string_postal_code: "US", postal_code_country_field: "country"}];
+  string country = 2;
+}`)
+	old := newMsg(t, fd, "Address", `{"zip":"12345","country":"US"}`)
+	if err := New().ValidateChanged(old, newMsg(t, fd, "Address", `{"zip":"12345","country":"US"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := New().ValidateChanged(old, newMsg(t, fd, "Address", `{"zip":"12345","country":"CA"}`)); !MatchViolation(err, "zip", "StringPostalCode") {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateChangedRules(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package changedrules; import "validator.proto"; import "google/type/types.proto"; import "google/protobuf/field_mask.proto";
+message Decimal { string value = 1; }
+message Item { string sku = 1; string note = 2; }
+message Order {
+  google.type.Money price = 1 [(validator.field) = {money: {max: "100"}}];
+  google.type.LatLng location = 2 [(validator.field) = {lat_lng: true}];
+  repeated google.type.LatLng route = 3 [(validator.field) = {lat_lng: true}];
+  google.type.Date day = 4 [(validator.field) = {date: {not_before_today: true}}];
+  google.type.TimeOfDay at = 5 [(validator.field) = {time_of_day: {max: "17:30"}}];
+  Decimal amount = 6 [(validator.field) = {regex: "^[0-9]+$"}];
+  string code = 7 [(validator.field) = {string_suffix: "-EU"}];
+  google.protobuf.FieldMask mask = 8 [(validator.field) = {field_mask: true, field_mask_target: "changedrules.Item"}];
+  Item item = 9 [(validator.field) = {nested: [{field_path: "sku", rule: {length_gt: 1}}]}];
+}`)
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	v := New(
+		WithClock(func() time.Time { return now }),
+		WithResolver(testTypes(t, fd)),
+		WithExtractor("changedrules.Decimal", protoreflect.StringKind, func(msg protoreflect.Message) (protoreflect.Value, bool) {
+			field := msg.Descriptor().Fields().ByName("value")
+			return msg.Get(field), msg.Has(field)
+		}),
+	)
+	const legal = `{"price":{"units":"10"},"location":{"latitude":1},"day":{"year":2026,"month":10,"day":17},"at":{"hours":9},` +
+		`"amount":{"value":"1"},"code":"A-EU","mask":"sku","item":{"sku":"ab"}}`
+	for _, c := range []struct {
+		name, old, change, path, rule string
+	}{
+		{"unchanged", legal, `{}`, "", ""},
+		{"unchanged illegal fields", `{"price":{"units":"200"},"location":{"latitude":95},"code":"A-US","item":{"sku":"a"}}`, `{}`, "", ""},
+		{"money", legal, `{"price":{"units":"200"}}`, "price", "MoneyMax"},
+		{"lat_lng", legal, `{"location":{"latitude":95}}`, "location", "LatLng"},
+		{"lat_lng element", legal, `{"route":[{"longitude":200}]}`, "route[0]", "LatLng"},
+		{"date", legal, `{"day":{"year":2026,"month":10,"day":16}}`, "day", "DateNotBeforeToday"},
+		{"time_of_day", legal, `{"at":{"hours":18}}`, "at", "TimeOfDayMax"},
+		{"extracted value", legal, `{"amount":{"value":"1.5"}}`, "amount", "Regex"},
+		{"string affix", legal, `{"code":"A-US"}`, "code", "StringSuffix"},
+		{"field mask", legal, `{"mask":"sku,skew"}`, "mask", "FieldMaskTarget"},
+		{"nested", legal, `{"item":{"sku":"a"}}`, "item.sku", "LengthGt"},
+		{"nested from unset", `{"code":"A-EU"}`, `{"item":{"sku":"a"}}`, "item.sku", "LengthGt"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			//change is merged into a copy of old
+			msg := newMsg(t, fd, "Order", c.old)
+			proto.Merge(msg, newMsg(t, fd, "Order", c.change))
+			err := v.ValidateChanged(newMsg(t, fd, "Order", c.old), msg)
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestValidateChangedCleared(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package changed; import "validator.proto"; import "google/type/types.proto";
+message Booking {
+  google.type.Date day = 1 [(validator.field) = {date: {min: "2024-01-01"}}];
+  repeated google.type.Date days = 2 [(validator.field) = {date: {min: "2024-01-01"}}];
+}`)
+	old := newMsg(t, fd, "Booking", `{"day":{"year":2026,"month":10,"day":17},"days":[{"year":2026,"month":10,"day":17}]}`)
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"cleared", `{}`, "", ""},
+		{"zero", `{"day":{}}`, "day", "Date"},
+		{"cleared list", `{"day":{"year":2026,"month":10,"day":17}}`, "", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateChanged(old, newMsg(t, fd, "Booking", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
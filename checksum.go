@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ChecksumFunc checksum algorithm referenced by the string_checksum rule,
+// reports whether checksum is the checksum of body for an ID of the form <body>-<checksum>
+type ChecksumFunc func(body, checksum string) bool
+
+// checksums checksum algorithms registered with RegisterChecksum
+var checksums sync.Map
+
+func init() {
+	RegisterChecksum("crc32", crc32Checksum)
+	RegisterChecksum("mod97", mod97Checksum)
+}
+
+// RegisterChecksum register a checksum algorithm referenced by string_checksum: name, for every validator.
+// Registering a name again replaces the algorithm, including the built-in "crc32" and "mod97".
+func RegisterChecksum(name string, fn ChecksumFunc) {
+	checksums.Store(name, fn)
+}
+
+// WithChecksum register a checksum algorithm referenced by string_checksum: name for the validator,
+// taking precedence over RegisterChecksum
+func WithChecksum(name string, fn ChecksumFunc) Option {
+	return func(v *Validator) {
+		if v.checksums == nil {
+			v.checksums = make(map[string]ChecksumFunc)
+		}
+		v.checksums[name] = fn
+	}
+}
+
+// getChecksum get the checksum algorithm named name, nil if not registered
+func (v *Validator) getChecksum(name string) ChecksumFunc {
+	if fn, ok := v.checksums[name]; ok {
+		return fn
+	}
+	if x, ok := checksums.Load(name); ok {
+		return x.(ChecksumFunc)
+	}
+	return nil
+}
+
+// checkChecksum check the checksum suffix of an ID, an unregistered algorithm rejects every value
+func (v *validator) checkChecksum(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.StringChecksum == nil {
+		return nil
+	}
+	fn := v.getChecksum(*rule.StringChecksum)
+	if fn == nil {
+		//reported by Register, fail closed instead of skipping the check
+		v.warnf("[pb valid]checksum[%s] not found", *rule.StringChecksum)
+		v.fault(&Fault{Kind: FaultConfig, Err: fmt.Errorf("checksum[%s] not found", *rule.StringChecksum)})
+		return v.fail(field, "StringChecksum", *rule.StringChecksum, "checksum not registered")
+	}
+	i := strings.LastIndexByte(value, '-')
+	if i <= 0 || i == len(value)-1 || !fn(value[:i], value[i+1:]) {
+		return v.fail(field, "StringChecksum", *rule.StringChecksum, value)
+	}
+	return nil
+}
+
+// crc32Checksum the checksum is the IEEE CRC-32 of the body as 8 hex digits, in any case
+func crc32Checksum(body, checksum string) bool {
+	return strings.EqualFold(checksum, fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(body))))
+}
+
+// mod97Checksum the checksum is the 2 check digits of ISO 7064 MOD 97-10 (as in IBAN),
+// the body holds digits and letters, letters counting A=10 to Z=35
+func mod97Checksum(body, checksum string) bool {
+	if len(checksum) != 2 {
+		return false
+	}
+	check, err := strconv.Atoi(checksum)
+	if err != nil || check < 2 || check > 98 {
+		return false
+	}
+	var rem int
+	for _, c := range strings.ToUpper(body) {
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return (rem*100+check)%97 == 1
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package checksum; import "validator.proto";
+message Known { string id = 1 [(validator.field) = {string_checksum: "crc32"}]; }
+message Custom { string id = 1 [(validator.field) = {string_checksum: "even"}]; }`)
+	even := func(body, checksum string) bool { return len(body)%2 == 0 && checksum == "0" }
+	for _, c := range []struct {
+		name, msg, json string
+		opts            []Option
+		compileErr      bool
+		illegal         bool
+	}{
+		{"built-in legal", "Known", `{"id":"abc-352441c2"}`, nil, false, false},
+		{"built-in illegal", "Known", `{"id":"abc-00000000"}`, nil, false, true},
+		{"registered legal", "Custom", `{"id":"ab-0"}`, []Option{WithChecksum("even", even)}, false, false},
+		{"registered illegal", "Custom", `{"id":"abc-0"}`, []Option{WithChecksum("even", even)}, false, true},
+		{"unregistered", "Custom", `{"id":"ab-0"}`, nil, true, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(c.opts...)
+			if err := v.Register(fd.Messages().ByName(protoreflect.Name(c.msg))); (err != nil) != c.compileErr {
+				t.Fatalf("Register: %v", err)
+			}
+			err := v.Validate(newMsg(t, fd, c.msg, c.json))
+			if !c.illegal && err != nil || c.illegal && !MatchViolation(err, "id", "StringChecksum") {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	if issues := LintMessage(fd.Messages().ByName("Known")); len(issues) != 0 {
+		t.Fatal(issues)
+	}
+	issues := LintMessage(fd.Messages().ByName("Custom"))
+	if len(issues) != 1 || issues[0].Rule != "StringChecksum" {
+		t.Fatal(issues)
+	}
+}
@@ -0,0 +1,62 @@
+// Command protovalid-diff compare the validator annotations of two descriptor sets, e.g. the previous and the current release
+// built with protoc --include_imports --descriptor_set_out, and print every added, removed, tightened, loosened or changed rule.
+// It exits with status 1 if a change may reject messages accepted by the old build.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+func main() {
+	os.Exit(run(os.Args[0], os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run run the command with args, returning the exit status:
+// 0 without breaking change, 1 if a change is breaking, 2 on a usage or load error
+func run(name string, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	breakingOnly := flags.Bool("breaking", false, "print the breaking changes only")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: %s [-breaking] old.pb new.pb\n", name)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	old, err := validator.LoadDescriptorSet(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	new, err := validator.LoadDescriptorSet(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	breaking := 0
+	for _, change := range validator.DiffRules(old.Files(), new.Files()) {
+		if change.Breaking() {
+			breaking++
+		} else if *breakingOnly {
+			continue
+		}
+		fmt.Fprintln(stdout, change)
+	}
+	if breaking > 0 {
+		fmt.Fprintf(stderr, "%d breaking changes\n", breaking)
+		return 1
+	}
+	return 0
+}
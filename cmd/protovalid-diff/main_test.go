@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// writeDescriptorSet compile src and write its descriptor set, without validator.proto, to dir/name
+func writeDescriptorSet(t *testing.T, dir, name, src string) string {
+	validatorFile, err := desc.WrapFile(validator.File_validator_proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := protoparse.Parser{
+		Accessor: protoparse.FileContentsFromMap(map[string]string{"test.proto": src}),
+		LookupImport: func(path string) (*desc.FileDescriptor, error) {
+			return validatorFile, nil
+		},
+	}
+	files, err := parser.ParseFiles("test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(files[0].UnwrapFile())}}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	header := `syntax = "proto3"; package diff; import "validator.proto";`
+	old := writeDescriptorSet(t, dir, "old.pb", header+`message Item {
+  string name = 1 [(validator.field) = {length_lt: 10}];
+  int64 count = 2 [(validator.field) = {int_gt: 0}];
+}`)
+	loosened := writeDescriptorSet(t, dir, "loosened.pb", header+`message Item {
+  string name = 1 [(validator.field) = {length_lt: 20}];
+  int64 count = 2 [(validator.field) = {int_gt: 0}];
+}`)
+	tightened := writeDescriptorSet(t, dir, "tightened.pb", header+`message Item {
+  string name = 1 [(validator.field) = {length_lt: 20}];
+  int64 count = 2 [(validator.field) = {int_gt: 1}];
+}`)
+	broken := filepath.Join(dir, "broken.pb")
+	if err := os.WriteFile(broken, []byte{0x0a, 0x05, 'a'}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		name   string
+		args   []string
+		status int
+		stdout []string
+	}{
+		{"same", []string{old, old}, 0, nil},
+		{"loosened", []string{old, loosened}, 0, []string{"diff.Item.name: LengthLt loosened: 10 -> 20"}},
+		{"breaking", []string{old, tightened}, 1, []string{"diff.Item.count: IntGt tightened: 0 -> 1", "diff.Item.name: LengthLt loosened: 10 -> 20"}},
+		{"breaking only", []string{"-breaking", old, tightened}, 1, []string{"diff.Item.count: IntGt tightened: 0 -> 1"}},
+		{"breaking only without breaking change", []string{"-breaking", old, loosened}, 0, nil},
+		{"malformed descriptor set", []string{old, broken}, 2, nil},
+		{"missing descriptor set", []string{filepath.Join(dir, "missing.pb"), old}, 2, nil},
+		{"one descriptor set", []string{old}, 2, nil},
+		{"unknown flag", []string{"-x", old, old}, 2, nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run("protovalid-diff", c.args, &stdout, &stderr); status != c.status {
+				t.Fatalf("status %d: %s%s", status, stdout.String(), stderr.String())
+			}
+			want := ""
+			if len(c.stdout) > 0 {
+				want = strings.Join(c.stdout, "\n") + "\n"
+			}
+			if stdout.String() != want {
+				t.Fatalf("got\n%s", stdout.String())
+			}
+			if c.status != 0 && stderr.Len() == 0 {
+				t.Fatal("want the error on stderr")
+			}
+		})
+	}
+}
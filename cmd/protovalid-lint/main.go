@@ -0,0 +1,118 @@
+//go:build !tinygo && !purereflect
+
+// Command protovalid-lint parse .proto sources and lint their validator annotations,
+// e.g. protovalid-lint -I proto proto/order.proto.
+// With -html, an HTML rule report per package is written into a directory as well.
+// With -buf, the equivalent buf.validate annotations of every field are printed instead of linting.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// importPaths repeated -I flag
+type importPaths []string
+
+// String implement flag.Value
+func (p *importPaths) String() string {
+	return strings.Join(*p, ",")
+}
+
+// Set implement flag.Value
+func (p *importPaths) Set(path string) error {
+	*p = append(*p, path)
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[0], os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run run the command with args, returning the exit status:
+// 0 without issue, 1 if issues were found, 2 on a usage, parse or write error
+func run(name string, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var paths importPaths
+	flags.Var(&paths, "I", "import path, may be repeated")
+	htmlDir := flags.String("html", "", "write an HTML rule report per package into this directory")
+	buf := flags.Bool("buf", false, "print the equivalent buf.validate annotations instead of linting")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: %s [-I path]... file.proto...\n", name)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	validatorFile, err := desc.WrapFile(validator.File_validator_proto)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	parser := protoparse.Parser{
+		ImportPaths: paths,
+		LookupImport: func(path string) (*desc.FileDescriptor, error) {
+			//validator.proto is served from the linked package if it is not found in the import paths
+			if path == validatorFile.GetName() {
+				return validatorFile, nil
+			}
+			return nil, os.ErrNotExist
+		},
+	}
+	files, err := parser.ParseFiles(flags.Args()...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if *htmlDir != "" {
+		fds := make([]protoreflect.FileDescriptor, len(files))
+		for i, fd := range files {
+			fds[i] = fd.UnwrapFile()
+		}
+		if err := validator.WriteHTMLReports(*htmlDir, fds...); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+
+	if *buf {
+		for _, fd := range files {
+			for _, export := range validator.ExportBufValidate(fd.UnwrapFile()) {
+				fmt.Fprintf(stdout, "%s: %s [\n  %s\n]\n", fd.GetName(), export.Field.FullName(), strings.Join(export.Options, ",\n  "))
+				if len(export.Unsupported) > 0 {
+					fmt.Fprintf(stdout, "  // no equivalent: %s\n", strings.Join(export.Unsupported, ", "))
+				}
+			}
+		}
+		return 0
+	}
+
+	count := 0
+	for _, fd := range files {
+		for _, issue := range validator.LintFile(fd.UnwrapFile()) {
+			fmt.Fprintf(stdout, "%s: %s\n", fd.GetName(), issue)
+			count++
+		}
+	}
+	if count > 0 {
+		fmt.Fprintf(stderr, "%d issues\n", count)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+//go:build !tinygo && !purereflect
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testSources .proto sources of the test directory
+var testSources = map[string]string{
+	"clean.proto": `syntax = "proto3"; package lint; import "validator.proto";
+message Clean { string name = 1 [(validator.field) = {length_gt: 1, length_lt: 10}]; }`,
+	"bad.proto": `syntax = "proto3"; package lint; import "validator.proto";
+message Bad {
+  int64 count = 1 [(validator.field) = {int_gt: 5, int_lt: 6}];
+  string host = 2 [(validator.field) = {ip_private: true, ip_public: true}];
+  message Inner { int32 port = 1 [(validator.field) = {int_port_allow_zero: true}]; }
+}`,
+	"sub/imported.proto": `syntax = "proto3"; package lint.sub; import "validator.proto";
+message Imported { bool on = 1 [(validator.field) = {regex: "x"}]; }`,
+	"importer.proto": `syntax = "proto3"; package lint; import "imported.proto";
+message Importer { lint.sub.Imported imported = 1; }`,
+	"broken.proto": `syntax = "proto3"; message {`,
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range testSources {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []struct {
+		name   string
+		args   []string
+		status int
+		stdout []string
+	}{
+		{"clean", []string{"-I", dir, "clean.proto"}, 0, nil},
+		{"issues", []string{"-I", dir, "clean.proto", "bad.proto"}, 1, []string{
+			"bad.proto: lint.Bad.count: IntLt: no integer is greater than 5 and smaller than 6",
+			"bad.proto: lint.Bad.host: IpPublic: an address can not be both private and public",
+			"bad.proto: lint.Bad.Inner.port: IntPortAllowZero: has no effect without int_port",
+		}},
+		{"import path", []string{"-I", dir, "-I", filepath.Join(dir, "sub"), "importer.proto"}, 0, nil},
+		{"imported file", []string{"-I", filepath.Join(dir, "sub"), "imported.proto"}, 1, []string{
+			"imported.proto: lint.sub.Imported.on: Regex: does not apply to a bool field",
+		}},
+		{"buf", []string{"-I", dir, "-buf", "clean.proto"}, 0, []string{
+			"clean.proto: lint.Clean.name [",
+			"  (buf.validate.field).string.min_bytes = 2,",
+			"  (buf.validate.field).string.max_bytes = 9",
+			"]",
+		}},
+		{"parse error", []string{"-I", dir, "broken.proto"}, 2, nil},
+		{"missing file", []string{"-I", dir, "missing.proto"}, 2, nil},
+		{"no file", nil, 2, nil},
+		{"unknown flag", []string{"-x", "clean.proto"}, 2, nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run("protovalid-lint", c.args, &stdout, &stderr); status != c.status {
+				t.Fatalf("status %d: %s%s", status, stdout.String(), stderr.String())
+			}
+			want := ""
+			if len(c.stdout) > 0 {
+				want = strings.Join(c.stdout, "\n") + "\n"
+			}
+			if stdout.String() != want {
+				t.Fatalf("got\n%s", stdout.String())
+			}
+			if c.status != 0 && stderr.Len() == 0 {
+				t.Fatal("want the error on stderr")
+			}
+		})
+	}
+}
+
+func TestRunHTML(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "clean.proto"), []byte(testSources["clean.proto"]), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	if status := run("protovalid-lint", []string{"-I", dir, "-html", out, "clean.proto"}, &stdout, &stderr); status != 0 {
+		t.Fatal(stderr.String())
+	}
+	page, err := os.ReadFile(filepath.Join(out, "lint.html"))
+	if err != nil || !bytes.Contains(page, []byte(`id="lint.Clean"`)) {
+		t.Fatal(err)
+	}
+	//the report directory must exist
+	if status := run("protovalid-lint", []string{"-I", dir, "-html", filepath.Join(out, "missing"), "clean.proto"}, &stdout, &stderr); status != 2 {
+		t.Fatal(status)
+	}
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"fmt"
+)
+
+// WithCollapsedElements collapse the collected violations of elements of the same repeated field failing the same rule
+// into the violation of the first element, counting them and keeping up to samples element indexes (3 if samples <= 0),
+// to keep the reports of large lists readable. See ValidError.Count and ValidError.Indices.
+func WithCollapsedElements(samples int) Option {
+	if samples <= 0 {
+		samples = 3
+	}
+	return func(v *Validator) {
+		v.collapseSamples = samples
+	}
+}
+
+// collapseKey violations of the same rule of the elements of the same repeated field share a key
+type collapseKey struct {
+	path  string
+	rule  string
+	value string
+}
+
+// collapse collapse the violations of elements of the same repeated field failing the same rule, keeping their order
+func (v *Validator) collapse(errs []error) []error {
+	seen := make(map[collapseKey]*ValidError)
+	out := errs[:0]
+	for _, err := range errs {
+		e, ok := err.(*ValidError)
+		if !ok {
+			out = append(out, err)
+			continue
+		}
+		i := lastIndexed(e.path)
+		if i < 0 {
+			out = append(out, err)
+			continue
+		}
+		//the path with the innermost index left out
+		path := append([]PathElement(nil), e.path...)
+		path[i].Index = -1
+		key := collapseKey{path: formatPath(path, PathDotted), rule: e.validKey, value: fmt.Sprint(e.validValue)}
+		first, ok := seen[key]
+		if !ok {
+			e.count, e.indices = 1, []int{e.path[i].Index}
+			seen[key] = e
+			out = append(out, err)
+			continue
+		}
+		first.count++
+		if len(first.indices) < v.collapseSamples {
+			first.indices = append(first.indices, e.path[i].Index)
+		}
+	}
+	return out
+}
+
+// lastIndexed position of the innermost element of a repeated field in a path, -1 if none
+func lastIndexed(path []PathElement) int {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].Index >= 0 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCollapsedElements(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package collapse; import "validator.proto";
+message Order {
+  repeated string skus = 1 [(validator.field) = {length_gt: 1, regex: "^[a-z]+$"}];
+  repeated Item items = 2;
+  string note = 3 [(validator.field) = {length_gt: 1}];
+}
+message Item { repeated string tags = 1 [(validator.field) = {length_gt: 1}]; }`)
+	type violation struct {
+		Path    string
+		Rule    string
+		Count   int
+		Indices []int
+	}
+	for _, c := range []struct {
+		name       string
+		samples    int
+		json       string
+		violations []violation
+	}{
+		{"legal", 3, `{"skus":["ab"],"note":"ab"}`, nil},
+		{"single element", 3, `{"skus":["a"],"note":"ab"}`, []violation{{"skus[0]", "LengthGt", 1, []int{0}}}},
+		{"elements of a field", 3, `{"skus":["a","ok","b","c"],"note":"ab"}`, []violation{{"skus[0]", "LengthGt", 3, []int{0, 2, 3}}}},
+		{"samples", 2, `{"skus":["a","b","c","d"],"note":"ab"}`, []violation{{"skus[0]", "LengthGt", 4, []int{0, 1}}}},
+		{"default samples", 0, `{"skus":["a","b","c","d"],"note":"ab"}`, []violation{{"skus[0]", "LengthGt", 4, []int{0, 1, 2}}}},
+		{"negative samples", -1, `{"skus":["a","b","c","d"],"note":"ab"}`, []violation{{"skus[0]", "LengthGt", 4, []int{0, 1, 2}}}},
+		{"rules collapsed apart", 3, `{"skus":["A","bb","C1"],"note":"ab"}`, []violation{
+			{"skus[0]", "LengthGt", 1, []int{0}}, {"skus[0]", "Regex", 2, []int{0, 2}}}},
+		{"fields without index", 3, `{"skus":["a","b"]}`, []violation{
+			{"skus[0]", "LengthGt", 2, []int{0, 1}}, {"note", "LengthGt", 1, nil}}},
+		{"innermost index", 3, `{"items":[{"tags":["a","b"]},{"tags":["c"]}],"note":"ab"}`, []violation{
+			{"items[0].tags[0]", "LengthGt", 2, []int{0, 1}}, {"items[1].tags[0]", "LengthGt", 1, []int{0}}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var got []violation
+			for _, e := range ValidErrors(New(WithCollapsedElements(c.samples)).ValidateAll(newMsg(t, fd, "Order", c.json))) {
+				got = append(got, violation{e.Path(), e.Rule(), e.Count(), e.Indices()})
+			}
+			if !reflect.DeepEqual(got, c.violations) {
+				t.Fatalf("got %v, want %v", got, c.violations)
+			}
+		})
+	}
+	//without the option every element is reported
+	errs := ValidErrors(New().ValidateAll(newMsg(t, fd, "Order", `{"skus":["a","b"],"note":"ab"}`)))
+	if len(errs) != 2 || errs[0].Count() != 1 || errs[0].Indices() != nil {
+		t.Fatal(errs)
+	}
+	//the first violation is not collapsed
+	err := New(WithCollapsedElements(3)).Validate(newMsg(t, fd, "Order", `{"skus":["a","b"],"note":"ab"}`))
+	if e := FindViolation(err, "skus[0]", "LengthGt"); e == nil || e.Count() != 1 || strings.Contains(err.Error(), "skus[1]") {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Program compiled verification rules of a message and of every message it references,
+// safe for concurrent use, e.g. compiled at startup and shared by the request handlers
+type Program struct {
+	v    *Validator
+	desc protoreflect.MessageDescriptor
+}
+
+// Compile compile the rules of md with a validator created with opts.
+// Configuration problems are returned, the program is usable even if there are some.
+func Compile(md protoreflect.MessageDescriptor, opts ...Option) (*Program, error) {
+	return New(opts...).Compile(md)
+}
+
+// Compile compile the rules of md and of every message it references for the validator, see Register
+func (v *Validator) Compile(md protoreflect.MessageDescriptor) (*Program, error) {
+	err := v.Register(md)
+	return &Program{v: v, desc: md}, err
+}
+
+// Descriptor descriptor of the message of the program
+func (p *Program) Descriptor() protoreflect.MessageDescriptor {
+	return p.desc
+}
+
+// Validate verify whether a message of the program's type is legal
+func (p *Program) Validate(msg proto.Message) error {
+	if err := p.check(msg); err != nil {
+		return err
+	}
+	return p.v.Validate(msg)
+}
+
+// ValidateAll verify whether a message of the program's type is legal, collecting every violation
+func (p *Program) ValidateAll(msg proto.Message) error {
+	if err := p.check(msg); err != nil {
+		return err
+	}
+	return p.v.ValidateAll(msg)
+}
+
+// check check that msg is of the program's type
+func (p *Program) check(msg proto.Message) error {
+	if msg == nil || isNilMessage(msg.ProtoReflect()) {
+		return nil
+	}
+	if name := msg.ProtoReflect().Descriptor().FullName(); name != p.desc.FullName() {
+		return fmt.Errorf("[proto valid]msg[%s] is not a %s", name, p.desc.FullName())
+	}
+	return nil
+}
+
+// Messages full names of the compiled messages, the message of the program first
+func (p *Program) Messages() []protoreflect.FullName {
+	progs := p.programs()
+	names := make([]protoreflect.FullName, len(progs))
+	for i, prog := range progs {
+		names[i] = prog.desc.FullName()
+	}
+	return names
+}
+
+// RuleCount number of rules set on the fields of the compiled messages, enforced and shadow,
+// and of their message-level rules (see MsgValidator)
+func (p *Program) RuleCount() int {
+	var n int
+	for _, prog := range p.programs() {
+		if prog.message != nil {
+			n += len(prog.message.exists) + len(prog.message.atLeastOneOf)
+		}
+		for _, fp := range prog.fields {
+			for _, rule := range []*FieldValidator{fp.rule, fp.shadow} {
+				if rule == nil {
+					continue
+				}
+				rule.ProtoReflect().Range(func(protoreflect.FieldDescriptor, protoreflect.Value) bool {
+					n++
+					return true
+				})
+			}
+		}
+	}
+	return n
+}
+
+// EstimatedCost relative estimated cost of a validation, in the units of the cost-based rule order (see OrderCost)
+func (p *Program) EstimatedCost() int {
+	var cost int
+	for _, prog := range p.programs() {
+		for _, fp := range prog.fields {
+			cost += fieldCost(fp)
+		}
+	}
+	return cost
+}
+
+// programs the compiled programs of the message of the program and of the messages it references
+func (p *Program) programs() []*program {
+	var progs []*program
+	seen := make(map[protoreflect.MessageDescriptor]bool)
+	var walk func(md protoreflect.MessageDescriptor)
+	walk = func(md protoreflect.MessageDescriptor) {
+		if seen[md] {
+			return
+		}
+		seen[md] = true
+		prog := p.v.progs.Get(md, p.v, nil, p.v.groups)
+		progs = append(progs, prog)
+		for _, fp := range prog.fields {
+			if hasMessage(fp.field) {
+				field := fp.field
+				if field.IsMap() {
+					field = field.MapValue()
+				}
+				walk(field.Message())
+			}
+		}
+	}
+	walk(p.desc)
+	return progs
+}
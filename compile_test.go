@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProgram(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package compile; import "validator.proto";
+message Line { string sku = 1 [(validator.field) = {length_gt: 1, regex: "^S"}]; }
+message Order {
+  option (validator.message) = {msg_exists: ["line"]};
+  Line line = 1;
+  repeated Line lines = 2 [(validator.field) = {repeated_count_max: 3}];
+  int64 qty = 3 [(validator.field) = {int_gt: 0}];
+  string note = 4;
+}
+message Other { string sku = 1; }`)
+	prog, err := Compile(fd.Messages().ByName("Order"), WithAggregation(AggregateAll))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prog.Descriptor().FullName() != "compile.Order" {
+		t.Fatal(prog.Descriptor().FullName())
+	}
+	if names := prog.Messages(); !reflect.DeepEqual(names, []protoreflect.FullName{"compile.Order", "compile.Line"}) {
+		t.Fatal(names)
+	}
+	//msg_exists, repeated_count_max, int_gt, then length_gt and regex of the line once
+	if n := prog.RuleCount(); n != 5 {
+		t.Fatal(n)
+	}
+	//line 1+50, lines (1+1+50)*4, qty 1+1, sku 1+1+10
+	if cost := prog.EstimatedCost(); cost != 273 {
+		t.Fatal(cost)
+	}
+
+	if err := prog.Validate(newMsg(t, fd, "Order", `{"line":{"sku":"S1"},"qty":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := prog.Validate(newMsg(t, fd, "Order", `{"line":{"sku":"S"},"qty":"1"}`)); !MatchViolation(err, "line.sku", "LengthGt") {
+		t.Fatal(err)
+	}
+	if keys := violationKeys(prog.ValidateAll(newMsg(t, fd, "Order", `{"lines":[{"sku":"a"}]}`))); strings.Join(keys, ",") !=
+		"line:MsgExists,lines[0].sku:LengthGt,lines[0].sku:Regex,qty:IntGt" {
+		t.Fatal(keys)
+	}
+	//messages of another type are rejected instead of validated with their own rules
+	other := newMsg(t, fd, "Other", `{"sku":"a"}`)
+	for _, validate := range []func(msg proto.Message) error{prog.Validate, prog.ValidateAll} {
+		if err := validate(other); err == nil || !strings.Contains(err.Error(), "msg[compile.Other] is not a compile.Order") || len(ValidErrors(err)) > 0 {
+			t.Fatal(err)
+		}
+		if err := validate(nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCompileConfig(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package compile; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {regex: "("}]; string name = 2 [(validator.field) = {length_gt: 1}]; }`)
+	prog, err := Compile(fd.Messages().ByName("Item"), WithAggregation(AggregateAll), WithLogger(&testLogger{}))
+	if err == nil || prog == nil {
+		t.Fatalf("%v %v", prog, err)
+	}
+	//the program is usable despite the configuration problem, the invalid regex rejects every value
+	if keys := violationKeys(prog.Validate(newMsg(t, fd, "Item", `{"sku":"a","name":"a"}`))); strings.Join(keys, ",") != "sku:Regex,name:LengthGt" {
+		t.Fatal(keys)
+	}
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"encoding/json"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"io"
+)
+
+// FieldConstraints constraints of a field in the constraint export
+type FieldConstraints struct {
+	// Type kind of the field (e.g. "string"), or the full name of its message or enum
+	Type string `json:"type"`
+	// Repeated whether the field is repeated, the rules apply to every element
+	Repeated bool `json:"repeated,omitempty"`
+	// Map whether the field is a map, the rules apply to every key
+	Map bool `json:"map,omitempty"`
+	// Rules enforced rules keyed by the JSON name of the rule field (e.g. "lengthGt"), enums by value name
+	Rules map[string]interface{} `json:"rules"`
+}
+
+// ConstraintExport constraints of messages keyed by message full name, then by field JSON name
+type ConstraintExport struct {
+	Messages map[string]map[string]*FieldConstraints `json:"messages"`
+}
+
+// constraintSkip rule fields evaluated server side only, left out of the constraint export
+var constraintSkip = map[protoreflect.Name]bool{
+	"since_version":      true,
+	"until_version":      true,
+	"versioned":          true,
+	"shadow":             true,
+	"groups":             true,
+	"go_func":            true,
+	"pre_validated":      true,
+	"priority":           true,
+	"nested":             true,
+	"string_not_similar": true,
+}
+
+// ExportConstraints write the constraints of the messages of files enforced by the default validator as compact JSON
+func ExportConstraints(w io.Writer, files ...protoreflect.FileDescriptor) error {
+	return std().ExportConstraints(w, files...)
+}
+
+// ExportConstraints write the constraints of the messages of files (and their nested messages) as compact JSON,
+// for embedding in generated clients so front-ends can mirror server-side validation.
+// Rules are scoped to the schema version and validation groups of the validator, shadow rules and rules evaluated
+// server side only (e.g. go_func) are left out. Integers are written as JSON numbers.
+func (v *Validator) ExportConstraints(w io.Writer, files ...protoreflect.FileDescriptor) error {
+	export := &ConstraintExport{Messages: make(map[string]map[string]*FieldConstraints)}
+	for _, fd := range files {
+		v.exportConstraints(export, fd.Messages())
+	}
+	return json.NewEncoder(w).Encode(export)
+}
+
+// exportConstraints add the constraints of messages and their nested messages to export
+func (v *Validator) exportConstraints(export *ConstraintExport, messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			rule := v.groups.scope(v.scopeRule(getRule(field, v.resolver)))
+			if rule == nil || rule.GetShadow() {
+				continue
+			}
+			rules := constraintRules(rule)
+			if len(rules) == 0 {
+				continue
+			}
+			if export.Messages[string(md.FullName())] == nil {
+				export.Messages[string(md.FullName())] = make(map[string]*FieldConstraints)
+			}
+			export.Messages[string(md.FullName())][field.JSONName()] = &FieldConstraints{
+				Type:     constraintType(field),
+				Repeated: field.IsList(),
+				Map:      field.IsMap(),
+				Rules:    rules,
+			}
+		}
+		v.exportConstraints(export, md.Messages())
+	}
+}
+
+// constraintRules set rule fields keyed by JSON name
+func constraintRules(rule *FieldValidator) map[string]interface{} {
+	return constraintFields(rule.ProtoReflect())
+}
+
+// constraintFields set fields of a rule message keyed by JSON name, e.g. of the money rule
+func constraintFields(m protoreflect.Message) map[string]interface{} {
+	rules := make(map[string]interface{})
+	m.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if constraintSkip[fd.Name()] {
+			return true
+		}
+		if fd.IsList() {
+			list := value.List()
+			values := make([]interface{}, list.Len())
+			for i := range values {
+				values[i] = constraintValue(fd, list.Get(i))
+			}
+			rules[fd.JSONName()] = values
+		} else {
+			rules[fd.JSONName()] = constraintValue(fd, value)
+		}
+		return true
+	})
+	return rules
+}
+
+// constraintValue value of a rule field in the constraint export, an element for a repeated field
+func constraintValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(value.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int32(value.Enum())
+	case protoreflect.MessageKind:
+		return constraintFields(value.Message())
+	}
+	return value.Interface()
+}
+
+// constraintType type of a field in the constraint export, of its keys for a map
+func constraintType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		field = field.MapKey()
+	}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(field.Enum().FullName())
+	}
+	return field.Kind().String()
+}
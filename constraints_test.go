@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestExportConstraints(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package export; import "validator.proto";
+message User {
+  string user_name = 1 [(validator.field) = {length_gt: 1, length_lt: 10, length_unit: LENGTH_UNIT_RUNES}];
+  string note = 2;
+  repeated int64 scores = 3 [(validator.field) = {int_gt: 0, go_func: "score"}];
+  map<string, string> tags = 4 [(validator.field) = {map_key_in: ["a", "b"]}];
+  string password = 5 [(validator.field) = {hash_format: [HASH_FORMAT_BCRYPT, HASH_FORMAT_ARGON2]}];
+  string code = 6 [(validator.field) = {regex: "^[A-Z]+$", since_version: "2"}];
+  string legacy = 7 [(validator.field) = {regex: "^[a-z]+$", until_version: "2"}];
+  string email = 8 [(validator.field) = {length_gt: 3, groups: ["create"]}];
+  string nick = 9 [(validator.field) = {length_gt: 3, shadow: true}];
+  string server = 10 [(validator.field) = {go_func: "server"}];
+  Address address = 11;
+  message Address { string zip = 1 [(validator.field) = {string_postal_code: "US"}]; }
+}
+message Empty { string a = 1; }`)
+	user := `"userName":{"type":"string","rules":{"lengthGt":1,"lengthLt":10,"lengthUnit":"LENGTH_UNIT_RUNES"}},` +
+		`"scores":{"type":"int64","repeated":true,"rules":{"intGt":0}},` +
+		`"tags":{"type":"string","map":true,"rules":{"mapKeyIn":["a","b"]}},` +
+		`"password":{"type":"string","rules":{"hashFormat":["HASH_FORMAT_BCRYPT","HASH_FORMAT_ARGON2"]}}`
+	address := `"export.User.Address":{"zip":{"type":"string","rules":{"stringPostalCode":"US"}}}`
+	for _, c := range []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"unversioned", nil, `{"messages":{"export.User":{` + user + `,"code":{"type":"string","rules":{"regex":"^[A-Z]+$"}}},` + address + `}}`},
+		{"old version", []Option{WithSchemaVersion("1")}, `{"messages":{"export.User":{` + user + `,"legacy":{"type":"string","rules":{"regex":"^[a-z]+$"}}},` + address + `}}`},
+		{"group", []Option{WithGroups("create")}, `{"messages":{"export.User":{` + user + `,"code":{"type":"string","rules":{"regex":"^[A-Z]+$"}},"email":{"type":"string","rules":{"lengthGt":3}}},` + address + `}}`},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := New(c.opts...).ExportConstraints(&buf, fd); err != nil {
+				t.Fatal(err)
+			}
+			var got, want interface{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(c.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(want)
+			if !bytes.Equal(gotJSON, wantJSON) {
+				t.Errorf("got %s\nwant %s", gotJSON, wantJSON)
+			}
+		})
+	}
+	//no file
+	var buf bytes.Buffer
+	if err := ExportConstraints(&buf); err != nil || buf.String() != "{\"messages\":{}}\n" {
+		t.Fatal(buf.String(), err)
+	}
+}
@@ -0,0 +1,160 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"time"
+)
+
+const (
+	// dateFullName full name of google.type.Date
+	dateFullName protoreflect.FullName = "google.type.Date"
+	// timeOfDayFullName full name of google.type.TimeOfDay
+	timeOfDayFullName protoreflect.FullName = "google.type.TimeOfDay"
+	// dateLayout layout of the bounds of the date rule
+	dateLayout = "2006-01-02"
+)
+
+// civilDate calendar date comparable as a number, e.g. 20240229
+type civilDate int
+
+// String format as YYYY-MM-DD
+func (d civilDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d/10000, d/100%100, d%100)
+}
+
+// newCivilDate civil date of a year, month and day, false if it isn't a valid calendar date
+func newCivilDate(year, month, day int64) (civilDate, bool) {
+	if year < 1 || year > 9999 || month < 1 || month > 12 || day < 1 || day > 31 {
+		return 0, false
+	}
+	t := time.Date(int(year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+	if t.Day() != int(day) {
+		//normalized, e.g. February 30 into March 1
+		return 0, false
+	}
+	return civilDate(year*10000 + month*100 + day), true
+}
+
+// parseCivilDate parse a YYYY-MM-DD date
+func parseCivilDate(s string) (civilDate, bool) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return 0, false
+	}
+	return newCivilDate(int64(t.Year()), int64(t.Month()), int64(t.Day()))
+}
+
+// parseTimeOfDay nanoseconds since midnight of a HH:MM or HH:MM:SS time of day
+func parseTimeOfDay(s string) (time.Duration, bool) {
+	if !validTimeOfDay(s) {
+		return 0, false
+	}
+	var h, m, sec int
+	if len(s) == len("15:04") {
+		_, _ = fmt.Sscanf(s, "%d:%d", &h, &m)
+	} else {
+		_, _ = fmt.Sscanf(s, "%d:%d:%d", &h, &m, &sec)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second, true
+}
+
+// checkDate check a google.type.Date with the date rule
+func (v *validator) checkDate(field protoreflect.FieldDescriptor, msg protoreflect.Message, rule *FieldValidator) error {
+	dateRule := rule.GetDate()
+	if dateRule == nil || msg.Descriptor().FullName() != dateFullName {
+		return nil
+	}
+	fields := msg.Descriptor().Fields()
+	year, month, day := msg.Get(fields.ByName("year")).Int(), msg.Get(fields.ByName("month")).Int(), msg.Get(fields.ByName("day")).Int()
+	date, ok := newCivilDate(year, month, day)
+	if !ok {
+		return v.fail(field, "Date", "valid calendar date", fmt.Sprintf("%04d-%02d-%02d", year, month, day))
+	}
+	if min, ok := parseCivilDate(dateRule.GetMin()); ok && date < min {
+		if err := v.fail(field, "DateMin", dateRule.GetMin(), date.String()); err != nil {
+			return err
+		}
+	}
+	if max, ok := parseCivilDate(dateRule.GetMax()); ok && date > max {
+		if err := v.fail(field, "DateMax", dateRule.GetMax(), date.String()); err != nil {
+			return err
+		}
+	}
+	if dateRule.GetNotBeforeToday() {
+		y, m, d := v.now().Date()
+		if today, _ := newCivilDate(int64(y), int64(m), int64(d)); date < today {
+			if err := v.fail(field, "DateNotBeforeToday", today.String(), date.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkTimeOfDay check a google.type.TimeOfDay with the time_of_day rule
+func (v *validator) checkTimeOfDay(field protoreflect.FieldDescriptor, msg protoreflect.Message, rule *FieldValidator) error {
+	timeRule := rule.GetTimeOfDay()
+	if timeRule == nil || msg.Descriptor().FullName() != timeOfDayFullName {
+		return nil
+	}
+	fields := msg.Descriptor().Fields()
+	h, m := msg.Get(fields.ByName("hours")).Int(), msg.Get(fields.ByName("minutes")).Int()
+	s, n := msg.Get(fields.ByName("seconds")).Int(), msg.Get(fields.ByName("nanos")).Int()
+	value := fmt.Sprintf("%02d:%02d:%02d.%09d", h, m, s, n)
+	if h < 0 || h > 23 || m < 0 || m > 59 || s < 0 || s > 60 || n < 0 || n > 999999999 {
+		return v.fail(field, "TimeOfDay", "valid time of day", value)
+	}
+	clock := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(n)
+	if min, ok := parseTimeOfDay(timeRule.GetMin()); ok && clock < min {
+		if err := v.fail(field, "TimeOfDayMin", timeRule.GetMin(), value); err != nil {
+			return err
+		}
+	}
+	if max, ok := parseTimeOfDay(timeRule.GetMax()); ok && clock > max {
+		if err := v.fail(field, "TimeOfDayMax", timeRule.GetMax(), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDateRule check that the bounds of the date rule are YYYY-MM-DD dates
+func checkDateRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	date := rule.GetDate()
+	if date == nil {
+		return nil
+	}
+	if _, ok := parseCivilDate(date.GetMin()); date.Min != nil && !ok {
+		return fmt.Errorf("[proto valid]field[%s] date min[%s] is not a YYYY-MM-DD date", field.FullName(), date.GetMin())
+	}
+	if _, ok := parseCivilDate(date.GetMax()); date.Max != nil && !ok {
+		return fmt.Errorf("[proto valid]field[%s] date max[%s] is not a YYYY-MM-DD date", field.FullName(), date.GetMax())
+	}
+	return nil
+}
+
+// checkTimeOfDayRule check that the bounds of the time_of_day rule are HH:MM or HH:MM:SS times
+func checkTimeOfDayRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	clock := rule.GetTimeOfDay()
+	if clock == nil {
+		return nil
+	}
+	if _, ok := parseTimeOfDay(clock.GetMin()); clock.Min != nil && !ok {
+		return fmt.Errorf("[proto valid]field[%s] time_of_day min[%s] is not a HH:MM or HH:MM:SS time", field.FullName(), clock.GetMin())
+	}
+	if _, ok := parseTimeOfDay(clock.GetMax()); clock.Max != nil && !ok {
+		return fmt.Errorf("[proto valid]field[%s] time_of_day max[%s] is not a HH:MM or HH:MM:SS time", field.FullName(), clock.GetMax())
+	}
+	return nil
+}
+
+// isDate whether the field is a google.type.Date
+func isDate(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.MessageKind && field.Message().FullName() == dateFullName
+}
+
+// isTimeOfDay whether the field is a google.type.TimeOfDay
+func isTimeOfDay(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.MessageKind && field.Message().FullName() == timeOfDayFullName
+}
@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package date; import "validator.proto"; import "google/type/types.proto";
+message Booking {
+  google.type.Date day = 1 [(validator.field) = {date: {min: "2024-01-01", max: "2030-12-31"}}];
+  google.type.Date start = 2 [(validator.field) = {date: {not_before_today: true}}];
+  google.type.TimeOfDay at = 3 [(validator.field) = {time_of_day: {min: "09:00", max: "17:30"}}];
+}`)
+	now := time.Date(2026, 10, 17, 23, 59, 0, 0, time.UTC)
+	v := New(WithClock(func() time.Time { return now }))
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"legal", `{"day":{"year":2026,"month":10,"day":17},"start":{"year":2026,"month":10,"day":18},"at":{"hours":12}}`, "", ""},
+		{"unset", `{}`, "", ""},
+		{"min bound", `{"day":{"year":2024,"month":1,"day":1}}`, "", ""},
+		{"max bound", `{"day":{"year":2030,"month":12,"day":31}}`, "", ""},
+		{"below min", `{"day":{"year":2023,"month":12,"day":31}}`, "day", "DateMin"},
+		{"above max", `{"day":{"year":2031,"month":1,"day":1}}`, "day", "DateMax"},
+		{"leap day", `{"day":{"year":2028,"month":2,"day":29}}`, "", ""},
+		{"leap day of a common year", `{"day":{"year":2027,"month":2,"day":29}}`, "day", "Date"},
+		{"day 31 of a 30 day month", `{"day":{"year":2026,"month":4,"day":31}}`, "day", "Date"},
+		{"zero date", `{"day":{}}`, "day", "Date"},
+		{"no year", `{"day":{"month":10,"day":17}}`, "day", "Date"},
+		{"no day", `{"day":{"year":2026,"month":10}}`, "day", "Date"},
+		{"today", `{"start":{"year":2026,"month":10,"day":17}}`, "", ""},
+		{"yesterday", `{"start":{"year":2026,"month":10,"day":16}}`, "start", "DateNotBeforeToday"},
+		{"time min bound", `{"at":{"hours":9}}`, "", ""},
+		{"time max bound", `{"at":{"hours":17,"minutes":30}}`, "", ""},
+		{"zero time", `{"at":{}}`, "at", "TimeOfDayMin"},
+		{"below time min", `{"at":{"hours":8,"minutes":59,"seconds":59,"nanos":999999999}}`, "at", "TimeOfDayMin"},
+		{"above time max", `{"at":{"hours":17,"minutes":30,"nanos":1}}`, "at", "TimeOfDayMax"},
+		{"hour 24", `{"at":{"hours":24}}`, "at", "TimeOfDay"},
+		{"negative minutes", `{"at":{"hours":12,"minutes":-1}}`, "at", "TimeOfDay"},
+		{"nanos overflow", `{"at":{"hours":12,"nanos":1000000000}}`, "at", "TimeOfDay"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := v.ValidateAll(newMsg(t, fd, "Booking", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestDateRuleConfig(t *testing.T) {
+	for _, c := range []struct {
+		name, field, rule string
+		invalid           bool
+	}{
+		{"date bounds", "Date", `date: {min: "2024-02-29", max: "2030-12-31"}`, false},
+		{"date month 13", "Date", `date: {min: "2024-13-01"}`, true},
+		{"date of a common year", "Date", `date: {max: "2023-02-29"}`, true},
+		{"date without padding", "Date", `date: {min: "2024-1-1"}`, true},
+		{"time bounds", "TimeOfDay", `time_of_day: {min: "09:00", max: "17:30:59"}`, false},
+		{"time hour 24", "TimeOfDay", `time_of_day: {max: "24:00"}`, true},
+		{"time with nanos", "TimeOfDay", `time_of_day: {min: "09:00:00.5"}`, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fd := compileProto(t, `syntax = "proto3"; package date; import "validator.proto"; import "google/type/types.proto";
+message Booking { google.type.`+c.field+` day = 1 [(validator.field) = {`+c.rule+`}]; }`)
+			if err := New().Register(fd.Messages().ByName("Booking")); (err != nil) != c.invalid {
+				t.Fatal(err)
+			}
+		})
+	}
+}
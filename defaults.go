@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// hasDefaults whether default limits apply to the field
+func (v *Validator) hasDefaults(field protoreflect.FieldDescriptor) bool {
+	if v.defaultMaxRepeated > 0 && (field.IsList() || field.IsMap()) {
+		return true
+	}
+	if v.defaultMaxStringBytes > 0 {
+		if field.IsMap() {
+			return field.MapKey().Kind() == protoreflect.StringKind || field.MapValue().Kind() == protoreflect.StringKind
+		}
+		return field.Kind() == protoreflect.StringKind
+	}
+	return false
+}
+
+// checkDefaultStringBytes check the default byte length limit of a string without explicit upper bound
+func (v *validator) checkDefaultStringBytes(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if v.defaultMaxStringBytes <= 0 || v.shadow {
+		return nil
+	}
+	if rule != nil && (rule.LengthLt != nil || rule.LengthEq != nil) {
+		return nil
+	}
+	if _len := int64(len(value)); _len > v.defaultMaxStringBytes {
+		if err := v.fail(field, "DefaultMaxStringBytes", v.defaultMaxStringBytes, _len); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDefaultRepeated check the default element count limit of a repeated or map field without explicit upper bound
+func (v *validator) checkDefaultRepeated(field protoreflect.FieldDescriptor, count int64, rule *FieldValidator) error {
+	if v.defaultMaxRepeated <= 0 || v.shadow {
+		return nil
+	}
+	if rule != nil && rule.RepeatedCountMax != nil {
+		return nil
+	}
+	if count > v.defaultMaxRepeated {
+		if err := v.fail(field, "DefaultMaxRepeated", v.defaultMaxRepeated, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
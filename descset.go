@@ -0,0 +1,218 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"os"
+)
+
+// Format encoding format of a serialized message
+type Format int
+
+const (
+	// FormatBinary protobuf wire format
+	FormatBinary Format = iota
+	// FormatJSON protobuf JSON mapping
+	FormatJSON
+	// FormatText protobuf text format
+	FormatText
+)
+
+// String implement fmt.Stringer
+func (f Format) String() string {
+	switch f {
+	case FormatBinary:
+		return "binary"
+	case FormatJSON:
+		return "json"
+	case FormatText:
+		return "text"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// DescriptorSet validator of the messages described by a FileDescriptorSet,
+// no generated Go types are needed
+type DescriptorSet struct {
+	*Validator
+	files *protoregistry.Files
+	types *protoregistry.Types
+}
+
+// LoadDescriptorSet load a FileDescriptorSet file,
+// e.g. the output of protoc --include_imports --descriptor_set_out
+func LoadDescriptorSet(path string, opts ...Option) (*DescriptorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("[proto valid]unmarshal descriptor set[%s]: %w", path, err)
+	}
+	return NewDescriptorSet(set, opts...)
+}
+
+// NewDescriptorSet build a validator from a FileDescriptorSet.
+// Imports missing from the set are resolved from protoregistry.GlobalFiles.
+func NewDescriptorSet(set *descriptorpb.FileDescriptorSet, opts ...Option) (*DescriptorSet, error) {
+	s := &DescriptorSet{
+		files: &protoregistry.Files{},
+		types: &protoregistry.Types{},
+	}
+
+	pending := make(map[string]*descriptorpb.FileDescriptorProto, len(set.GetFile()))
+	for _, file := range set.GetFile() {
+		pending[file.GetName()] = file
+	}
+	for _, file := range set.GetFile() {
+		if err := s.addFile(file, pending); err != nil {
+			return nil, err
+		}
+	}
+
+	s.Validator = New(append([]Option{WithResolver(typeResolver{s.types})}, opts...)...)
+	return s, nil
+}
+
+// addFile register a file after its dependencies
+func (s *DescriptorSet) addFile(file *descriptorpb.FileDescriptorProto, pending map[string]*descriptorpb.FileDescriptorProto) error {
+	if _, ok := pending[file.GetName()]; !ok {
+		return nil
+	}
+	delete(pending, file.GetName())
+	for _, dep := range file.GetDependency() {
+		if depFile, ok := pending[dep]; ok {
+			if err := s.addFile(depFile, pending); err != nil {
+				return err
+			}
+		}
+	}
+
+	fd, err := protodesc.NewFile(file, fileResolver{s.files})
+	if err != nil {
+		return fmt.Errorf("[proto valid]build file[%s]: %w", file.GetName(), err)
+	}
+	if err := s.files.RegisterFile(fd); err != nil {
+		return fmt.Errorf("[proto valid]register file[%s]: %w", file.GetName(), err)
+	}
+	return s.registerTypes(fd.Messages(), fd.Extensions())
+}
+
+// registerTypes register dynamic types of messages and extensions, recursively
+func (s *DescriptorSet) registerTypes(messages protoreflect.MessageDescriptors, extensions protoreflect.ExtensionDescriptors) error {
+	for i := 0; i < extensions.Len(); i++ {
+		if err := s.types.RegisterExtension(dynamicpb.NewExtensionType(extensions.Get(i))); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if err := s.types.RegisterMessage(dynamicpb.NewMessageType(md)); err != nil {
+			return err
+		}
+		if err := s.registerTypes(md.Messages(), md.Extensions()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files files of the descriptor set
+func (s *DescriptorSet) Files() *protoregistry.Files {
+	return s.files
+}
+
+// Types dynamic types of the descriptor set
+func (s *DescriptorSet) Types() *protoregistry.Types {
+	return s.types
+}
+
+// ValidateNamed decode data as the message named messageFullName and verify whether it is legal
+func (s *DescriptorSet) ValidateNamed(messageFullName string, data []byte, format Format) error {
+	mt, err := s.types.FindMessageByName(protoreflect.FullName(messageFullName))
+	if err != nil {
+		return fmt.Errorf("[proto valid]find message[%s]: %w", messageFullName, err)
+	}
+	msg := mt.New().Interface()
+
+	switch format {
+	case FormatBinary:
+		err = proto.UnmarshalOptions{Resolver: s.resolver}.Unmarshal(data, msg)
+	case FormatJSON:
+		err = protojson.UnmarshalOptions{Resolver: s.resolver}.Unmarshal(data, msg)
+	case FormatText:
+		err = prototext.UnmarshalOptions{Resolver: s.resolver}.Unmarshal(data, msg)
+	default:
+		err = fmt.Errorf("unknown format %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("[proto valid]decode message[%s] as %s: %w", messageFullName, format, err)
+	}
+	return s.Validate(msg)
+}
+
+// fileResolver resolve imports from the loaded files first, then from protoregistry.GlobalFiles
+type fileResolver struct {
+	files *protoregistry.Files
+}
+
+// FindFileByPath implement protodesc.Resolver
+func (r fileResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+// FindDescriptorByName implement protodesc.Resolver
+func (r fileResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// typeResolver resolve types from the loaded types first, then from protoregistry.GlobalTypes
+type typeResolver struct {
+	types *protoregistry.Types
+}
+
+// FindExtensionByName implement protoregistry.ExtensionTypeResolver
+func (r typeResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if xt, err := r.types.FindExtensionByName(field); err == nil {
+		return xt, nil
+	}
+	return protoregistry.GlobalTypes.FindExtensionByName(field)
+}
+
+// FindExtensionByNumber implement protoregistry.ExtensionTypeResolver
+func (r typeResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	if xt, err := r.types.FindExtensionByNumber(message, field); err == nil {
+		return xt, nil
+	}
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
+
+// FindMessageByName implement protoregistry.MessageTypeResolver
+func (r typeResolver) FindMessageByName(message protoreflect.FullName) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByName(message); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(message)
+}
+
+// FindMessageByURL implement protoregistry.MessageTypeResolver
+func (r typeResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	if mt, err := r.types.FindMessageByURL(url); err == nil {
+		return mt, nil
+	}
+	return protoregistry.GlobalTypes.FindMessageByURL(url)
+}
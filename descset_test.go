@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDescriptorSet(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package descset; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 1}]; }
+message Order { repeated Item items = 1 [(validator.field) = {repeated_count_min: 1}]; }`)
+	//validator.proto is left out of the set, it is resolved from protoregistry.GlobalFiles
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(fd)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "set.pb")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	set, err := LoadDescriptorSet(path, WithAggregation(AggregateAll))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(json string, format Format) []byte {
+		msg := newMsg(t, fd, "Order", json)
+		var data []byte
+		var err error
+		switch format {
+		case FormatBinary:
+			data, err = proto.Marshal(msg)
+		case FormatJSON:
+			data, err = protojson.Marshal(msg)
+		case FormatText:
+			data, err = prototext.Marshal(msg)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	for _, format := range []Format{FormatBinary, FormatJSON, FormatText} {
+		t.Run(format.String(), func(t *testing.T) {
+			if err := set.ValidateNamed("descset.Order", encode(`{"items":[{"sku":"ab"}]}`, format), format); err != nil {
+				t.Fatal(err)
+			}
+			err := set.ValidateNamed("descset.Order", encode(`{"items":[{"sku":"ab"},{"sku":"a"}]}`, format), format)
+			if !MatchViolation(err, "items[1].sku", "LengthGt") {
+				t.Fatal(err)
+			}
+			if err := set.ValidateNamed("descset.Order", encode(`{}`, format), format); !MatchViolation(err, "items", "RepeatedCountMin") {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	for _, c := range []struct {
+		name, message string
+		data          []byte
+		format        Format
+		want          string
+	}{
+		{"unknown message", "descset.Missing", nil, FormatBinary, "find message[descset.Missing]"},
+		{"undecodable payload", "descset.Order", []byte("{"), FormatJSON, "decode message[descset.Order] as json"},
+		{"unknown format", "descset.Order", nil, Format(7), "as Format(7): unknown format"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := set.ValidateNamed(c.message, c.data, c.format)
+			if c.want == "" && err != nil || c.want != "" && (err == nil || !strings.Contains(err.Error(), c.want) || len(ValidErrors(err)) > 0) {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	if _, err := LoadDescriptorSet(filepath.Join(dir, "missing.pb")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte{0xff}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDescriptorSet(path); err == nil || !strings.Contains(err.Error(), "unmarshal descriptor set") {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,330 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+	"strings"
+)
+
+// RuleChangeKind direction of a rule change
+type RuleChangeKind int
+
+const (
+	// RuleAdded rule set on a field that didn't have it, it rejects more messages
+	RuleAdded RuleChangeKind = iota
+	// RuleRemoved rule of a field dropped, it rejects fewer messages
+	RuleRemoved
+	// RuleTightened rule value changed to reject more messages, e.g. a smaller length_lt
+	RuleTightened
+	// RuleLoosened rule value changed to reject fewer messages, e.g. a larger length_lt
+	RuleLoosened
+	// RuleChanged rule value changed without a known direction, e.g. another regex
+	RuleChanged
+)
+
+// String implement fmt.Stringer
+func (k RuleChangeKind) String() string {
+	switch k {
+	case RuleAdded:
+		return "added"
+	case RuleRemoved:
+		return "removed"
+	case RuleTightened:
+		return "tightened"
+	case RuleLoosened:
+		return "loosened"
+	case RuleChanged:
+		return "changed"
+	}
+	return fmt.Sprintf("RuleChangeKind(%d)", int(k))
+}
+
+// RuleChange change of a rule of a field between two builds
+type RuleChange struct {
+	// Field full name of the field
+	Field protoreflect.FullName
+	// Rule rule name, e.g. "LengthLt"
+	Rule string
+	Kind RuleChangeKind
+	// Old rule value in the old build, nil if added
+	Old interface{}
+	// New rule value in the new build, nil if removed
+	New interface{}
+}
+
+// String implement fmt.Stringer
+func (c *RuleChange) String() string {
+	return fmt.Sprintf("%s: %s %s: %v -> %v", c.Field, c.Rule, c.Kind, c.Old, c.New)
+}
+
+// Breaking whether messages accepted by the old build may be rejected by the new one
+func (c *RuleChange) Breaking() bool {
+	return c.Kind == RuleAdded || c.Kind == RuleTightened || c.Kind == RuleChanged
+}
+
+// ruleDirection how a rule value restricts messages
+type ruleDirection int
+
+const (
+	// lowerBound a larger value rejects more messages
+	lowerBound ruleDirection = iota + 1
+	// upperBound a smaller value rejects more messages
+	upperBound
+	// enabling a true value rejects more messages
+	enabling
+	// disabling a true value rejects fewer messages
+	disabling
+	// allowList a smaller list rejects more messages
+	allowList
+	// denyList a larger list rejects more messages
+	denyList
+)
+
+// ruleDirections direction of the rules, the changes of other rules have no known direction
+var ruleDirections = map[string]ruleDirection{
+	"IntGt":            lowerBound,
+	"IntGte":           lowerBound,
+	"UintGt":           lowerBound,
+	"UintGte":          lowerBound,
+	"FloatGt":          lowerBound,
+	"FloatGte":         lowerBound,
+	"LengthGt":         lowerBound,
+	"RepeatedCountMin": lowerBound,
+	"IntLt":            upperBound,
+	"IntLte":           upperBound,
+	"UintLt":           upperBound,
+	"UintLte":          upperBound,
+	"FloatLt":          upperBound,
+	"FloatLte":         upperBound,
+	"FloatEpsilon":     upperBound,
+	"LengthLt":         upperBound,
+	"RepeatedCountMax": upperBound,
+	"MsgMaxBytes":      upperBound,
+	"StringNotEmpty":   enabling,
+	"IsInEnum":         enabling,
+	"Ip":               enabling,
+	"Ipv4":             enabling,
+	"Ipv6":             enabling,
+	"IpPrivate":        enabling,
+	"IpPublic":         enabling,
+	"IntPort":          enabling,
+	"FieldMask":        enabling,
+	"LatLng":           enabling,
+	"PreValidated":     disabling,
+	"IntPortAllowZero": disabling,
+	"Shadow":           disabling,
+	"EnumIn":           allowList,
+	"EnumNotIn":        denyList,
+	"MapKeyIn":         allowList,
+}
+
+// DiffRules compare the annotated rules of two builds, e.g. the descriptor sets of the previous and the current release,
+// and report every added, removed, tightened, loosened or otherwise changed rule, sorted by field and rule.
+// Fields are matched by full name, the rules of a removed field are reported as removed.
+func DiffRules(old, new FileRanger) []*RuleChange {
+	oldRules, newRules := collectRules(old), collectRules(new)
+	names := make([]protoreflect.FullName, 0, len(oldRules)+len(newRules))
+	for name := range oldRules {
+		names = append(names, name)
+	}
+	for name := range newRules {
+		if oldRules[name] == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	var changes []*RuleChange
+	for _, name := range names {
+		changes = append(changes, diffRule(name, oldRules[name], newRules[name])...)
+	}
+	return changes
+}
+
+// collectRules annotated rules of the fields of files keyed by field full name
+func collectRules(files FileRanger) map[protoreflect.FullName]*FieldValidator {
+	rules := make(map[protoreflect.FullName]*FieldValidator)
+	_ = WalkRules(files, func(_ protoreflect.MessageDescriptor, field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+		rules[field.FullName()] = rule
+		return nil
+	})
+	return rules
+}
+
+// diffRule compare the old and the new rule of a field, either may be nil
+func diffRule(name protoreflect.FullName, old, new *FieldValidator) []*RuleChange {
+	oldValues, newValues := ruleValues(old), ruleValues(new)
+	keys := make([]string, 0, len(oldValues)+len(newValues))
+	for key := range oldValues {
+		keys = append(keys, key)
+	}
+	for key := range newValues {
+		if _, ok := oldValues[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []*RuleChange
+	for _, key := range keys {
+		o, hasOld := oldValues[key]
+		n, hasNew := newValues[key]
+		direction := ruleDirections[key]
+		if direction == enabling || direction == disabling {
+			//an unset bool rule is false
+			hasOld, hasNew = true, true
+			if o == nil {
+				o = false
+			}
+			if n == nil {
+				n = false
+			}
+		}
+		change := &RuleChange{Field: name, Rule: key, Old: o, New: n}
+		switch {
+		case !hasOld:
+			change.Kind = RuleAdded
+		case !hasNew:
+			change.Kind = RuleRemoved
+		case fmt.Sprint(o) == fmt.Sprint(n):
+			continue
+		default:
+			change.Kind = compareRule(direction, o, n)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// ruleValues set rule fields keyed by rule name, lists as []interface{}
+func ruleValues(rule *FieldValidator) map[string]interface{} {
+	values := make(map[string]interface{})
+	if rule == nil {
+		return values
+	}
+	rule.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if fd.IsList() {
+			list := value.List()
+			items := make([]interface{}, list.Len())
+			for i := range items {
+				items[i] = list.Get(i).Interface()
+				if fd.Kind() == protoreflect.MessageKind {
+					//nested rules, e.g. versioned, compared by their stable text
+					items[i] = strings.TrimSpace(formatRule(list.Get(i).Message(), ""))
+				}
+			}
+			values[goName(fd)] = items
+		} else {
+			values[goName(fd)] = value.Interface()
+		}
+		return true
+	})
+	return values
+}
+
+// compareRule direction of a changed rule value
+func compareRule(direction ruleDirection, old, new interface{}) RuleChangeKind {
+	switch direction {
+	case lowerBound, upperBound:
+		greater := toFloat(new) > toFloat(old)
+		if o, ok := old.(uint64); ok {
+			//compared exactly, float64 rounds large values
+			greater = new.(uint64) > o
+		}
+		if greater == (direction == lowerBound) {
+			return RuleTightened
+		}
+		return RuleLoosened
+	case enabling, disabling:
+		if new.(bool) == (direction == enabling) {
+			return RuleTightened
+		}
+		return RuleLoosened
+	case allowList, denyList:
+		o, n := old.([]interface{}), new.([]interface{})
+		switch {
+		case subset(n, o):
+			if direction == allowList {
+				return RuleTightened
+			}
+			return RuleLoosened
+		case subset(o, n):
+			if direction == allowList {
+				return RuleLoosened
+			}
+			return RuleTightened
+		}
+	}
+	return RuleChanged
+}
+
+// toFloat numeric rule value as float64
+func toFloat(value interface{}) float64 {
+	switch x := value.(type) {
+	case int64:
+		return float64(x)
+	case uint64:
+		return float64(x)
+	case float64:
+		return x
+	}
+	return 0
+}
+
+// subset whether every item of a is in b
+func subset(a, b []interface{}) bool {
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// formatRule format the set fields of a rule in field number order, one per line.
+// prototext output is not used as it is deliberately unstable.
+func formatRule(m protoreflect.Message, indent string) string {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Number() < fields[j].Number()
+	})
+
+	var b strings.Builder
+	for _, fd := range fields {
+		value := m.Get(fd)
+		if fd.IsList() {
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				b.WriteString(formatRuleValue(fd, list.Get(i), indent))
+			}
+			continue
+		}
+		b.WriteString(formatRuleValue(fd, value, indent))
+	}
+	return b.String()
+}
+
+// formatRuleValue format a value of a rule field
+func formatRuleValue(fd protoreflect.FieldDescriptor, value protoreflect.Value, indent string) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return fmt.Sprintf("%s%s {\n%s%s}\n", indent, fd.Name(), formatRule(value.Message(), indent+"  "), indent)
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%s%s: %q\n", indent, fd.Name(), value.String())
+	}
+	return fmt.Sprintf("%s%s: %v\n", indent, fd.Name(), value.Interface())
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffRules(t *testing.T) {
+	diff := func(t *testing.T, old, new string) []*RuleChange {
+		header := `syntax = "proto3"; package diff; import "validator.proto";` + "\n"
+		return DiffRules(testFiles{compileProto(t, header+old)}, testFiles{compileProto(t, header+new)})
+	}
+	field := func(rule string) string {
+		return `message Item { string name = 1; int64 count = 2; uint64 size = 3; repeated int32 kinds = 4 ` + rule + `; }`
+	}
+	for _, c := range []struct {
+		name, old, new string
+		changes        []string
+		breaking       []bool
+	}{
+		{"same rules", `message Item { string name = 1 [(validator.field) = {length_gt: 1}]; }`,
+			`message Item { string name = 1 [(validator.field) = {length_gt: 1}]; }`, nil, nil},
+		{"no rule", `message Item { string name = 1; }`, `message Item { string name = 1; }`, nil, nil},
+		{"added", `message Item { string name = 1; }`, `message Item { string name = 1 [(validator.field) = {length_gt: 1}]; }`,
+			[]string{"diff.Item.name: LengthGt added: <nil> -> 1"}, []bool{true}},
+		{"removed", `message Item { string name = 1 [(validator.field) = {length_gt: 1}]; }`, `message Item { string name = 1; }`,
+			[]string{"diff.Item.name: LengthGt removed: 1 -> <nil>"}, []bool{false}},
+		{"removed field", `message Item { string name = 1 [(validator.field) = {length_gt: 1}]; }`, `message Item {}`,
+			[]string{"diff.Item.name: LengthGt removed: 1 -> <nil>"}, []bool{false}},
+		{"lower bound raised", `message Item { int64 count = 1 [(validator.field) = {int_gt: 0}]; }`,
+			`message Item { int64 count = 1 [(validator.field) = {int_gt: 1}]; }`,
+			[]string{"diff.Item.count: IntGt tightened: 0 -> 1"}, []bool{true}},
+		{"lower bound lowered", `message Item { int64 count = 1 [(validator.field) = {int_gt: 0}]; }`,
+			`message Item { int64 count = 1 [(validator.field) = {int_gt: -1}]; }`,
+			[]string{"diff.Item.count: IntGt loosened: 0 -> -1"}, []bool{false}},
+		{"upper bound lowered", `message Item { string name = 1 [(validator.field) = {length_lt: 10}]; }`,
+			`message Item { string name = 1 [(validator.field) = {length_lt: 9}]; }`,
+			[]string{"diff.Item.name: LengthLt tightened: 10 -> 9"}, []bool{true}},
+		//float64 can't tell these apart
+		{"large uint64 bound", `message Item { uint64 size = 1 [(validator.field) = {uint_lt: 18446744073709551614}]; }`,
+			`message Item { uint64 size = 1 [(validator.field) = {uint_lt: 18446744073709551615}]; }`,
+			[]string{"diff.Item.size: UintLt loosened: 18446744073709551614 -> 18446744073709551615"}, []bool{false}},
+		{"enabled", `message Item { string ip = 1; }`, `message Item { string ip = 1 [(validator.field) = {ip: true}]; }`,
+			[]string{"diff.Item.ip: Ip tightened: false -> true"}, []bool{true}},
+		{"disabled", `message Item { string ip = 1 [(validator.field) = {ip: true}]; }`, `message Item { string ip = 1 [(validator.field) = {ip: false}]; }`,
+			[]string{"diff.Item.ip: Ip loosened: true -> false"}, []bool{false}},
+		{"shadowed", `message Item { string ip = 1 [(validator.field) = {ip: true}]; }`, `message Item { string ip = 1 [(validator.field) = {ip: true, shadow: true}]; }`,
+			[]string{"diff.Item.ip: Shadow loosened: false -> true"}, []bool{false}},
+		{"allow list shrunk", field(`[(validator.field) = {enum_in: [1, 2]}]`), field(`[(validator.field) = {enum_in: [1]}]`),
+			[]string{"diff.Item.kinds: EnumIn tightened: [1 2] -> [1]"}, []bool{true}},
+		{"allow list grown", field(`[(validator.field) = {enum_in: [1]}]`), field(`[(validator.field) = {enum_in: [2, 1]}]`),
+			[]string{"diff.Item.kinds: EnumIn loosened: [1] -> [2 1]"}, []bool{false}},
+		{"deny list grown", field(`[(validator.field) = {enum_not_in: [1]}]`), field(`[(validator.field) = {enum_not_in: [1, 2]}]`),
+			[]string{"diff.Item.kinds: EnumNotIn tightened: [1] -> [1 2]"}, []bool{true}},
+		{"allow list replaced", field(`[(validator.field) = {enum_in: [1]}]`), field(`[(validator.field) = {enum_in: [2]}]`),
+			[]string{"diff.Item.kinds: EnumIn changed: [1] -> [2]"}, []bool{true}},
+		{"regex", `message Item { string name = 1 [(validator.field) = {regex: "^a"}]; }`,
+			`message Item { string name = 1 [(validator.field) = {regex: "^b"}]; }`,
+			[]string{"diff.Item.name: Regex changed: ^a -> ^b"}, []bool{true}},
+		{"sorted by field and rule", `message Item { string b = 1 [(validator.field) = {length_lt: 5}]; string a = 2; }`,
+			`message Item { string b = 1 [(validator.field) = {length_gt: 1}]; string a = 2 [(validator.field) = {regex: "x"}]; }`,
+			[]string{"diff.Item.a: Regex added: <nil> -> x", "diff.Item.b: LengthGt added: <nil> -> 1", "diff.Item.b: LengthLt removed: 5 -> <nil>"},
+			[]bool{true, true, false}},
+		{"nested message", `message Item { message Inner { string a = 1; } }`,
+			`message Item { message Inner { string a = 1 [(validator.field) = {length_gt: 1}]; } }`,
+			[]string{"diff.Item.Inner.a: LengthGt added: <nil> -> 1"}, []bool{true}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			changes := diff(t, c.old, c.new)
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(c.changes, "\n") {
+				t.Fatalf("got\n%s", strings.Join(got, "\n"))
+			}
+			for i, change := range changes {
+				if change.Breaking() != c.breaking[i] {
+					t.Errorf("%s: breaking %v", change, change.Breaking())
+				}
+			}
+		})
+	}
+}
+
+func TestRuleChangeKindString(t *testing.T) {
+	for kind, want := range map[RuleChangeKind]string{RuleAdded: "added", RuleRemoved: "removed", RuleTightened: "tightened",
+		RuleLoosened: "loosened", RuleChanged: "changed", RuleChanged + 1: "RuleChangeKind(5)"} {
+		if kind.String() != want {
+			t.Errorf("got %s, want %s", kind, want)
+		}
+	}
+}
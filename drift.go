@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+)
+
+// DriftPolicy handling of overlaid rules referencing fields missing from the runtime descriptors (schema drift),
+// e.g. an external rule set written against a newer or older version of the proto files
+type DriftPolicy int
+
+const (
+	// DriftIgnore orphaned rules are ignored (default)
+	DriftIgnore DriftPolicy = iota
+	// DriftWarn orphaned rules are logged when the program of their message is compiled
+	DriftWarn
+	// DriftError orphaned rules are configuration errors of the program of their message,
+	// logged and reported as faults (see WithFaultReporter) when it is compiled.
+	// Check a rule set with OrphanedRules to reject it before applying it.
+	DriftError
+)
+
+// String implement fmt.Stringer
+func (p DriftPolicy) String() string {
+	switch p {
+	case DriftIgnore:
+		return "ignore"
+	case DriftWarn:
+		return "warn"
+	case DriftError:
+		return "error"
+	}
+	return fmt.Sprintf("DriftPolicy(%d)", int(p))
+}
+
+// WithDriftPolicy handle the overlaid rules of fields missing from the runtime descriptors with policy
+func WithDriftPolicy(policy DriftPolicy) Option {
+	return func(v *Validator) {
+		v.driftPolicy = policy
+	}
+}
+
+// OrphanedRule overlaid rule referencing a message or a field missing from the runtime descriptors
+type OrphanedRule struct {
+	// RuleSet name of the rule set
+	RuleSet string `json:"rule_set"`
+	// Message full name of the message
+	Message string `json:"message"`
+	// Field name of the field, empty if the whole message is missing
+	Field string `json:"field,omitempty"`
+}
+
+// String implement fmt.Stringer
+func (o OrphanedRule) String() string {
+	if o.Field == "" {
+		return fmt.Sprintf("rule set[%s] msg[%s] not found", o.RuleSet, o.Message)
+	}
+	return fmt.Sprintf("rule set[%s] field[%s.%s] not found", o.RuleSet, o.Message, o.Field)
+}
+
+// OrphanedRules list the rules of a rule set referencing messages unknown to the resolver of the default validator,
+// or fields missing from their message, see (*Validator).OrphanedRules
+func OrphanedRules(rules *RuleSet) []OrphanedRule {
+	return std().OrphanedRules(rules)
+}
+
+// OrphanedRules list the rules of a rule set referencing messages unknown to the resolver of the validator,
+// or fields missing from their message, sorted by message then field, e.g. to check an external rule set before applying it
+func (v *Validator) OrphanedRules(rules *RuleSet) []OrphanedRule {
+	var orphans []OrphanedRule
+	for name := range rules.GetMessages() {
+		mt, err := v.resolver.FindMessageByName(protoreflect.FullName(name))
+		if err != nil {
+			orphans = append(orphans, OrphanedRule{RuleSet: rules.GetName(), Message: name})
+			continue
+		}
+		orphans = append(orphans, orphanedFields(mt.Descriptor(), rules)...)
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Message != orphans[j].Message {
+			return orphans[i].Message < orphans[j].Message
+		}
+		return orphans[i].Field < orphans[j].Field
+	})
+	return orphans
+}
+
+// orphanedFields overlaid rules of md referencing fields missing from md
+func orphanedFields(md protoreflect.MessageDescriptor, rules *RuleSet) []OrphanedRule {
+	var orphans []OrphanedRule
+	for name := range rules.GetMessages()[string(md.FullName())].GetFields() {
+		if md.Fields().ByName(protoreflect.Name(name)) == nil {
+			orphans = append(orphans, OrphanedRule{RuleSet: rules.GetName(), Message: string(md.FullName()), Field: name})
+		}
+	}
+	return orphans
+}
+
+// checkDrift handle the orphaned rules of md with the drift policy of the validator
+func (v *Validator) checkDrift(md protoreflect.MessageDescriptor, rules *RuleSet) error {
+	if v.driftPolicy == DriftIgnore || rules == nil {
+		return nil
+	}
+	orphans := orphanedFields(md, rules)
+	if len(orphans) == 0 {
+		return nil
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Field < orphans[j].Field
+	})
+	if v.driftPolicy == DriftWarn {
+		for _, orphan := range orphans {
+			v.warnf("[pb valid]%s", orphan)
+		}
+		return nil
+	}
+	fields := make([]string, len(orphans))
+	for i, orphan := range orphans {
+		fields[i] = orphan.Field
+	}
+	return fmt.Errorf("[proto valid]rule set[%s] msg[%s] fields %v not found", rules.GetName(), md.FullName(), fields)
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+	"reflect"
+	"testing"
+)
+
+// testDriftProto message targeted by rule sets written against another version of the schema
+const testDriftProto = `syntax = "proto3"; package drift; import "validator.proto";
+message Account { string name = 1 [(validator.field) = {length_gt: 1}]; string email = 2; }`
+
+// testDriftRules rule set with a rule of a known field and rules of missing fields
+func testDriftRules() *RuleSet {
+	gt := int64(3)
+	return &RuleSet{
+		Name: proto.String("v2"),
+		Messages: map[string]*MessageRules{"drift.Account": {Fields: map[string]*FieldValidator{
+			"name":     {LengthGt: &gt},
+			"nickname": {LengthGt: &gt},
+			"avatar":   {LengthGt: &gt},
+		}}},
+	}
+}
+
+func TestOrphanedRules(t *testing.T) {
+	fd := compileProto(t, testDriftProto)
+	v := New(WithResolver(testTypes(t, fd)))
+	gt := int64(1)
+	for _, c := range []struct {
+		name    string
+		rules   *RuleSet
+		orphans []OrphanedRule
+	}{
+		{"nil rule set", nil, nil},
+		{"no orphan", &RuleSet{Name: proto.String("ok"), Messages: map[string]*MessageRules{
+			"drift.Account": {Fields: map[string]*FieldValidator{"email": {LengthGt: &gt}}}}}, nil},
+		{"missing fields", testDriftRules(), []OrphanedRule{
+			{RuleSet: "v2", Message: "drift.Account", Field: "avatar"}, {RuleSet: "v2", Message: "drift.Account", Field: "nickname"}}},
+		{"missing message", &RuleSet{Name: proto.String("old"), Messages: map[string]*MessageRules{
+			"drift.Profile": {Fields: map[string]*FieldValidator{"bio": {LengthGt: &gt}}},
+			"drift.Account": {Fields: map[string]*FieldValidator{"phone": {LengthGt: &gt}}}}}, []OrphanedRule{
+			{RuleSet: "old", Message: "drift.Account", Field: "phone"}, {RuleSet: "old", Message: "drift.Profile"}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if orphans := v.OrphanedRules(c.rules); !reflect.DeepEqual(orphans, c.orphans) {
+				t.Fatalf("got %v, want %v", orphans, c.orphans)
+			}
+		})
+	}
+}
+
+func TestDriftPolicy(t *testing.T) {
+	fd := compileProto(t, testDriftProto)
+	for _, c := range []struct {
+		policy   DriftPolicy
+		warnings []string
+		faults   int
+	}{
+		{DriftIgnore, nil, 0},
+		{DriftWarn, []string{"[pb valid]rule set[v2] field[drift.Account.avatar] not found", "[pb valid]rule set[v2] field[drift.Account.nickname] not found"}, 0},
+		{DriftError, []string{"[pb valid]compile msg[drift.Account] err: [proto valid]rule set[v2] msg[drift.Account] fields [avatar nickname] not found"}, 1},
+	} {
+		t.Run(c.policy.String(), func(t *testing.T) {
+			logger := &testLogger{}
+			var faults []*Fault
+			v := New(WithOverlay(testDriftRules()), WithDriftPolicy(c.policy), WithLogger(logger),
+				WithFaultReporter(func(fault *Fault) { faults = append(faults, fault) }))
+			ctx := ContextWithOverlay(context.Background(), "v2")
+			//the rules of the known fields are still applied, the program is compiled once
+			for i := 0; i < 2; i++ {
+				if err := v.ValidateContext(ctx, newMsg(t, fd, "Account", `{"name":"abc"}`)); !MatchViolation(err, "name", "LengthGt") {
+					t.Fatal(err)
+				}
+			}
+			if !reflect.DeepEqual(logger.warnings, c.warnings) {
+				t.Fatalf("got %q", logger.warnings)
+			}
+			if len(faults) != c.faults || c.faults > 0 && (faults[0].Kind != FaultConfig || faults[0].Message != "drift.Account") {
+				t.Fatal(faults)
+			}
+			//the annotated program has no orphan
+			logger.warnings = nil
+			if err := v.Validate(newMsg(t, fd, "Account", `{"name":"ab"}`)); err != nil || len(logger.warnings) != 0 {
+				t.Fatal(err, logger.warnings)
+			}
+		})
+	}
+}
+
+func TestDriftPolicyString(t *testing.T) {
+	for policy, s := range map[DriftPolicy]string{DriftIgnore: "ignore", DriftWarn: "warn", DriftError: "error", 3: "DriftPolicy(3)"} {
+		if policy.String() != s {
+			t.Errorf("got %s, want %s", policy, s)
+		}
+	}
+	if s := (OrphanedRule{RuleSet: "old", Message: "drift.Profile"}).String(); s != "rule set[old] msg[drift.Profile] not found" {
+		t.Fatal(s)
+	}
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EnumPolicy treatment of unknown values of open (proto3) enums, e.g. values added by newer clients
+type EnumPolicy int
+
+const (
+	// EnumAccept accept unknown values, the default
+	EnumAccept EnumPolicy = iota
+	// EnumReject fail the validation on unknown values
+	EnumReject
+	// EnumWarn log unknown values and report them to the OnViolation hook as shadow violations
+	EnumWarn
+)
+
+// String implement fmt.Stringer
+func (p EnumPolicy) String() string {
+	switch p {
+	case EnumAccept:
+		return "accept"
+	case EnumReject:
+		return "reject"
+	case EnumWarn:
+		return "warn"
+	}
+	return fmt.Sprintf("EnumPolicy(%d)", int(p))
+}
+
+// WithOpenEnumPolicy apply policy to unknown values of every open enum field, independent of is_in_enum
+func WithOpenEnumPolicy(policy EnumPolicy) Option {
+	return func(v *Validator) {
+		v.openEnumPolicy = policy
+	}
+}
+
+// hasOpenEnumPolicy whether the open enum policy applies to the field
+func (v *Validator) hasOpenEnumPolicy(field protoreflect.FieldDescriptor) bool {
+	if v.openEnumPolicy == EnumAccept {
+		return false
+	}
+	if field.IsMap() {
+		field = field.MapValue()
+	}
+	return field.Kind() == protoreflect.EnumKind && field.Enum().ParentFile().Syntax() == protoreflect.Proto3
+}
+
+// checkOpenEnum check an open enum value against the open enum policy
+func (v *validator) checkOpenEnum(field protoreflect.FieldDescriptor, value int32, rule *FieldValidator) error {
+	if v.shadow || !v.hasOpenEnumPolicy(field) {
+		return nil
+	}
+	if rule != nil && rule.IsInEnum != nil && *rule.IsInEnum {
+		//reported by is_in_enum
+		return nil
+	}
+	if field.Enum().Values().ByNumber(protoreflect.EnumNumber(value)) != nil {
+		return nil
+	}
+
+	if v.openEnumPolicy == EnumWarn {
+		v.warnf("[pb valid]field[%s] unknown enum value[%d]", field.FullName(), value)
+		warn := *v
+		warn.shadow = true
+		return warn.fail(field, "OpenEnum", v.openEnumPolicy, value)
+	}
+	return v.fail(field, "OpenEnum", v.openEnumPolicy, value)
+}
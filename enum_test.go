@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestRepeatedEnum(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package enums; import "validator.proto";
+enum Color { COLOR_UNSPECIFIED = 0; RED = 1; GREEN = 2; BLUE = 3; }
+message Palette {
+  repeated Color defined = 1 [(validator.field) = {is_in_enum: true, repeated_count_min: 1, repeated_count_max: 3}];
+  repeated Color allowed = 2 [(validator.field) = {enum_in: [1, 2]}];
+  repeated Color denied = 3 [(validator.field) = {enum_not_in: [0]}];
+}`)
+	for _, c := range []struct {
+		name, json string
+		want       [][2]string
+	}{
+		{"legal", `{"defined":["RED","BLUE"],"allowed":["RED","GREEN"],"denied":["BLUE"]}`, nil},
+		{"undefined element", `{"defined":["RED",7]}`, [][2]string{{"defined[1]", "IsInEnum"}}},
+		{"element not in", `{"defined":["RED"],"allowed":["RED","BLUE","GREEN","BLUE"]}`, [][2]string{{"allowed[1]", "EnumIn"}, {"allowed[3]", "EnumIn"}}},
+		{"element in denylist", `{"defined":["RED"],"denied":["RED","COLOR_UNSPECIFIED"]}`, [][2]string{{"denied[1]", "EnumNotIn"}}},
+		{"too few elements", `{}`, [][2]string{{"defined", "RepeatedCountMin"}}},
+		{"too many elements", `{"defined":["RED","RED","RED","RED"]}`, [][2]string{{"defined", "RepeatedCountMax"}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Palette", c.json))
+			if got := len(ValidErrors(err)); got != len(c.want) {
+				t.Fatalf("want %d violations, got %v", len(c.want), err)
+			}
+			for _, want := range c.want {
+				if !MatchViolation(err, want[0], want[1]) {
+					t.Errorf("want %s at %s, got %v", want[1], want[0], err)
+				}
+			}
+		})
+	}
+}
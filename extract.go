@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sync"
+)
+
+// ExtractorFunc extract the logical scalar value of a message, e.g. the decimal string of a company-internal Decimal.
+// ok is false if the message holds no value, the scalar rules of the field are then skipped.
+type ExtractorFunc func(msg protoreflect.Message) (value protoreflect.Value, ok bool)
+
+// extractor value extractor of a message type
+type extractor struct {
+	//kind kind of the extracted value
+	kind protoreflect.Kind
+	fn   ExtractorFunc
+}
+
+// extractors value extractors registered with RegisterExtractor, keyed by message full name
+var extractors sync.Map
+
+// RegisterExtractor apply the scalar rules of the fields of message type fullName to the value extracted by fn,
+// of kind kind (e.g. protoreflect.StringKind), for every validator, instead of treating the message as opaque.
+// The rules of the message itself are still validated. Registering a name again replaces the extractor.
+func RegisterExtractor(fullName protoreflect.FullName, kind protoreflect.Kind, fn ExtractorFunc) {
+	extractors.Store(fullName, &extractor{kind: kind, fn: fn})
+}
+
+// WithExtractor register a value extractor of message type fullName for the validator, taking precedence over RegisterExtractor.
+// Lint and WithStrictTyping only know the extractors registered with RegisterExtractor.
+func WithExtractor(fullName protoreflect.FullName, kind protoreflect.Kind, fn ExtractorFunc) Option {
+	return func(v *Validator) {
+		if v.extractors == nil {
+			v.extractors = make(map[protoreflect.FullName]*extractor)
+		}
+		v.extractors[fullName] = &extractor{kind: kind, fn: fn}
+	}
+}
+
+// getExtractor get the value extractor of a message type, nil if none
+func (v *Validator) getExtractor(fullName protoreflect.FullName) *extractor {
+	if ex, ok := v.extractors[fullName]; ok {
+		return ex
+	}
+	if x, ok := extractors.Load(fullName); ok {
+		return x.(*extractor)
+	}
+	return nil
+}
+
+// checkExtracted check the value extracted from a sub-message with the scalar rules of its field
+func (v *validator) checkExtracted(field protoreflect.FieldDescriptor, subMsg protoreflect.Message, rule *FieldValidator) error {
+	if rule == nil {
+		return nil
+	}
+	ex := v.getExtractor(subMsg.Descriptor().FullName())
+	if ex == nil {
+		return nil
+	}
+	value, ok := ex.fn(subMsg)
+	if !ok || !value.IsValid() {
+		return nil
+	}
+	switch x := value.Interface().(type) {
+	case int32:
+		return v.checkInt(field, int64(x), rule)
+	case int64:
+		return v.checkInt(field, x, rule)
+	case uint32:
+		return v.checkUint(field, uint64(x), rule)
+	case uint64:
+		return v.checkUint(field, x, rule)
+	case float32:
+		return v.checkFloat(field, float64(x), rule)
+	case float64:
+		return v.checkFloat(field, x, rule)
+	case string:
+		return v.checkString(field, x, rule)
+	case []byte:
+		return v.checkBytes(field, x, rule)
+	case protoreflect.EnumNumber:
+		return v.checkEnum(field, int32(x), rule)
+	}
+	return nil
+}
+
+// extractedField field of a message type with a registered extractor, of the kind of the extracted value
+type extractedField struct {
+	protoreflect.FieldDescriptor
+	kind protoreflect.Kind
+}
+
+// Kind kind of the extracted value
+func (f extractedField) Kind() protoreflect.Kind {
+	return f.kind
+}
+
+// extractedKind field as the kind of its registered extractor (see RegisterExtractor), nil if none
+func extractedKind(field protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	if field.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+	x, ok := extractors.Load(field.Message().FullName())
+	if !ok {
+		return nil
+	}
+	return extractedField{FieldDescriptor: field, kind: x.(*extractor).kind}
+}
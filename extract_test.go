@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+)
+
+func TestExtractor(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package extract; import "validator.proto";
+message Decimal { string value = 1; }
+message Count { int64 n = 1; }
+message Price {
+  Decimal amount = 1 [(validator.field) = {regex: "^[0-9]+$", length_lt: 6}];
+  repeated Decimal history = 2 [(validator.field) = {regex: "^[0-9]+$"}];
+  Count count = 3 [(validator.field) = {int_gt: 0, int_lt: 10}];
+}`)
+	value := func(name protoreflect.Name) ExtractorFunc {
+		return func(msg protoreflect.Message) (protoreflect.Value, bool) {
+			field := msg.Descriptor().Fields().ByName(name)
+			return msg.Get(field), msg.Has(field)
+		}
+	}
+	v := New(
+		WithExtractor("extract.Decimal", protoreflect.StringKind, value("value")),
+		WithExtractor("extract.Count", protoreflect.Int64Kind, value("n")),
+	)
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"legal", `{"amount":{"value":"100"},"history":[{"value":"1"}],"count":{"n":"9"}}`, "", ""},
+		{"unset", `{}`, "", ""},
+		{"no value", `{"amount":{},"history":[{}],"count":{}}`, "", ""},
+		{"regex", `{"amount":{"value":"1.5"}}`, "amount", "Regex"},
+		{"length bound", `{"amount":{"value":"99999"}}`, "", ""},
+		{"length", `{"amount":{"value":"100000"}}`, "amount", "LengthLt"},
+		{"element", `{"history":[{"value":"1"},{"value":"x"}]}`, "history[1]", "Regex"},
+		{"integer lower bound", `{"count":{"n":"1"}}`, "", ""},
+		{"integer below", `{"count":{"n":"-1"}}`, "count", "IntGt"},
+		{"integer above", `{"count":{"n":"10"}}`, "count", "IntLt"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := v.ValidateAll(newMsg(t, fd, "Price", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+	//without an extractor, the scalar rules of a message field are not applied
+	if err := New().ValidateAll(newMsg(t, fd, "Price", `{"amount":{"value":"1.5"}}`)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractorRuleConfig(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package extract; import "validator.proto";
+message Decimal { string value = 1; }
+message Price { Decimal amount = 1 [(validator.field) = {regex: "^[0-9]+$"}]; }`)
+	extract := func(msg protoreflect.Message) (protoreflect.Value, bool) { return protoreflect.Value{}, false }
+	for _, c := range []struct {
+		name    string
+		opts    []Option
+		invalid bool
+	}{
+		{"validator extractor", []Option{WithExtractor("extract.Decimal", protoreflect.StringKind, extract)}, false},
+		{"strict typing without registered extractor", []Option{WithStrictTyping(), WithExtractor("extract.Decimal", protoreflect.StringKind, extract)}, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if err := New(c.opts...).Register(fd.Messages().ByName("Price")); (err != nil) != c.invalid {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"errors"
+	"google.golang.org/protobuf/proto"
+	"strings"
+)
+
+// MessageValidator common abstraction of message validation engines,
+// implemented by *Validator, *CompatValidator and protovalidate-go's *protovalidate.Validator
+type MessageValidator interface {
+	Validate(msg proto.Message) error
+}
+
+// ValidationError every violation of a message, shaped like the ValidationError of protovalidate-go
+type ValidationError struct {
+	Violations []*ValidError
+	// Errs errors other than violations, e.g. ErrViolationsTruncated, ErrBudgetExceeded or an *InternalValidationError,
+	// meaning Violations may be incomplete
+	Errs []error
+}
+
+// Error implement interface
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("[proto valid]validation error:")
+	for _, violation := range e.Violations {
+		b.WriteString("\n - ")
+		b.WriteString(violation.Error())
+	}
+	for _, err := range e.Errs {
+		b.WriteString("\n - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap violations and the other errors, for errors.Is and errors.As
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations)+len(e.Errs))
+	for _, violation := range e.Violations {
+		errs = append(errs, violation)
+	}
+	return append(errs, e.Errs...)
+}
+
+// CompatValidator facade with the method shape of protovalidate-go's Validator,
+// to swap engines behind MessageValidator while migrating
+type CompatValidator struct {
+	v *Validator
+}
+
+// NewCompat create a protovalidate compatible validator, the error is always nil and kept for the shape of protovalidate.New
+func NewCompat(opts ...Option) (*CompatValidator, error) {
+	return &CompatValidator{v: New(opts...)}, nil
+}
+
+// Compat protovalidate compatible facade of the validator
+func (v *Validator) Compat() *CompatValidator {
+	return &CompatValidator{v: v}
+}
+
+// Validate verify a message and return a *ValidationError with every violation, nil if it is legal.
+// Errors other than violations are kept in ValidationError.Errs, or returned as is without violation.
+func (c *CompatValidator) Validate(msg proto.Message) error {
+	err := c.v.ValidateAll(msg)
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	verr := &ValidationError{}
+	for _, e := range errs {
+		var violation *ValidError
+		if errors.As(e, &violation) {
+			verr.Violations = append(verr.Violations, violation)
+		} else {
+			verr.Errs = append(verr.Errs, e)
+		}
+	}
+	if len(verr.Violations) == 0 {
+		return err
+	}
+	return verr
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompatValidator(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package facade; import "validator.proto";
+message User {
+  string name = 1 [(validator.field) = {length_gt: 1}];
+  string nick = 2 [(validator.field) = {length_gt: 1}];
+  string mail = 3 [(validator.field) = {length_gt: 1}];
+}`)
+	for _, c := range []struct {
+		name       string
+		opts       []Option
+		json       string
+		violations int
+		truncated  bool
+	}{
+		{"legal", nil, `{"name":"ab","nick":"ab","mail":"ab"}`, 0, false},
+		{"every violation", nil, `{"name":"a","nick":"a","mail":"a"}`, 3, false},
+		{"truncated", []Option{WithMaxViolations(2)}, `{"name":"a","nick":"a","mail":"a"}`, 2, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			compat, err := NewCompat(c.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var mv MessageValidator = compat
+			err = mv.Validate(newMsg(t, fd, "User", c.json))
+			if c.violations == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) || len(verr.Violations) != c.violations {
+				t.Fatalf("want %d violations, got %v", c.violations, err)
+			}
+			if errors.Is(err, ErrViolationsTruncated) != c.truncated {
+				t.Fatalf("truncated: %v", err)
+			}
+		})
+	}
+}
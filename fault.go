@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FaultKind kind of an internal validator fault
+type FaultKind int
+
+const (
+	// FaultPanic a panic recovered during a validation, failing it with an *InternalValidationError
+	// (or treated as legal with WithLenientPanics)
+	FaultPanic FaultKind = iota
+	// FaultTypeMismatch a field value of an unexpected Go type
+	FaultTypeMismatch
+	// FaultConfig a bad rule configuration, e.g. an invalid regex or a missing overlay
+	FaultConfig
+	// FaultConversion a message that could not be converted for the validation, failing it with an *InternalValidationError
+	FaultConversion
+	// FaultSource a rule source that could not be fetched
+	FaultSource
+)
+
+// String implement fmt.Stringer
+func (k FaultKind) String() string {
+	switch k {
+	case FaultPanic:
+		return "panic"
+	case FaultTypeMismatch:
+		return "type mismatch"
+	case FaultConfig:
+		return "config"
+	case FaultConversion:
+		return "conversion"
+	case FaultSource:
+		return "source"
+	}
+	return fmt.Sprintf("FaultKind(%d)", int(k))
+}
+
+// Fault internal validator fault, distinct from the violations of user data.
+// Faults are bugs or misconfigurations to be paged on, only panics fail a validation.
+type Fault struct {
+	// Kind kind of the fault
+	Kind FaultKind
+	// Message full name of the message being validated or compiled, may be empty
+	Message string
+	// Err cause of the fault
+	Err error
+	// Stack stack trace of a recovered panic
+	Stack []byte
+}
+
+// Error implement interface
+func (f *Fault) Error() string {
+	return fmt.Sprintf("[proto valid]fault[%s] msg[%s]: %s", f.Kind, f.Message, f.Err)
+}
+
+// Unwrap the cause of the fault
+func (f *Fault) Unwrap() error {
+	return f.Err
+}
+
+// InternalValidationError error of a validation that panicked, of a message that could not be converted
+// or of a validation selecting a missing overlay, the message was not fully validated.
+// It is distinct from *ValidError, e.g. to answer an internal error instead of a bad request.
+type InternalValidationError struct {
+	// Fault recovered panic with its stack trace, conversion error or missing overlay
+	Fault *Fault
+}
+
+// Error implement interface
+func (e *InternalValidationError) Error() string {
+	return fmt.Sprintf("[proto valid]internal error validating msg[%s]: %s", e.Fault.Message, e.Fault.Err)
+}
+
+// Unwrap the fault
+func (e *InternalValidationError) Unwrap() error {
+	return e.Fault
+}
+
+// WithLenientPanics treat a message as legal when its validation panics instead of failing with an *InternalValidationError.
+// The panic is still logged and reported as a fault.
+func WithLenientPanics() Option {
+	return func(v *Validator) {
+		v.lenientPanics = true
+	}
+}
+
+// WithFaultReporter call report on every internal fault, e.g. to send it to an error tracker like Sentry.
+// Faults are still logged.
+func WithFaultReporter(report func(fault *Fault)) Option {
+	return func(v *Validator) {
+		v.faultReporter = report
+	}
+}
+
+// fault report an internal fault
+func (v *Validator) fault(fault *Fault) {
+	if v.faultReporter != nil {
+		v.faultReporter(fault)
+	}
+}
+
+// messageName full name of a message, empty if it has no descriptor (e.g. a typed nil message)
+func messageName(m protoreflect.Message) (name string) {
+	defer func() {
+		_ = recover()
+	}()
+	if m != nil {
+		name = string(m.Descriptor().FullName())
+	}
+	return name
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// fieldMaskFullName full name of google.protobuf.FieldMask
+const fieldMaskFullName protoreflect.FullName = "google.protobuf.FieldMask"
+
+// checkFieldMask check the paths of a google.protobuf.FieldMask
+func (v *validator) checkFieldMask(field protoreflect.FieldDescriptor, mask protoreflect.Message, rule *FieldValidator) error {
+	if rule == nil || !rule.GetFieldMask() || mask.Descriptor().FullName() != fieldMaskFullName {
+		return nil
+	}
+
+	var target protoreflect.MessageDescriptor
+	if rule.FieldMaskTarget != nil {
+		mt, err := v.resolver.FindMessageByName(protoreflect.FullName(*rule.FieldMaskTarget))
+		if err != nil {
+			v.warnf("[pb valid]field[%s] resolve field mask target[%s] err: %s", field.FullName(), *rule.FieldMaskTarget, err)
+			v.fault(&Fault{
+				Kind:    FaultConfig,
+				Message: string(field.ContainingMessage().FullName()),
+				Err:     fmt.Errorf("field[%s] resolve field mask target[%s]: %w", field.FullName(), *rule.FieldMaskTarget, err),
+			})
+		} else {
+			target = mt.Descriptor()
+		}
+	}
+
+	paths := mask.Get(mask.Descriptor().Fields().ByName("paths")).List()
+	for i := 0; i < paths.Len(); i++ {
+		path := paths.Get(i).String()
+		if !validMaskPath(path) {
+			if err := v.fail(field, "FieldMask", *rule.FieldMask, path); err != nil {
+				return err
+			}
+			continue
+		}
+		if target != nil && !maskPathExists(target, path) {
+			if err := v.fail(field, "FieldMaskTarget", *rule.FieldMaskTarget, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validMaskPath whether a path is dot-separated field names
+func validMaskPath(path string) bool {
+	for _, name := range strings.Split(path, ".") {
+		if !protoreflect.Name(name).IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// maskPathExists whether a well-formed path refers to a field of md
+func maskPathExists(md protoreflect.MessageDescriptor, path string) bool {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := md.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return false
+		}
+		if i == len(names)-1 {
+			return true
+		}
+		if field.IsList() || field.IsMap() || field.Kind() != protoreflect.MessageKind {
+			//only the last field of a path may be repeated or scalar
+			return false
+		}
+		md = field.Message()
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"testing"
+)
+
+func TestFieldMask(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package fieldmask; import "validator.proto"; import "google/protobuf/field_mask.proto";
+message Book { string title = 1; Author author = 2; repeated Author editors = 3; }
+message Author { string name = 1; }
+message Update {
+  google.protobuf.FieldMask mask = 1 [(validator.field) = {field_mask: true, field_mask_target: "fieldmask.Book"}];
+  google.protobuf.FieldMask loose = 2 [(validator.field) = {field_mask: true}];
+}`)
+	v := New(WithResolver(testTypes(t, fd)))
+	for _, c := range []struct {
+		name, text, path, rule string
+	}{
+		{"legal", `mask: {paths: ["title", "author.name"]} loose: {paths: "any.path"}`, "", ""},
+		{"unset", ``, "", ""},
+		{"empty mask", `mask: {} loose: {}`, "", ""},
+		{"message field", `mask: {paths: "author"}`, "", ""},
+		{"repeated field", `mask: {paths: "editors"}`, "", ""},
+		{"unknown field", `mask: {paths: "author.nmae"}`, "mask", "FieldMaskTarget"},
+		{"path through a scalar", `mask: {paths: "title.length"}`, "mask", "FieldMaskTarget"},
+		{"path through a repeated field", `mask: {paths: "editors.name"}`, "mask", "FieldMaskTarget"},
+		{"empty path", `loose: {paths: ""}`, "loose", "FieldMask"},
+		{"empty segment", `loose: {paths: "a..b"}`, "loose", "FieldMask"},
+		{"trailing dot", `mask: {paths: "author."}`, "mask", "FieldMask"},
+		{"json name", `loose: {paths: "authorName"}`, "", ""},
+		{"invalid name", `loose: {paths: "1st"}`, "loose", "FieldMask"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			//protojson rejects malformed paths itself
+			msg := dynamicpb.NewMessage(fd.Messages().ByName("Update"))
+			if err := prototext.Unmarshal([]byte(c.text), msg); err != nil {
+				t.Fatal(err)
+			}
+			err := v.ValidateAll(msg)
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestFieldMaskTargetNotFound(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package fieldmask; import "validator.proto"; import "google/protobuf/field_mask.proto";
+message Update { google.protobuf.FieldMask mask = 1 [(validator.field) = {field_mask: true, field_mask_target: "fieldmask.Missing"}]; }`)
+	var faults []*Fault
+	v := New(WithResolver(testTypes(t, fd)), WithFaultReporter(func(fault *Fault) { faults = append(faults, fault) }))
+	for _, c := range []struct {
+		name, json, rule string
+		faults           int
+	}{
+		{"unset", `{}`, "", 0},
+		{"well-formed path", `{"mask":"any.path"}`, "", 1},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			faults = nil
+			err := v.ValidateAll(newMsg(t, fd, "Update", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, "mask", c.rule) {
+				t.Fatal(err)
+			}
+			if len(faults) != c.faults || c.faults > 0 && faults[0].Kind != FaultConfig {
+				t.Fatal(faults)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sync"
+)
+
+// RuleFunc Go function referenced by the go_func rule, a returned error fails the validation of the value.
+// ctx is the context of the call (context.Background() if none), value is a message, a scalar or an element of a repeated field.
+type RuleFunc func(ctx context.Context, field protoreflect.FieldDescriptor, value protoreflect.Value) error
+
+// funcs rule functions registered with RegisterFunc
+var funcs sync.Map
+
+// RegisterFunc register a rule function referenced by go_func: name, for every validator.
+// Registering a name again replaces the function.
+func RegisterFunc(name string, fn RuleFunc) {
+	funcs.Store(name, fn)
+}
+
+// WithFunc register a rule function referenced by go_func: name for the validator,
+// taking precedence over RegisterFunc
+func WithFunc(name string, fn RuleFunc) Option {
+	return func(v *Validator) {
+		if v.funcs == nil {
+			v.funcs = make(map[string]RuleFunc)
+		}
+		v.funcs[name] = fn
+	}
+}
+
+// getFunc get the rule function named name, nil if not registered
+func (v *Validator) getFunc(name string) RuleFunc {
+	if fn, ok := v.funcs[name]; ok {
+		return fn
+	}
+	if x, ok := funcs.Load(name); ok {
+		return x.(RuleFunc)
+	}
+	return nil
+}
+
+// checkFunc call the go_func rule function of a value, an unregistered function rejects every value
+func (v *validator) checkFunc(field protoreflect.FieldDescriptor, value protoreflect.Value, rule *FieldValidator) error {
+	if rule == nil || rule.GoFunc == nil {
+		return nil
+	}
+	fn := v.getFunc(*rule.GoFunc)
+	if fn == nil {
+		//reported by Register, fail closed instead of skipping the check
+		v.warnf("[pb valid]rule func[%s] not found", *rule.GoFunc)
+		v.fault(&Fault{Kind: FaultConfig, Err: fmt.Errorf("rule func[%s] not found", *rule.GoFunc)})
+		return v.fail(field, "GoFunc", *rule.GoFunc, "rule func not registered")
+	}
+	ctx := v.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := fn(ctx, field, value); err != nil {
+		return v.fail(field, "GoFunc", *rule.GoFunc, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+	"testing"
+)
+
+func TestGoFunc(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package gofunc; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {go_func: "checkSKU"}]; }`)
+	checkSKU := func(_ context.Context, _ protoreflect.FieldDescriptor, value protoreflect.Value) error {
+		if !strings.HasPrefix(value.String(), "SKU-") {
+			return errors.New("not a SKU")
+		}
+		return nil
+	}
+	md := fd.Messages().ByName("Item")
+	for _, c := range []struct {
+		name       string
+		opts       []Option
+		json       string
+		compileErr bool
+		rule       string
+	}{
+		{"registered legal", []Option{WithFunc("checkSKU", checkSKU)}, `{"sku":"SKU-1"}`, false, ""},
+		{"registered illegal", []Option{WithFunc("checkSKU", checkSKU)}, `{"sku":"1"}`, false, "GoFunc"},
+		{"unregistered", nil, `{"sku":"SKU-1"}`, true, "GoFunc"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(c.opts...)
+			if err := v.Register(md); (err != nil) != c.compileErr {
+				t.Fatalf("Register: %v", err)
+			}
+			err := v.Validate(newMsg(t, fd, "Item", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, "sku", c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestGoFuncPanic(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package gofunc; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {go_func: "explode"}]; string name = 2 [(validator.field) = {length_gt: 1}]; }`)
+	explode := func(context.Context, protoreflect.FieldDescriptor, protoreflect.Value) error {
+		panic("boom")
+	}
+	for _, c := range []struct {
+		name     string
+		opts     []Option
+		internal bool
+	}{
+		{"fails by default", nil, true},
+		{"lenient", []Option{WithLenientPanics()}, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var faults []*Fault
+			logger := &testLogger{}
+			v := New(append([]Option{WithFunc("explode", explode), WithLogger(logger),
+				WithFaultReporter(func(fault *Fault) { faults = append(faults, fault) })}, c.opts...)...)
+			err := v.Validate(newMsg(t, fd, "Item", `{"sku":"a","name":"ab"}`))
+			var internal *InternalValidationError
+			if errors.As(err, &internal) != c.internal || !c.internal && err != nil {
+				t.Fatal(err)
+			}
+			if c.internal && (internal.Fault.Kind != FaultPanic || internal.Fault.Message != "gofunc.Item") {
+				t.Fatal(internal.Fault)
+			}
+			if len(faults) != 1 || faults[0].Kind != FaultPanic || len(faults[0].Stack) == 0 || !strings.Contains(faults[0].Err.Error(), "boom") {
+				t.Fatalf("%v", faults)
+			}
+			if len(logger.warnings) == 0 {
+				t.Fatal("panic not logged")
+			}
+		})
+	}
+}
@@ -3,9 +3,25 @@ module github.com/bafflingbug/go-proto-reflect-validators
 go 1.20
 
 require (
-	github.com/golang/protobuf v1.5.3
+	github.com/go-kit/kit v0.13.0
 	github.com/jhump/protoreflect v1.15.3
+	github.com/nats-io/nats.go v1.37.0
+	github.com/rivo/uniseg v0.4.7
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19
+	google.golang.org/grpc v1.57.0
 	google.golang.org/protobuf v1.31.0
+	sigs.k8s.io/yaml v1.4.0
 )
 
-require github.com/bufbuild/protocompile v0.6.0 // indirect
+require (
+	github.com/bufbuild/protocompile v0.6.0 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/klauspost/compress v1.17.2 // indirect
+	github.com/nats-io/nkeys v0.4.7 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
+	golang.org/x/crypto v0.18.0 // indirect
+	golang.org/x/net v0.10.0 // indirect
+	golang.org/x/sync v0.3.0 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
@@ -0,0 +1,12 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"github.com/rivo/uniseg"
+)
+
+// graphemeCount number of grapheme clusters of a string, ok is false if grapheme counting is excluded from the build
+func graphemeCount(value string) (n int64, ok bool) {
+	return int64(uniseg.GraphemeClusterCount(value)), true
+}
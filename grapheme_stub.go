@@ -0,0 +1,9 @@
+//go:build tinygo || purereflect
+
+package validator
+
+// graphemeCount grapheme counting is excluded from this build to keep the Unicode segmentation tables out of it,
+// rules counting graphemes reject every value
+func graphemeCount(string) (n int64, ok bool) {
+	return 0, false
+}
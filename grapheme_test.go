@@ -0,0 +1,61 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGraphemeLength(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package grapheme; import "validator.proto";
+message Profile {
+  string name = 1 [(validator.field) = {length_lt: 4, length_unit: LENGTH_UNIT_GRAPHEMES}];
+  string avatar = 2 [(validator.field) = {length_eq: 1, length_unit: LENGTH_UNIT_GRAPHEMES}];
+  string runes = 3 [(validator.field) = {length_lt: 4, length_unit: LENGTH_UNIT_RUNES}];
+}`)
+	const (
+		//e followed by a combining acute accent: 1 grapheme, 2 runes, 3 bytes
+		accented = "e\u0301"
+		//man, woman, girl and boy joined by zero width joiners: 1 grapheme, 7 runes, 25 bytes
+		family = "\U0001F468\u200d\U0001F469\u200d\U0001F467\u200d\U0001F466"
+		//thumbs up with a skin tone modifier: 1 grapheme, 2 runes
+		thumbsUp = "\U0001F44D\U0001F3FD"
+		//regional indicators F and R: 1 grapheme, 2 runes
+		flag = "\U0001F1EB\U0001F1F7"
+	)
+	for _, c := range []struct {
+		name, field, value, rule string
+	}{
+		{"ascii", "name", "abc", ""},
+		{"ascii too long", "name", "abcd", "LengthLt"},
+		{"combining marks", "name", accented + accented + accented, ""},
+		{"combining marks too long", "name", accented + accented + accented + accented, "LengthLt"},
+		{"stacked combining marks", "name", "a\u0301\u0302\u0303\u0304", ""},
+		{"zwj sequence", "name", family, ""},
+		{"zwj sequences", "name", family + family + family, ""},
+		{"zwj sequences too long", "name", family + family + family + family, "LengthLt"},
+		{"zwj sequence once", "avatar", family, ""},
+		{"modifier once", "avatar", thumbsUp, ""},
+		{"flag once", "avatar", flag, ""},
+		{"combining mark once", "avatar", accented, ""},
+		{"trailing zwj", "avatar", "\U0001F468\u200d", ""},
+		{"two emoji", "avatar", thumbsUp + flag, "LengthEq"},
+		//the same values counted in runes
+		{"combining marks in runes", "runes", accented + accented, "LengthLt"},
+		{"zwj sequence in runes", "runes", family, "LengthLt"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fields := map[string]string{"name": "a", "avatar": "a", "runes": "a"}
+			fields[c.field] = c.value
+			data, err := json.Marshal(fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = New().Validate(newMsg(t, fd, "Profile", string(data)))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.field, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"sort"
+	"strings"
+)
+
+// groupSet canonical set of active validation groups, sorted names joined by ",",
+// used with the message descriptor as the key of compiled program variants
+type groupSet string
+
+// newGroupSet canonical set of groups
+func newGroupSet(groups []string) groupSet {
+	sorted := append([]string(nil), groups...)
+	sort.Strings(sorted)
+	unique := sorted[:0]
+	for i, group := range sorted {
+		if i == 0 || group != sorted[i-1] {
+			unique = append(unique, group)
+		}
+	}
+	return groupSet(strings.Join(unique, ","))
+}
+
+// has whether group is active
+func (s groupSet) has(group string) bool {
+	for _, active := range strings.Split(string(s), ",") {
+		if active == group {
+			return true
+		}
+	}
+	return false
+}
+
+// scope the rule if enforced with the active groups, nil otherwise
+func (s groupSet) scope(rule *FieldValidator) *FieldValidator {
+	if rule == nil || len(rule.Groups) == 0 {
+		return rule
+	}
+	for _, group := range rule.Groups {
+		if s.has(group) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// WithGroups activate validation groups, rules of other groups are not enforced.
+// A program variant is compiled and cached per message and set of active groups.
+func WithGroups(groups ...string) Option {
+	return func(v *Validator) {
+		v.groups = newGroupSet(groups)
+	}
+}
+
+// CallGroups override WithGroups for the call
+func CallGroups(groups ...string) CallOption {
+	set := newGroupSet(groups)
+	return func(c *callOptions) {
+		c.groups = &set
+	}
+}
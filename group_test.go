@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package group; import "validator.proto";
+message User {
+  string id = 1 [(validator.field) = {length_gt: 1}];
+  string email = 2 [(validator.field) = {length_gt: 1, groups: ["create"]}];
+  string name = 3 [(validator.field) = {length_gt: 1, groups: ["create", "update"]}];
+}`)
+	msg := newMsg(t, fd, "User", `{"id":"a","email":"a","name":"a"}`)
+	for _, c := range []struct {
+		name   string
+		groups []string
+		call   []CallOption
+		fields []string
+	}{
+		{"no group", nil, nil, []string{"id"}},
+		{"create", []string{"create"}, nil, []string{"email", "id", "name"}},
+		{"update", []string{"update"}, nil, []string{"id", "name"}},
+		{"unknown group", []string{"delete"}, nil, []string{"id"}},
+		{"duplicate groups", []string{"update", "update"}, nil, []string{"id", "name"}},
+		{"several groups", []string{"update", "create"}, nil, []string{"email", "id", "name"}},
+		{"group prefix", []string{"creat"}, nil, []string{"id"}},
+		{"call groups", nil, []CallOption{CallGroups("create")}, []string{"email", "id", "name"}},
+		{"call groups replace", []string{"create"}, []CallOption{CallGroups("update")}, []string{"id", "name"}},
+		{"no call group", []string{"create"}, []CallOption{CallGroups()}, []string{"id"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(WithGroups(c.groups...), WithAggregation(AggregateAll))
+			//twice, the program variant of the groups is cached
+			for i := 0; i < 2; i++ {
+				var fields []string
+				for _, err := range ValidErrors(v.ValidateContext(ContextWithCallOptions(context.Background(), c.call...), msg)) {
+					fields = append(fields, err.Path())
+				}
+				sort.Strings(fields)
+				if strings.Join(fields, ",") != strings.Join(c.fields, ",") {
+					t.Fatalf("got %v, want %v", fields, c.fields)
+				}
+			}
+		})
+	}
+}
+
+func TestNewGroupSet(t *testing.T) {
+	for _, c := range []struct {
+		groups []string
+		set    groupSet
+	}{
+		{nil, ""},
+		{[]string{"b", "a", "b", "a"}, "a,b"},
+		{[]string{"a"}, "a"},
+	} {
+		if set := newGroupSet(c.groups); set != c.set {
+			t.Errorf("%v: got %q, want %q", c.groups, set, c.set)
+		}
+	}
+	//the caller's slice is left alone
+	groups := []string{"b", "a"}
+	newGroupSet(groups)
+	if groups[0] != "b" {
+		t.Fatal(groups)
+	}
+}
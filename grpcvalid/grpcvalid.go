@@ -0,0 +1,217 @@
+// Package grpcvalid gRPC server interceptors validating proto requests, responses and streamed messages
+package grpcvalid
+
+import (
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// Option interceptor option
+type Option func(*options)
+
+// options interceptor options
+type options struct {
+	validator     *validator.Validator
+	responseMode  validator.ResponseMode
+	trustMetadata string
+}
+
+// WithValidator validate with v instead of the default validator
+func WithValidator(v *validator.Validator) Option {
+	return func(o *options) {
+		o.validator = v
+	}
+}
+
+// WithResponseMode validate the responses, or the messages sent on streams, handling the invalid ones by mode:
+// ResponseFailClosed replaces them by an INTERNAL error, ResponseLogOnly logs and sends them.
+// Responses are not validated by default (validator.ResponseSkip).
+func WithResponseMode(mode validator.ResponseMode) Option {
+	return func(o *options) {
+		o.responseMode = mode
+	}
+}
+
+// WithTrustTokenMetadata read the trust token of the requests (see validator.SignTrustToken) from the incoming metadata key,
+// e.g. "x-validator-trust-token", and attach it to their validation context with the full method of the RPC,
+// so the fields with pre_validated are skipped for the requests signed by the edge (see validator.WithTrustKey)
+func WithTrustTokenMetadata(key string) Option {
+	return func(o *options) {
+		o.trustMetadata = key
+	}
+}
+
+// newOptions apply the interceptor options
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// methodContext attach the validation groups and overlay of the method (see validator.MethodValidator) to ctx,
+// and the trust token of the incoming metadata with WithTrustTokenMetadata
+func (o *options) methodContext(ctx context.Context, fullMethod string) context.Context {
+	if o.trustMetadata != "" {
+		if tokens := metadata.ValueFromIncomingContext(ctx, o.trustMetadata); len(tokens) > 0 {
+			ctx = validator.ContextWithTrustToken(ctx, fullMethod, tokens[0])
+		}
+	}
+	if o.validator == nil {
+		return validator.ContextForFullMethod(ctx, fullMethod)
+	}
+	return o.validator.ContextForFullMethod(ctx, fullMethod)
+}
+
+// validate verify a request with the options attached to ctx by methodContext
+func (o *options) validate(ctx context.Context, req interface{}) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if o.validator == nil {
+		return validator.ValidateContext(ctx, msg)
+	}
+	return o.validator.ValidateContext(ctx, msg)
+}
+
+// validateResponse verify a response, or a message sent on a stream, following the response mode.
+// An invalid response is a fault of the server, not of the client, hence INTERNAL.
+func (o *options) validateResponse(ctx context.Context, fullMethod string, resp interface{}) error {
+	if o.responseMode == validator.ResponseSkip {
+		return nil
+	}
+	err := o.validate(ctx, resp)
+	if err == nil {
+		return nil
+	}
+	if o.responseMode == validator.ResponseLogOnly {
+		logger := validator.GetLogger()
+		if o.validator != nil {
+			logger = o.validator.Logger()
+		}
+		logger.Warnf("[pb valid]invalid response of method[%s] err: %s", fullMethod, err)
+		return nil
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// UnaryServerInterceptor validate the requests of unary RPCs before calling the handler.
+// An invalid request is rejected with INVALID_ARGUMENT and a google.rpc.BadRequest detail listing the violations,
+// a failure of the validation itself with INTERNAL. Requests which are not proto messages are passed through.
+// Responses are validated after the handler with WithResponseMode.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := o.validate(o.methodContext(ctx, info.FullMethod), req); err != nil {
+			return nil, statusError(err)
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if err := o.validateResponse(ctx, info.FullMethod, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor validate every message received on client-streaming and bidi RPCs, and the request
+// of server-streaming RPCs. The method is resolved once per stream and the compiled programs are shared across its messages.
+// The first invalid message fails RecvMsg with INVALID_ARGUMENT (see UnaryServerInterceptor), and every later RecvMsg
+// with the same error, so the stream is rejected once the handler returns it.
+// Sent messages are validated with WithResponseMode, an invalid message fails SendMsg and is not sent.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{
+			ServerStream: ss,
+			options:      o,
+			fullMethod:   info.FullMethod,
+			ctx:          o.methodContext(ss.Context(), info.FullMethod),
+		})
+	}
+}
+
+// serverStream server stream validating the received and sent messages
+type serverStream struct {
+	grpc.ServerStream
+	options    *options
+	fullMethod string
+	//ctx validation context of the method
+	ctx context.Context
+	//err error of the first invalid message
+	err error
+}
+
+// SendMsg validate a message and send it
+func (s *serverStream) SendMsg(m interface{}) error {
+	if err := s.options.validateResponse(s.ServerStream.Context(), s.fullMethod, m); err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// RecvMsg receive a message and validate it
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := s.options.validate(s.ctx, m); err != nil {
+		s.err = statusError(err)
+		return s.err
+	}
+	return nil
+}
+
+// BadRequest google.rpc.BadRequest listing the violations held by err, one FieldViolation per *validator.ValidError
+// with the dotted path of the violating value (e.g. "items[1].sku") and the message of the violation.
+// It is nil if err holds no violation.
+func BadRequest(err error) *errdetails.BadRequest {
+	violations := validator.ValidErrors(err)
+	if len(violations) == 0 {
+		return nil
+	}
+	br := &errdetails.BadRequest{}
+	for _, e := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       e.FormatPath(validator.PathDotted),
+			Description: e.Message(),
+		})
+	}
+	return br
+}
+
+// Status gRPC status of a validation error: INVALID_ARGUMENT with the BadRequest detail of the violations,
+// INTERNAL if err holds no violation (e.g. a *validator.InternalValidationError), nil if err is nil
+func Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	br := BadRequest(err)
+	if br == nil {
+		return status.New(codes.Internal, err.Error())
+	}
+	st := status.New(codes.InvalidArgument, err.Error())
+	if detailed, derr := st.WithDetails(br); derr == nil {
+		st = detailed
+	}
+	return st
+}
+
+// statusError gRPC status error of a validation error
+func statusError(err error) error {
+	return Status(err).Err()
+}
@@ -0,0 +1,232 @@
+package grpcvalid
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"testing"
+	"time"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// testLogger logger recording the warnings
+type testLogger struct {
+	warnings []string
+}
+
+// Debugf implement validator.Logger
+func (l *testLogger) Debugf(string, ...interface{}) {}
+
+// Warnf implement validator.Logger
+func (l *testLogger) Warnf(format string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
+
+// testValidator validator requiring google.protobuf.StringValue values longer than 3 bytes
+func testValidator(logger validator.Logger) *validator.Validator {
+	gt := int64(3)
+	rules := &validator.RuleSet{Messages: map[string]*validator.MessageRules{
+		"google.protobuf.StringValue": {Fields: map[string]*validator.FieldValidator{"value": {LengthGt: &gt}}},
+	}}
+	return validator.New(validator.WithLogger(logger), validator.WithRuleSource(validator.RuleSourceFunc(
+		func(context.Context, string) (*validator.RuleSet, error) { return rules, nil },
+	)))
+}
+
+// testStream server stream recording the sent messages
+type testStream struct {
+	grpc.ServerStream
+	sent []interface{}
+}
+
+// Context implement grpc.ServerStream
+func (s *testStream) Context() context.Context {
+	return context.Background()
+}
+
+// SendMsg implement grpc.ServerStream
+func (s *testStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+// RecvMsg implement grpc.ServerStream, leaving m as built by the handler
+func (s *testStream) RecvMsg(interface{}) error {
+	return nil
+}
+
+func TestResponseMode(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	for _, c := range []struct {
+		name   string
+		opts   []Option
+		code   codes.Code
+		warned bool
+	}{
+		{"skipped by default", nil, codes.OK, false},
+		{"fail closed", []Option{WithResponseMode(validator.ResponseFailClosed)}, codes.Internal, false},
+		{"log only", []Option{WithResponseMode(validator.ResponseLogOnly)}, codes.OK, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			logger := &testLogger{}
+			opts := append([]Option{WithValidator(testValidator(logger))}, c.opts...)
+			for _, resp := range []string{"long enough", "bad"} {
+				handler := func(context.Context, interface{}) (interface{}, error) { return wrapperspb.String(resp), nil }
+				got, err := UnaryServerInterceptor(opts...)(context.Background(), wrapperspb.String("request"), info, handler)
+				code := codes.OK
+				if resp == "bad" {
+					code = c.code
+				}
+				if status.Code(err) != code || code == codes.OK && got == nil {
+					t.Fatalf("unary %s: %v", resp, err)
+				}
+
+				stream := &testStream{}
+				err = StreamServerInterceptor(opts...)(nil, stream, &grpc.StreamServerInfo{FullMethod: info.FullMethod},
+					func(_ interface{}, ss grpc.ServerStream) error { return ss.SendMsg(wrapperspb.String(resp)) })
+				if status.Code(err) != code || (code == codes.OK) != (len(stream.sent) == 1) {
+					t.Fatalf("stream %s: %v, sent %d", resp, err, len(stream.sent))
+				}
+			}
+			if (len(logger.warnings) > 0) != c.warned {
+				t.Fatal(logger.warnings)
+			}
+		})
+	}
+}
+
+func TestTrustTokenMetadata(t *testing.T) {
+	const method = "/test.Service/Get"
+	const key = "x-validator-trust-token"
+	gt, trustKey := int64(3), []byte("k")
+	rules := &validator.RuleSet{Messages: map[string]*validator.MessageRules{
+		"google.protobuf.StringValue": {Fields: map[string]*validator.FieldValidator{"value": {LengthGt: &gt, PreValidated: proto.Bool(true)}}},
+	}}
+	v := validator.New(validator.WithTrustKey(trustKey), validator.WithRuleSource(validator.RuleSourceFunc(
+		func(context.Context, string) (*validator.RuleSet, error) { return rules, nil },
+	)))
+	req := wrapperspb.String("bad")
+	token, err := validator.SignTrustToken(trustKey, method, req, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := func(context.Context, interface{}) (interface{}, error) { return req, nil }
+	for _, c := range []struct {
+		name string
+		opts []Option
+		md   metadata.MD
+		code codes.Code
+	}{
+		{"token", []Option{WithTrustTokenMetadata(key)}, metadata.Pairs(key, token), codes.OK},
+		{"no token", []Option{WithTrustTokenMetadata(key)}, metadata.MD{}, codes.InvalidArgument},
+		{"invalid token", []Option{WithTrustTokenMetadata(key)}, metadata.Pairs(key, "garbage"), codes.InvalidArgument},
+		{"metadata not read", nil, metadata.Pairs(key, token), codes.InvalidArgument},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			opts := append([]Option{WithValidator(v)}, c.opts...)
+			ctx := metadata.NewIncomingContext(context.Background(), c.md)
+			_, err := UnaryServerInterceptor(opts...)(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+			if status.Code(err) != c.code {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// registerMethods register the service grpcvalid.test.Users in protoregistry.GlobalFiles,
+// with Create scoped to the group "create" and Rename to the overlay "strict" by their validator.method option
+func registerMethods(t *testing.T) {
+	t.Helper()
+	const path = "grpcvalid/test/users.proto"
+	if _, err := protoregistry.GlobalFiles.FindFileByPath(path); err == nil {
+		return
+	}
+	method := func(name string, rule *validator.MethodValidator) *descriptorpb.MethodDescriptorProto {
+		opts := &descriptorpb.MethodOptions{}
+		if rule != nil {
+			proto.SetExtension(opts, validator.E_Method, rule)
+		}
+		return &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(".google.protobuf.StringValue"),
+			OutputType: proto.String(".google.protobuf.StringValue"),
+			Options:    opts,
+		}
+	}
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String(path),
+		Package:    proto.String("grpcvalid.test"),
+		Dependency: []string{"google/protobuf/wrappers.proto"},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Users"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("Create", &validator.MethodValidator{Groups: []string{"create"}}),
+				method("Rename", &validator.MethodValidator{Overlay: proto.String("strict")}),
+				method("Get", nil),
+			},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMethodRules(t *testing.T) {
+	registerMethods(t)
+	gt, lt := int64(3), int64(6)
+	rules := &validator.RuleSet{Messages: map[string]*validator.MessageRules{
+		"google.protobuf.StringValue": {Fields: map[string]*validator.FieldValidator{"value": {LengthGt: &gt, Groups: []string{"create"}}}},
+	}}
+	strict := &validator.RuleSet{
+		Name:     proto.String("strict"),
+		Messages: map[string]*validator.MessageRules{"google.protobuf.StringValue": {Fields: map[string]*validator.FieldValidator{"value": {LengthLt: &lt}}}},
+	}
+	handler := func(_ context.Context, req interface{}) (interface{}, error) { return req, nil }
+	for _, c := range []struct {
+		name, method, value string
+		opts                []validator.Option
+		code                codes.Code
+	}{
+		{"no option", "Get", "ab", nil, codes.OK},
+		{"option groups", "Create", "ab", nil, codes.InvalidArgument},
+		{"option groups legal", "Create", "abcd", nil, codes.OK},
+		{"option overlay", "Rename", "too long", nil, codes.InvalidArgument},
+		{"option overlay legal", "Rename", "abc", nil, codes.OK},
+		{"overlay not on other methods", "Get", "too long", nil, codes.OK},
+		{"method rule", "Get", "ab", []validator.Option{validator.WithMethodRule("grpcvalid.test.Users.Get",
+			&validator.MethodValidator{Groups: []string{"create"}})}, codes.InvalidArgument},
+		{"method rule over the option", "Create", "ab", []validator.Option{validator.WithMethodRule("grpcvalid.test.Users.Create",
+			&validator.MethodValidator{})}, codes.OK},
+		{"unknown method", "Delete", "ab", nil, codes.OK},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := validator.New(append([]validator.Option{validator.WithOverlay(strict), validator.WithRuleSource(validator.RuleSourceFunc(
+				func(context.Context, string) (*validator.RuleSet, error) { return rules, nil },
+			))}, c.opts...)...)
+			info := &grpc.UnaryServerInfo{FullMethod: "/grpcvalid.test.Users/" + c.method}
+			_, err := UnaryServerInterceptor(WithValidator(v))(context.Background(), wrapperspb.String(c.value), info, handler)
+			if status.Code(err) != c.code {
+				t.Fatalf("unary: %v", err)
+			}
+
+			stream := &testStream{}
+			err = StreamServerInterceptor(WithValidator(v))(nil, stream, &grpc.StreamServerInfo{FullMethod: info.FullMethod},
+				func(_ interface{}, ss grpc.ServerStream) error { return ss.RecvMsg(wrapperspb.String(c.value)) })
+			if status.Code(err) != c.code {
+				t.Fatalf("stream: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"regexp"
+)
+
+// hashPatterns patterns of the hash formats
+var hashPatterns = map[HashFormat]*regexp.Regexp{
+	HashFormat_HASH_FORMAT_BCRYPT:     regexp.MustCompile(`^\$2[aby]\$(0[4-9]|[12][0-9]|3[01])\$[./A-Za-z0-9]{53}$`),
+	HashFormat_HASH_FORMAT_ARGON2:     regexp.MustCompile(`^\$argon2(id|i|d)\$v=[0-9]+\$m=[0-9]+,t=[0-9]+,p=[0-9]+\$[A-Za-z0-9+/]+\$[A-Za-z0-9+/]+$`),
+	HashFormat_HASH_FORMAT_SHA256_HEX: regexp.MustCompile(`^[0-9a-fA-F]{64}$`),
+	HashFormat_HASH_FORMAT_SHA512_HEX: regexp.MustCompile(`^[0-9a-fA-F]{128}$`),
+}
+
+// checkHash check that a string is a hash of one of the hash_format formats, the value is redacted in the violation
+func (v *validator) checkHash(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if len(rule.HashFormat) == 0 {
+		return nil
+	}
+	for _, format := range rule.HashFormat {
+		if exp := hashPatterns[format]; exp != nil && exp.MatchString(value) {
+			return nil
+		}
+	}
+	return v.fail(field, "HashFormat", rule.HashFormat, redact(value))
+}
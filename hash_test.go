@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHashFormat(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package hash; import "validator.proto";
+message Unspecified { string digest = 1 [(validator.field) = {hash_format: HASH_FORMAT_UNSPECIFIED}]; }
+message Credential {
+  string bcrypt = 1 [(validator.field) = {hash_format: HASH_FORMAT_BCRYPT}];
+  string argon2 = 2 [(validator.field) = {hash_format: HASH_FORMAT_ARGON2}];
+  string sha256 = 3 [(validator.field) = {hash_format: HASH_FORMAT_SHA256_HEX}];
+  string sha512 = 4 [(validator.field) = {hash_format: HASH_FORMAT_SHA512_HEX}];
+  string any = 5 [(validator.field) = {hash_format: [HASH_FORMAT_BCRYPT, HASH_FORMAT_SHA256_HEX]}];
+  repeated string digests = 6 [(validator.field) = {hash_format: HASH_FORMAT_SHA256_HEX}];
+}`)
+	bcrypt := "$2b$12$" + strings.Repeat("a", 53)
+	sha256 := strings.Repeat("ab", 32)
+	for _, c := range []struct {
+		name, field string
+		value       interface{}
+		valid       bool
+	}{
+		{"bcrypt", "bcrypt", bcrypt, true},
+		{"bcrypt 2a", "bcrypt", "$2a$04$" + strings.Repeat("./", 26) + "Z", true},
+		{"bcrypt 2y max cost", "bcrypt", "$2y$31$" + strings.Repeat("a", 53), true},
+		{"bcrypt cost too low", "bcrypt", "$2b$03$" + strings.Repeat("a", 53), false},
+		{"bcrypt cost too high", "bcrypt", "$2b$32$" + strings.Repeat("a", 53), false},
+		{"bcrypt unknown prefix", "bcrypt", "$2x$12$" + strings.Repeat("a", 53), false},
+		{"bcrypt too short", "bcrypt", "$2b$12$" + strings.Repeat("a", 52), false},
+		{"bcrypt too long", "bcrypt", "$2b$12$" + strings.Repeat("a", 54), false},
+		{"bcrypt invalid character", "bcrypt", "$2b$12$" + strings.Repeat("a", 52) + "+", false},
+		{"argon2id", "argon2", "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$aGFzaA", true},
+		{"argon2i", "argon2", "$argon2i$v=19$m=4096,t=3,p=1$c2FsdA$aGFzaA", true},
+		{"argon2d", "argon2", "$argon2d$v=19$m=4096,t=3,p=1$c2FsdA$aGFzaA", true},
+		{"argon2 unknown variant", "argon2", "$argon2x$v=19$m=4096,t=3,p=1$c2FsdA$aGFzaA", false},
+		{"argon2 without hash", "argon2", "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA", false},
+		{"argon2 without parameters", "argon2", "$argon2id$v=19$c2FsdA$aGFzaA", false},
+		{"sha256", "sha256", sha256, true},
+		{"sha256 upper case", "sha256", strings.ToUpper(sha256), true},
+		{"sha256 63 digits", "sha256", sha256[1:], false},
+		{"sha256 65 digits", "sha256", sha256 + "a", false},
+		{"sha256 not hex", "sha256", sha256[1:] + "g", false},
+		{"sha512", "sha512", sha256 + sha256, true},
+		{"sha512 of sha256 length", "sha512", sha256, false},
+		{"plaintext", "sha256", "hunter2", false},
+		{"unset", "sha256", "", false},
+		{"first of formats", "any", bcrypt, true},
+		{"second of formats", "any", sha256, true},
+		{"none of formats", "any", sha256 + sha256, false},
+		{"elements", "digests", []string{sha256, sha256}, true},
+		{"illegal element", "digests", []string{sha256, "hunter2"}, false},
+		{"no element", "digests", []string{}, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			//every other field is legal
+			fields := map[string]interface{}{"bcrypt": bcrypt, "argon2": "$argon2id$v=19$m=1,t=1,p=1$a$b", "sha256": sha256,
+				"sha512": sha256 + sha256, "any": sha256}
+			fields[c.field] = c.value
+			data, err := json.Marshal(fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = New().ValidateAll(newMsg(t, fd, "Credential", string(data)))
+			if (err == nil) != c.valid || err != nil && !strings.HasPrefix(ValidErrors(err)[0].Path(), c.field) {
+				t.Fatal(err)
+			}
+			//the value is never echoed
+			if s, ok := c.value.(string); ok && len(s) > 0 && err != nil && strings.Contains(err.Error(), s) {
+				t.Fatal(err)
+			}
+		})
+	}
+	//no format is accepted for the unspecified format
+	if err := New().Validate(newMsg(t, fd, "Unspecified", `{"digest":"`+sha256+`"}`)); !MatchViolation(err, "digest", "HashFormat") {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"sync/atomic"
+)
+
+// ruleIndex rules of the fields of every message registered in protoregistry.GlobalFiles
+type ruleIndex struct {
+	//messages indexed messages
+	messages map[protoreflect.MessageDescriptor]bool
+	//rules rule-bearing fields of the indexed messages
+	rules map[protoreflect.FieldDescriptor]*FieldValidator
+	//errs errors decoding the rules of the indexed fields
+	errs map[protoreflect.FieldDescriptor]error
+}
+
+// index built rule index, nil until BuildIndex is called
+var index atomic.Pointer[ruleIndex]
+
+// BuildIndex range protoregistry.GlobalFiles once and record the rules of every field.
+// Later rule lookups of the indexed messages are served from the index instead of decoding field options.
+// Call it after all generated packages are initialized, e.g. at the start of main, and again if files are registered later.
+func BuildIndex() {
+	idx := &ruleIndex{
+		messages: make(map[protoreflect.MessageDescriptor]bool),
+		rules:    make(map[protoreflect.FieldDescriptor]*FieldValidator),
+		errs:     make(map[protoreflect.FieldDescriptor]error),
+	}
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		idx.add(fd.Messages())
+		return true
+	})
+	index.Store(idx)
+}
+
+// ResetIndex drop the rule index, rules are decoded from field options again
+func ResetIndex() {
+	index.Store(nil)
+}
+
+// add index messages and their nested messages
+func (idx *ruleIndex) add(messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		idx.messages[md] = true
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			rule, err := decodeRule(field, protoregistry.GlobalTypes)
+			if rule != nil {
+				idx.rules[field] = rule
+			}
+			if err != nil {
+				idx.errs[field] = err
+			}
+		}
+		idx.add(md.Messages())
+	}
+}
+
+// lookup rule of an indexed field and the error decoding it, ok is false if the message of the field is not indexed
+func (idx *ruleIndex) lookup(field protoreflect.FieldDescriptor) (rule *FieldValidator, err error, ok bool) {
+	if !idx.messages[field.ContainingMessage()] {
+		return nil, nil, false
+	}
+	return idx.rules[field], idx.errs[field], true
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"strings"
+	"testing"
+)
+
+// registerIndexProto register the file validator/index_test.proto in protoregistry.GlobalFiles for BuildIndex,
+// the field corrupt of index.Order holds an undecodable rule
+func registerIndexProto(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	const path = "validator/index_test.proto"
+	if fd, err := protoregistry.GlobalFiles.FindFileByPath(path); err == nil {
+		return fd
+	}
+	fdp := protodesc.ToFileDescriptorProto(compileProto(t, `syntax = "proto3"; package index; import "validator.proto";
+message Order {
+  message Line { string sku = 1 [(validator.field) = {length_gt: 1}]; }
+  string id = 1 [(validator.field) = {regex: "^[a-z]+$", length_lt: 8}];
+  string note = 2;
+  repeated Line lines = 3 [(validator.field) = {repeated_count_max: 2}];
+  string corrupt = 4;
+}`))
+	fdp.Name = proto.String(path)
+	rule, err := proto.Marshal(&FieldValidator{LengthGt: proto.Int64(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &descriptorpb.FieldOptions{}
+	//the tag of length_gt again, without value
+	opts.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, E_Field.TypeDescriptor().Number(), protowire.BytesType), append(rule, rule[0])))
+	fdp.MessageType[0].Field[3].Options = opts
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+func TestBuildIndex(t *testing.T) {
+	fd := registerIndexProto(t)
+	order := fd.Messages().ByName("Order")
+	BuildIndex()
+	defer ResetIndex()
+	idx := index.Load()
+
+	//every field of the indexed messages, nested ones included, decodes as without the index
+	for _, md := range []protoreflect.MessageDescriptor{order, order.Messages().ByName("Line")} {
+		for i := 0; i < md.Fields().Len(); i++ {
+			field := md.Fields().Get(i)
+			rule, err, ok := idx.lookup(field)
+			if !ok {
+				t.Fatalf("%s not indexed", field.FullName())
+			}
+			want, wantErr := decodeRule(field, protoregistry.GlobalTypes)
+			if !proto.Equal(rule, want) || fmt.Sprint(err) != fmt.Sprint(wantErr) {
+				t.Fatalf("%s: got %v %v, want %v %v", field.FullName(), rule, err, want, wantErr)
+			}
+		}
+	}
+	if rule, _, _ := idx.lookup(order.Fields().ByName("id")); rule.GetLengthLt() != 8 || rule.GetRegex() != "^[a-z]+$" {
+		t.Fatal(rule)
+	}
+	if _, err, _ := idx.lookup(order.Fields().ByName("corrupt")); err == nil || !strings.Contains(err.Error(), "index.Order.corrupt] decode rule") {
+		t.Fatal(err)
+	}
+	//messages outside protoregistry.GlobalFiles are decoded from their options
+	other := compileProto(t, `syntax = "proto3"; package index; import "validator.proto";
+message Order { string id = 1 [(validator.field) = {length_gt: 1}]; }`)
+	if _, _, ok := idx.lookup(other.Messages().ByName("Order").Fields().ByName("id")); ok {
+		t.Fatal("message of another file indexed")
+	}
+	if err := New().Validate(newMsg(t, other, "Order", `{"id":"a"}`)); !MatchViolation(err, "id", "LengthGt") {
+		t.Fatal(err)
+	}
+
+	//the validations agree with and without the index
+	for _, json := range []string{`{"id":"abc"}`, `{"id":"A"}`, `{"id":"abcdefgh"}`, `{"id":"a","lines":[{"sku":"a"}]}`,
+		`{"id":"a","lines":[{},{},{}]}`, `{"id":"a","corrupt":"a"}`} {
+		msg := newMsg(t, fd, "Order", json)
+		validate := func() string {
+			v := New(WithAggregation(AggregateAll), WithRuleDecodeViolations(), WithLogger(&testLogger{}))
+			return strings.Join(violationKeys(v.Validate(msg)), ",")
+		}
+		indexed := validate()
+		ResetIndex()
+		decoded := validate()
+		BuildIndex()
+		if indexed != decoded {
+			t.Fatalf("%s: got %s with the index, %s without", json, indexed, decoded)
+		}
+	}
+	if keys := strings.Join(violationKeys(New(WithAggregation(AggregateAll), WithRuleDecodeViolations(), WithLogger(&testLogger{})).Validate(
+		newMsg(t, fd, "Order", `{"id":"A","lines":[{"sku":"a"}]}`))), ","); keys != "id:Regex,lines[0].sku:LengthGt,corrupt:RuleDecode" {
+		t.Fatal(keys)
+	}
+
+	ResetIndex()
+	if index.Load() != nil {
+		t.Fatal("index not reset")
+	}
+}
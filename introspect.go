@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RulesFor rules of the fields of a message with the default validator, see (*Validator).RulesFor
+func RulesFor(md protoreflect.MessageDescriptor) map[string]*FieldValidator {
+	return std().RulesFor(md)
+}
+
+// HasRules whether a field of a message has rules with the default validator
+func HasRules(md protoreflect.MessageDescriptor) bool {
+	return std().HasRules(md)
+}
+
+// RulesFor rules of the fields of a message keyed by field name, as annotated and scoped to the schema version
+// of the validator, e.g. for doc generators, gateways and client SDK generators.
+// Fields without rules are omitted, rules of nested messages are not included.
+// The returned rules are copies and may be modified.
+func (v *Validator) RulesFor(md protoreflect.MessageDescriptor) map[string]*FieldValidator {
+	rules := make(map[string]*FieldValidator)
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if rule := v.scopeRule(getRule(field, v.resolver)); rule != nil {
+			rules[string(field.Name())] = proto.Clone(rule).(*FieldValidator)
+		}
+	}
+	return rules
+}
+
+// HasRules whether a field of a message has rules scoped to the schema version of the validator.
+// The answer is cached per message descriptor, it is cheap enough for hot paths, e.g. to skip validation.
+func (v *Validator) HasRules(md protoreflect.MessageDescriptor) bool {
+	if x, ok := v.hasRules.Load(md); ok {
+		return x.(bool)
+	}
+	has := false
+	fields := md.Fields()
+	for i := 0; i < fields.Len() && !has; i++ {
+		has = v.scopeRule(getRule(fields.Get(i), v.resolver)) != nil
+	}
+	v.hasRules.Store(md, has)
+	return has
+}
+
+// FileRanger set of file descriptors, e.g. *protoregistry.Files
+type FileRanger interface {
+	RangeFiles(f func(protoreflect.FileDescriptor) bool)
+}
+
+// WalkRulesFunc called for every rule-bearing field by WalkRules, a returned error stops the walk
+type WalkRulesFunc func(message protoreflect.MessageDescriptor, field protoreflect.FieldDescriptor, rule *FieldValidator) error
+
+// WalkRules call fn for every field with an annotated rule in files and their nested messages,
+// e.g. to list every field with a regex longer than 200 chars. Rules are decoded with protoregistry.GlobalTypes.
+// The error returned by fn is returned.
+func WalkRules(files FileRanger, fn WalkRulesFunc) (err error) {
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		err = WalkFileRules(fd, fn)
+		return err == nil
+	})
+	return err
+}
+
+// WalkFileRules call fn for every field with an annotated rule in a file and its nested messages
+func WalkFileRules(fd protoreflect.FileDescriptor, fn WalkRulesFunc) error {
+	return walkMessageRules(fd.Messages(), fn)
+}
+
+// walkMessageRules call fn for every field with an annotated rule in messages and their nested messages
+func walkMessageRules(messages protoreflect.MessageDescriptors, fn WalkRulesFunc) error {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			if rule := getRule(field, protoregistry.GlobalTypes); rule != nil {
+				if err := fn(md, field, rule); err != nil {
+					return err
+				}
+			}
+		}
+		if err := walkMessageRules(md.Messages(), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"errors"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testIntrospectProto messages with unscoped, versioned and nested rules
+const testIntrospectProto = `syntax = "proto3"; package introspect; import "validator.proto";
+message Plain { string a = 1; }
+message Item {
+  string sku = 1 [(validator.field) = {length_gt: 1}];
+  string note = 2;
+  string code = 3 [(validator.field) = {regex: "^[A-Z]+$", since_version: "2"}];
+  string legacy = 4 [(validator.field) = {length_lt: 10, until_version: "2"}];
+  int64 count = 5 [(validator.field) = {int_gt: 0, versioned: [{since_version: "3", int_lt: 100}]}];
+  message Inner { string x = 1 [(validator.field) = {length_gt: 1}]; }
+  Inner inner = 6;
+}
+message Versioned { string code = 1 [(validator.field) = {regex: "^[A-Z]+$", since_version: "2"}]; }`
+
+func TestRulesFor(t *testing.T) {
+	fd := compileProto(t, testIntrospectProto)
+	for _, c := range []struct {
+		name, message, version string
+		rules                  map[string]string
+	}{
+		{"no rule", "Plain", "", map[string]string{}},
+		//without a schema version the latest rules apply
+		{"unversioned", "Item", "", map[string]string{"sku": `length_gt: 1`, "code": `regex: "^[A-Z]+$" since_version: "2"`, "count": `int_gt: 0 int_lt: 100 since_version: "3"`}},
+		{"before a version", "Item", "1", map[string]string{"sku": `length_gt: 1`, "legacy": `length_lt: 10 until_version: "2"`, "count": `int_gt: 0`}},
+		{"at a version", "Item", "2", map[string]string{"sku": `length_gt: 1`, "code": `regex: "^[A-Z]+$" since_version: "2"`, "count": `int_gt: 0`}},
+		{"versioned rule", "Item", "3.1", map[string]string{"sku": `length_gt: 1`, "code": `regex: "^[A-Z]+$" since_version: "2"`, "count": `int_gt: 0 int_lt: 100 since_version: "3"`}},
+		{"nested message", "Item.Inner", "", map[string]string{"x": `length_gt: 1`}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var md protoreflect.MessageDescriptor
+			messages := fd.Messages()
+			for _, name := range strings.Split(c.message, ".") {
+				md = messages.ByName(protoreflect.Name(name))
+				messages = md.Messages()
+			}
+			rules := New(WithSchemaVersion(c.version)).RulesFor(md)
+			var got, want []string
+			for name, rule := range rules {
+				got = append(got, name+" "+prototext.MarshalOptions{}.Format(rule))
+			}
+			for name, text := range c.rules {
+				rule := &FieldValidator{}
+				if err := prototext.Unmarshal([]byte(text), rule); err != nil {
+					t.Fatal(err)
+				}
+				if !proto.Equal(rules[name], rule) {
+					t.Errorf("%s: got %v, want %v", name, rules[name], rule)
+				}
+				want = append(want, name)
+			}
+			if len(rules) != len(c.rules) {
+				sort.Strings(got)
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRulesForCopies(t *testing.T) {
+	fd := compileProto(t, testIntrospectProto)
+	md := fd.Messages().ByName("Item")
+	v := New()
+	rules := v.RulesFor(md)
+	*rules["sku"].LengthGt = 100
+	if got := v.RulesFor(md)["sku"].GetLengthGt(); got != 1 {
+		t.Fatal(got)
+	}
+	if err := v.Validate(newMsg(t, fd, "Item", `{"sku":"ab","code":"AB","count":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHasRules(t *testing.T) {
+	fd := compileProto(t, testIntrospectProto)
+	for _, c := range []struct {
+		message, version string
+		has              bool
+	}{
+		{"Plain", "", false},
+		{"Item", "", true},
+		{"Versioned", "", true},
+		{"Versioned", "1", false},
+		{"Versioned", "2", true},
+	} {
+		v := New(WithSchemaVersion(c.version))
+		md := fd.Messages().ByName(protoreflect.Name(c.message))
+		//the second call is answered from the cache
+		for i := 0; i < 2; i++ {
+			if v.HasRules(md) != c.has {
+				t.Errorf("%s at version %q: want %v", c.message, c.version, c.has)
+			}
+		}
+	}
+}
+
+// testFiles files ranged in order
+type testFiles []protoreflect.FileDescriptor
+
+func (files testFiles) RangeFiles(f func(protoreflect.FileDescriptor) bool) {
+	for _, fd := range files {
+		if !f(fd) {
+			return
+		}
+	}
+}
+
+func TestWalkRules(t *testing.T) {
+	walk := func(t *testing.T, files FileRanger, stop string) ([]string, error) {
+		var fields []string
+		err := WalkRules(files, func(md protoreflect.MessageDescriptor, field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+			if rule == nil || field.ContainingMessage() != md {
+				t.Errorf("%s: rule %v of %s", field.FullName(), rule, md.FullName())
+			}
+			fields = append(fields, string(field.FullName()))
+			if string(field.FullName()) == stop {
+				return errors.New("stop")
+			}
+			return nil
+		})
+		return fields, err
+	}
+	files := testFiles{compileProto(t, testIntrospectProto), compileProto(t, `syntax = "proto3"; package walk; import "validator.proto";
+message Order { map<string, string> tags = 1 [(validator.field) = {map_key_regex: "^[a-z]+$"}]; }
+message Empty {}`)}
+	for _, c := range []struct {
+		name, stop string
+		fields     []string
+		err        bool
+	}{
+		{"all", "", []string{"introspect.Item.sku", "introspect.Item.code", "introspect.Item.legacy", "introspect.Item.count",
+			"introspect.Item.Inner.x", "introspect.Versioned.code", "walk.Order.tags"}, false},
+		{"stopped in a nested message", "introspect.Item.Inner.x", []string{"introspect.Item.sku", "introspect.Item.code",
+			"introspect.Item.legacy", "introspect.Item.count", "introspect.Item.Inner.x"}, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fields, err := walk(t, files, c.stop)
+			if (err != nil) != c.err {
+				t.Fatal(err)
+			}
+			if strings.Join(fields, ",") != strings.Join(c.fields, ",") {
+				t.Errorf("got %v, want %v", fields, c.fields)
+			}
+		})
+	}
+	//a file without rules
+	var fields []string
+	if err := WalkFileRules(compileProto(t, `syntax = "proto3"; package none; message Plain { message Inner { string a = 1; } }`),
+		func(md protoreflect.MessageDescriptor, field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+			fields = append(fields, string(field.FullName()))
+			return nil
+		}); err != nil || len(fields) != 0 {
+		t.Fatal(fields, err)
+	}
+}
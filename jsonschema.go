@@ -0,0 +1,234 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"math"
+	"strings"
+)
+
+// jsonSchema keywords of a JSON Schema (or OpenAPI schema object) mapped onto rules
+type jsonSchema struct {
+	Ref              string                 `json:"$ref"`
+	Properties       map[string]*jsonSchema `json:"properties"`
+	Items            *jsonSchema            `json:"items"`
+	Pattern          *string                `json:"pattern"`
+	Minimum          *float64               `json:"minimum"`
+	Maximum          *float64               `json:"maximum"`
+	ExclusiveMinimum json.RawMessage        `json:"exclusiveMinimum"`
+	ExclusiveMaximum json.RawMessage        `json:"exclusiveMaximum"`
+	MinLength        *int64                 `json:"minLength"`
+	MaxLength        *int64                 `json:"maxLength"`
+	MinItems         *int64                 `json:"minItems"`
+	MaxItems         *int64                 `json:"maxItems"`
+	Enum             []interface{}          `json:"enum"`
+	Format           string                 `json:"format"`
+}
+
+// ImportJSONSchema map a JSON Schema document describing the message md onto a RuleSet named name.
+// Properties are matched by JSON or proto field name, properties of message fields are mapped onto their message.
+// Local $ref ("#/definitions/X", "#/$defs/X", "#/components/schemas/X") are resolved.
+// Mapped keywords: pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength
+// (in bytes), minItems, maxItems, enum (of enum fields) and the ipv4/ipv6 formats.
+func ImportJSONSchema(data []byte, md protoreflect.MessageDescriptor, name string) (*RuleSet, error) {
+	var root json.RawMessage
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("[proto valid]parse json schema: %w", err)
+	}
+	schema := &jsonSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("[proto valid]parse json schema: %w", err)
+	}
+
+	im := &schemaImporter{
+		root:  root,
+		rules: &RuleSet{Name: proto.String(name), Messages: make(map[string]*MessageRules)},
+		seen:  make(map[protoreflect.FullName]bool),
+	}
+	im.message(md, schema)
+	return im.rules, errors.Join(im.errs...)
+}
+
+// schemaImporter state of an import
+type schemaImporter struct {
+	root  json.RawMessage
+	rules *RuleSet
+	seen  map[protoreflect.FullName]bool
+	errs  []error
+}
+
+// resolve follow the local $ref of a schema
+func (im *schemaImporter) resolve(schema *jsonSchema) *jsonSchema {
+	for i := 0; schema.Ref != "" && i < 32; i++ {
+		if !strings.HasPrefix(schema.Ref, "#/") {
+			im.errs = append(im.errs, fmt.Errorf("[proto valid]unsupported $ref[%s]", schema.Ref))
+			return schema
+		}
+		node := im.root
+		for _, key := range strings.Split(strings.TrimPrefix(schema.Ref, "#/"), "/") {
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(node, &obj); err != nil || obj[key] == nil {
+				im.errs = append(im.errs, fmt.Errorf("[proto valid]unresolved $ref[%s]", schema.Ref))
+				return schema
+			}
+			node = obj[key]
+		}
+		resolved := &jsonSchema{}
+		if err := json.Unmarshal(node, resolved); err != nil {
+			im.errs = append(im.errs, fmt.Errorf("[proto valid]parse $ref[%s]: %w", schema.Ref, err))
+			return schema
+		}
+		schema = resolved
+	}
+	return schema
+}
+
+// message map the properties of a schema onto the fields of md
+func (im *schemaImporter) message(md protoreflect.MessageDescriptor, schema *jsonSchema) {
+	schema = im.resolve(schema)
+	if im.seen[md.FullName()] || len(schema.Properties) == 0 {
+		return
+	}
+	im.seen[md.FullName()] = true
+
+	fields := make(map[string]*FieldValidator)
+	for prop, sub := range schema.Properties {
+		field := md.Fields().ByJSONName(prop)
+		if field == nil {
+			field = md.Fields().ByName(protoreflect.Name(prop))
+		}
+		if field == nil {
+			im.errs = append(im.errs, fmt.Errorf("[proto valid]property[%s] is not a field of %s", prop, md.FullName()))
+			continue
+		}
+		sub = im.resolve(sub)
+		rule := &FieldValidator{}
+		if field.IsList() {
+			setInt(&rule.RepeatedCountMin, sub.MinItems, 0)
+			setInt(&rule.RepeatedCountMax, sub.MaxItems, 0)
+			if sub.Items != nil {
+				sub = im.resolve(sub.Items)
+			}
+		}
+		if field.Kind() == protoreflect.MessageKind && !field.IsMap() {
+			im.message(field.Message(), sub)
+		} else if !field.IsMap() {
+			im.scalar(field, sub, rule)
+		}
+		if proto.Size(rule) > 0 {
+			fields[string(field.Name())] = rule
+		}
+	}
+	if len(fields) > 0 {
+		im.rules.Messages[string(md.FullName())] = &MessageRules{Fields: fields}
+	}
+}
+
+// scalar map the keywords of a scalar schema onto rule
+func (im *schemaImporter) scalar(field protoreflect.FieldDescriptor, schema *jsonSchema, rule *FieldValidator) {
+	lower, upper := schema.Minimum, schema.Maximum
+	exclusiveLower := im.exclusive(schema.ExclusiveMinimum, &lower, false)
+	exclusiveUpper := im.exclusive(schema.ExclusiveMaximum, &upper, true)
+
+	switch {
+	case isInt(field):
+		if lower != nil {
+			if exclusiveLower {
+				gt := int64(math.Floor(*lower))
+				rule.IntGt = &gt
+			} else {
+				gte := int64(math.Ceil(*lower))
+				rule.IntGte = &gte
+			}
+		}
+		if upper != nil {
+			if exclusiveUpper {
+				lt := int64(math.Ceil(*upper))
+				rule.IntLt = &lt
+			} else {
+				lte := int64(math.Floor(*upper))
+				rule.IntLte = &lte
+			}
+		}
+	case isFloat(field):
+		if lower != nil {
+			if exclusiveLower {
+				rule.FloatGt = lower
+			} else {
+				rule.FloatGte = lower
+			}
+		}
+		if upper != nil {
+			if exclusiveUpper {
+				rule.FloatLt = upper
+			} else {
+				rule.FloatLte = upper
+			}
+		}
+	case field.Kind() == protoreflect.StringKind:
+		rule.Regex = schema.Pattern
+		setLength(rule, schema)
+		if rule.LengthGt != nil || rule.LengthLt != nil {
+			//JSON Schema counts string lengths in characters
+			rule.LengthUnit = LengthUnit_LENGTH_UNIT_RUNES.Enum()
+		}
+		switch schema.Format {
+		case "ipv4":
+			rule.Ipv4 = proto.Bool(true)
+		case "ipv6":
+			rule.Ipv6 = proto.Bool(true)
+		}
+	case field.Kind() == protoreflect.BytesKind:
+		setLength(rule, schema)
+	case field.Kind() == protoreflect.EnumKind:
+		for _, value := range schema.Enum {
+			name, ok := value.(string)
+			ev := field.Enum().Values().ByName(protoreflect.Name(name))
+			if !ok || ev == nil {
+				im.errs = append(im.errs, fmt.Errorf("[proto valid]enum value[%v] is not a value of %s", value, field.Enum().FullName()))
+				continue
+			}
+			rule.EnumIn = append(rule.EnumIn, int32(ev.Number()))
+		}
+	}
+}
+
+// exclusive read an exclusive bound, a boolean (draft 4, applying to bound) or a number (draft 6+, replacing bound if stricter)
+func (im *schemaImporter) exclusive(raw json.RawMessage, bound **float64, upper bool) bool {
+	if len(raw) == 0 {
+		return false
+	}
+	var flag bool
+	if err := json.Unmarshal(raw, &flag); err == nil {
+		return flag
+	}
+	var value float64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		im.errs = append(im.errs, fmt.Errorf("[proto valid]invalid exclusive bound[%s]", raw))
+		return false
+	}
+	if *bound == nil || (!upper && value >= **bound) || (upper && value <= **bound) {
+		*bound = &value
+		return true
+	}
+	return false
+}
+
+// setInt set a rule from an inclusive count, shifted by delta to a strict bound
+func setInt(dst **int64, value *int64, delta int64) {
+	if value != nil {
+		x := *value + delta
+		*dst = &x
+	}
+}
+
+// setLength set the length rules from minLength and maxLength
+func setLength(rule *FieldValidator, schema *jsonSchema) {
+	if schema.MinLength != nil && *schema.MinLength > 0 {
+		setInt(&rule.LengthGt, schema.MinLength, -1)
+	}
+	setInt(&rule.LengthLt, schema.MaxLength, 1)
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"context"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+// testSchemaProto messages described by the imported JSON schemas
+const testSchemaProto = `syntax = "proto3"; package schema;
+enum Color { COLOR_UNSPECIFIED = 0; RED = 1; BLUE = 2; }
+message Address { string city = 1; }
+message Person {
+  int32 age = 1;
+  double score = 2;
+  string name = 3;
+  bytes avatar = 4;
+  Color color = 5;
+  repeated string tags = 6;
+  Address address = 7;
+  string ip = 8;
+  Person parent = 9;
+  map<string, string> labels = 10;
+  uint64 visits = 11;
+}`
+
+func TestImportJSONSchema(t *testing.T) {
+	fd := compileProto(t, testSchemaProto)
+	md := fd.Messages().ByName("Person")
+	for _, c := range []struct {
+		name, schema string
+		//rules text format of the rules of each "message.field", empty if the field has none
+		rules map[string]string
+		err   string
+	}{
+		{"inclusive integer bounds", `{"properties":{"age":{"minimum":1.5,"maximum":9.5}}}`, map[string]string{"schema.Person.age": `int_gte: 2 int_lte: 9`}, ""},
+		{"draft 4 exclusive bounds", `{"properties":{"age":{"minimum":0,"exclusiveMinimum":true,"maximum":10,"exclusiveMaximum":true}}}`, map[string]string{"schema.Person.age": `int_gt: 0 int_lt: 10`}, ""},
+		{"draft 4 exclusive without bound", `{"properties":{"age":{"exclusiveMinimum":true}}}`, map[string]string{"schema.Person.age": ``}, ""},
+		{"draft 6 exclusive bound", `{"properties":{"age":{"exclusiveMinimum":0,"exclusiveMaximum":120}}}`, map[string]string{"schema.Person.age": `int_gt: 0 int_lt: 120`}, ""},
+		{"draft 6 stricter inclusive bound", `{"properties":{"age":{"minimum":18,"exclusiveMinimum":0}}}`, map[string]string{"schema.Person.age": `int_gte: 18`}, ""},
+		{"invalid exclusive bound", `{"properties":{"age":{"exclusiveMinimum":"0"}}}`, nil, "invalid exclusive bound"},
+		{"unsigned integer", `{"properties":{"visits":{"minimum":1}}}`, map[string]string{"schema.Person.visits": `int_gte: 1`}, ""},
+		{"float bounds", `{"properties":{"score":{"minimum":0.5,"exclusiveMaximum":1}}}`, map[string]string{"schema.Person.score": `float_gte: 0.5 float_lt: 1`}, ""},
+		{"string", `{"properties":{"name":{"pattern":"^[A-Z]","minLength":1,"maxLength":20}}}`, map[string]string{"schema.Person.name": `regex: "^[A-Z]" length_gt: 0 length_lt: 21 length_unit: LENGTH_UNIT_RUNES`}, ""},
+		{"zero min length", `{"properties":{"name":{"minLength":0}}}`, map[string]string{"schema.Person.name": ``}, ""},
+		{"zero max length", `{"properties":{"name":{"maxLength":0}}}`, map[string]string{"schema.Person.name": `length_lt: 1 length_unit: LENGTH_UNIT_RUNES`}, ""},
+		{"bytes length", `{"properties":{"avatar":{"maxLength":1024}}}`, map[string]string{"schema.Person.avatar": `length_lt: 1025`}, ""},
+		{"ipv4 format", `{"properties":{"ip":{"format":"ipv4"}}}`, map[string]string{"schema.Person.ip": `ipv4: true`}, ""},
+		{"unmapped format", `{"properties":{"ip":{"format":"hostname"}}}`, map[string]string{"schema.Person.ip": ``}, ""},
+		{"enum", `{"properties":{"color":{"enum":["RED","BLUE"]}}}`, map[string]string{"schema.Person.color": `enum_in: [1, 2]`}, ""},
+		{"unknown enum value", `{"properties":{"color":{"enum":["RED","GREEN",3]}}}`, map[string]string{"schema.Person.color": `enum_in: 1`}, "GREEN"},
+		{"items", `{"properties":{"tags":{"minItems":1,"maxItems":5,"items":{"maxLength":8}}}}`, map[string]string{"schema.Person.tags": `length_lt: 9 length_unit: LENGTH_UNIT_RUNES repeated_count_min: 1 repeated_count_max: 5`}, ""},
+		{"message", `{"properties":{"address":{"properties":{"city":{"minLength":2}}}}}`, map[string]string{"schema.Person.address": ``, "schema.Address.city": `length_gt: 1 length_unit: LENGTH_UNIT_RUNES`}, ""},
+		{"proto field name", `{"properties":{"ip":{"format":"ipv6"}}}`, map[string]string{"schema.Person.ip": `ipv6: true`}, ""},
+		{"recursive message", `{"$defs":{"P":{"properties":{"age":{"minimum":0},"parent":{"$ref":"#/$defs/P"}}}},"$ref":"#/$defs/P"}`, map[string]string{"schema.Person.age": `int_gte: 0`}, ""},
+		{"openapi ref", `{"components":{"schemas":{"Name":{"maxLength":3}}},"properties":{"name":{"$ref":"#/components/schemas/Name"}}}`, map[string]string{"schema.Person.name": `length_lt: 4 length_unit: LENGTH_UNIT_RUNES`}, ""},
+		{"map", `{"properties":{"labels":{"maxLength":3}}}`, map[string]string{"schema.Person.labels": ``}, ""},
+		{"unresolved ref", `{"properties":{"name":{"$ref":"#/definitions/Missing"}}}`, nil, "unresolved $ref[#/definitions/Missing]"},
+		{"remote ref", `{"properties":{"name":{"$ref":"other.json#/Name"}}}`, nil, "unsupported $ref[other.json#/Name]"},
+		{"unknown property", `{"properties":{"nickname":{"maxLength":3}}}`, nil, "property[nickname] is not a field of schema.Person"},
+		{"no property", `{}`, map[string]string{}, ""},
+		{"malformed", `{"properties":`, nil, "parse json schema"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rules, err := ImportJSONSchema([]byte(c.schema), md, "imported")
+			if c.err == "" && err != nil || c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Fatal(err)
+			}
+			if c.rules == nil {
+				return
+			}
+			if rules.GetName() != "imported" {
+				t.Fatal(rules.GetName())
+			}
+			for msg, msgRules := range rules.Messages {
+				for field := range msgRules.Fields {
+					if _, ok := c.rules[msg+"."+field]; !ok {
+						t.Errorf("unexpected rule of %s.%s", msg, field)
+					}
+				}
+			}
+			for path, text := range c.rules {
+				i := strings.LastIndex(path, ".")
+				want := &FieldValidator{}
+				if err := prototext.Unmarshal([]byte(text), want); err != nil {
+					t.Fatal(err)
+				}
+				got := rules.Messages[path[:i]].GetFields()[path[i+1:]]
+				if text == "" && got != nil || text != "" && !proto.Equal(got, want) {
+					t.Errorf("%s: got %v, want %v", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImportJSONSchemaOverlay(t *testing.T) {
+	fd := compileProto(t, testSchemaProto)
+	rules, err := ImportJSONSchema([]byte(`{"properties":{"name":{"maxLength":2}}}`), fd.Messages().ByName("Person"), "schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := New(WithOverlay(rules))
+	ctx := ContextWithOverlay(context.Background(), "schema")
+	//lengths are counted in characters, as JSON Schema does
+	if err := v.ValidateContext(ctx, newMsg(t, fd, "Person", `{"name":"éé"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.ValidateContext(ctx, newMsg(t, fd, "Person", `{"name":"abc"}`)); !MatchViolation(err, "name", "LengthLt") {
+		t.Fatal(err)
+	}
+}
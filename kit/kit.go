@@ -0,0 +1,133 @@
+// Package kit go-kit endpoint middleware validating proto request and response models
+package kit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// grpcInvalidArgument grpc status code of invalid argument
+const grpcInvalidArgument = 3
+
+// grpcInternal grpc status code of internal error
+const grpcInternal = 13
+
+// Error validation error of an endpoint model.
+// It implements the StatusCoder and json.Marshaler interfaces used by go-kit's http transport.
+type Error struct {
+	// Err validation error
+	Err error
+	// Response whether the response failed the validation, i.e. a server side fault
+	Response bool
+}
+
+// Error implement interface
+func (e *Error) Error() string {
+	if e.Response {
+		return fmt.Sprintf("[proto valid]invalid response: %s", e.Err)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap the validation error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode http status code, 400 for an invalid request and 500 for an invalid response
+func (e *Error) StatusCode() int {
+	if e.Response {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// GRPCCode grpc status code, INVALID_ARGUMENT for an invalid request and INTERNAL for an invalid response
+func (e *Error) GRPCCode() int {
+	if e.Response {
+		return grpcInternal
+	}
+	return grpcInvalidArgument
+}
+
+// MarshalJSON encode the error as the body written by go-kit's DefaultErrorEncoder
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"code":    e.GRPCCode(),
+		"message": e.Error(),
+	})
+}
+
+// ResponseMode handling of the responses by the middleware, shared with the other middlewares of the validator
+type ResponseMode = validator.ResponseMode
+
+const (
+	// ResponseSkip responses are not validated, the default
+	ResponseSkip = validator.ResponseSkip
+	// ResponseFailClosed an invalid response is replaced by an Error with Response set
+	ResponseFailClosed = validator.ResponseFailClosed
+	// ResponseLogOnly an invalid response is logged and returned, e.g. while rolling out response rules
+	ResponseLogOnly = validator.ResponseLogOnly
+)
+
+// Option middleware option
+type Option func(*options)
+
+// options middleware options
+type options struct {
+	responseMode ResponseMode
+}
+
+// WithResponseMode select the handling of the responses, ResponseSkip by default
+func WithResponseMode(mode ResponseMode) Option {
+	return func(o *options) {
+		o.responseMode = mode
+	}
+}
+
+// Middleware validate proto requests before calling the endpoint, and proto responses after it with WithResponseMode,
+// with the context of the call (see validator.ValidateContext). Models which are not proto messages are passed through.
+// v may be nil to use the default validator.
+func Middleware(v *validator.Validator, opts ...Option) endpoint.Middleware {
+	validate := validator.ValidateContext
+	if v != nil {
+		validate = v.ValidateContext
+	}
+	logger := validator.GetLogger()
+	if v != nil {
+		logger = v.Logger()
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if msg, ok := request.(proto.Message); ok {
+				if err := validate(ctx, msg); err != nil {
+					return nil, &Error{Err: err}
+				}
+			}
+			response, err := next(ctx, request)
+			if err != nil || o.responseMode == ResponseSkip {
+				return response, err
+			}
+			if msg, ok := response.(proto.Message); ok {
+				if err := validate(ctx, msg); err != nil {
+					if o.responseMode == ResponseLogOnly {
+						logger.Warnf("[pb valid]invalid response msg[%s] err: %s", msg.ProtoReflect().Descriptor().FullName(), err)
+						return response, nil
+					}
+					return nil, &Error{Err: err, Response: true}
+				}
+			}
+			return response, nil
+		}
+	}
+}
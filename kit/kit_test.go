@@ -0,0 +1,81 @@
+package kit
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"net/http"
+	"testing"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// testValidator validator with the overlay "strict" requiring google.protobuf.StringValue values longer than 3 bytes
+func testValidator(opts ...validator.Option) *validator.Validator {
+	gt := int64(3)
+	return validator.New(append(opts, validator.WithOverlay(&validator.RuleSet{
+		Name: proto.String("strict"),
+		Messages: map[string]*validator.MessageRules{
+			"google.protobuf.StringValue": {Fields: map[string]*validator.FieldValidator{"value": {LengthGt: &gt}}},
+		},
+	}))...)
+}
+
+func TestMiddlewareContext(t *testing.T) {
+	echo := func(_ context.Context, request interface{}) (interface{}, error) { return request, nil }
+	endpoint := Middleware(testValidator())(echo)
+	if _, err := endpoint(context.Background(), wrapperspb.String("bad")); err != nil {
+		t.Fatalf("without overlay: %v", err)
+	}
+	//the overlay selected by the context of the call applies
+	_, err := endpoint(validator.ContextWithOverlay(context.Background(), "strict"), wrapperspb.String("bad"))
+	var kerr *Error
+	if !errors.As(err, &kerr) || kerr.Response || kerr.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("with overlay: %v", err)
+	}
+}
+
+// testLogger logger counting the warnings
+type testLogger struct {
+	warnings int
+}
+
+// Debugf implement validator.Logger
+func (l *testLogger) Debugf(string, ...interface{}) {}
+
+// Warnf implement validator.Logger
+func (l *testLogger) Warnf(string, ...interface{}) {
+	l.warnings++
+}
+
+func TestMiddlewareResponseMode(t *testing.T) {
+	ctx := validator.ContextWithOverlay(context.Background(), "strict")
+	for _, c := range []struct {
+		name   string
+		opts   []Option
+		failed bool
+		warned bool
+	}{
+		{"skipped by default", nil, false, false},
+		{"fail closed", []Option{WithResponseMode(ResponseFailClosed)}, true, false},
+		{"log only", []Option{WithResponseMode(ResponseLogOnly)}, false, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			logger := &testLogger{}
+			v := testValidator(validator.WithLogger(logger))
+			bad := func(context.Context, interface{}) (interface{}, error) { return wrapperspb.String("bad"), nil }
+			response, err := Middleware(v, c.opts...)(bad)(ctx, wrapperspb.String("long enough"))
+			var kerr *Error
+			if c.failed != errors.As(err, &kerr) || c.failed && (!kerr.Response || kerr.StatusCode() != http.StatusInternalServerError) {
+				t.Fatalf("response %v: %v", response, err)
+			}
+			if !c.failed && response == nil {
+				t.Fatal("want the response")
+			}
+			if (logger.warnings > 0) != c.warned {
+				t.Fatalf("%d warnings", logger.warnings)
+			}
+		})
+	}
+}
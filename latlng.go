@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// latLngFullName full name of google.type.LatLng
+const latLngFullName protoreflect.FullName = "google.type.LatLng"
+
+// checkLatLng check the coordinates of a google.type.LatLng, NaN is out of bounds
+func (v *validator) checkLatLng(field protoreflect.FieldDescriptor, latLng protoreflect.Message, rule *FieldValidator) error {
+	if rule == nil || !rule.GetLatLng() || latLng.Descriptor().FullName() != latLngFullName {
+		return nil
+	}
+	fields := latLng.Descriptor().Fields()
+	if lat := latLng.Get(fields.ByName("latitude")).Float(); !(lat >= -90 && lat <= 90) {
+		if err := v.fail(field, "LatLng", "latitude in [-90, 90]", lat); err != nil {
+			return err
+		}
+	}
+	if lng := latLng.Get(fields.ByName("longitude")).Float(); !(lng >= -180 && lng <= 180) {
+		if err := v.fail(field, "LatLng", "longitude in [-180, 180]", lng); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isLatLng whether the field is a google.type.LatLng
+func isLatLng(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.MessageKind && field.Message().FullName() == latLngFullName
+}
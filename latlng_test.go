@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestLatLng(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package latlng; import "validator.proto"; import "google/type/types.proto";
+message Place {
+  google.type.LatLng location = 1 [(validator.field) = {lat_lng: true}];
+  repeated google.type.LatLng route = 2 [(validator.field) = {lat_lng: true}];
+}`)
+	for _, c := range []struct {
+		name, json, path string
+	}{
+		{"legal", `{"location":{"latitude":48.85,"longitude":2.35},"route":[{"latitude":-33.87,"longitude":151.21}]}`, ""},
+		{"unset", `{}`, ""},
+		{"zero", `{"location":{},"route":[{}]}`, ""},
+		{"bounds", `{"location":{"latitude":90,"longitude":-180},"route":[{"latitude":-90,"longitude":180}]}`, ""},
+		{"latitude above", `{"location":{"latitude":90.000001,"longitude":0}}`, "location"},
+		{"latitude below", `{"location":{"latitude":-90.000001,"longitude":0}}`, "location"},
+		{"longitude above", `{"location":{"latitude":0,"longitude":180.000001}}`, "location"},
+		{"longitude below", `{"location":{"latitude":0,"longitude":-181}}`, "location"},
+		{"NaN", `{"location":{"latitude":"NaN","longitude":0}}`, "location"},
+		{"infinity", `{"location":{"latitude":0,"longitude":"-Infinity"}}`, "location"},
+		{"element", `{"route":[{"latitude":0,"longitude":0},{"latitude":91,"longitude":0}]}`, "route[1]"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Place", c.json))
+			if c.path == "" && err != nil || c.path != "" && !MatchViolation(err, c.path, "LatLng") {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestLatLngRuleConfig(t *testing.T) {
+	for _, c := range []struct {
+		name, field string
+		opts        []Option
+		invalid     bool
+	}{
+		{"lat_lng", "google.type.LatLng", []Option{WithStrictTyping()}, false},
+		{"other message", "google.type.Money", []Option{WithStrictTyping()}, true},
+		{"scalar", "string", []Option{WithStrictTyping()}, true},
+		{"scalar without strict typing", "string", nil, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fd := compileProto(t, `syntax = "proto3"; package latlng; import "validator.proto"; import "google/type/types.proto";
+message Place { `+c.field+` location = 1 [(validator.field) = {lat_lng: true}]; }`)
+			if err := New(c.opts...).Register(fd.Messages().ByName("Place")); (err != nil) != c.invalid {
+				t.Fatal(err)
+			}
+		})
+	}
+}
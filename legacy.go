@@ -0,0 +1,106 @@
+//go:build !tinygo && !purereflect
+
+// The jhump/protoreflect based API, excluded from TinyGo builds and the purereflect build tag, e.g. for proxy-wasm filters.
+// The same tags exclude the HTTP proxy and stats handler, the SQL audit sink, the HTML report, the YAML rule files
+// and grapheme counting, so the reduced build only depends on google.golang.org/protobuf and the standard library
+// without net/http, database/sql and the template packages (checked by TestPureReflectDeps).
+
+package validator
+
+import (
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ValidMsg verify whether a proto message is legal.
+// msg is converted to a dynamicpb message and validated by the protoreflect core, prefer ValidProto for generated messages.
+// A message that can't be converted fails with an *InternalValidationError.
+func ValidMsg(msg *dynamic.Message) error {
+	return std().ValidMsg(msg)
+}
+
+// ValidMsg verify whether a proto message is legal
+func (v *Validator) ValidMsg(msg *dynamic.Message) error {
+	if msg == nil {
+		return nil
+	}
+	m, err := v.convert(msg)
+	if err != nil {
+		return err
+	}
+	return v.valid(m)
+}
+
+// ValidMsgAll verify whether a proto message is legal, collecting every violation with its field path
+func ValidMsgAll(msg *dynamic.Message) error {
+	return std().ValidMsgAll(msg)
+}
+
+// ValidMsgAll verify whether a proto message is legal, collecting every violation with its field path.
+// The returned error joins the *ValidError of every violation, see ValidateAll.
+func (v *Validator) ValidMsgAll(msg *dynamic.Message) error {
+	if msg == nil {
+		return nil
+	}
+	m, err := v.convert(msg)
+	if err != nil {
+		return err
+	}
+	return v.ValidateAll(m.Interface())
+}
+
+// convert convert a dynamic message for the validation, a message that can't be converted
+// fails it with an *InternalValidationError
+func (v *Validator) convert(msg *dynamic.Message) (protoreflect.Message, error) {
+	m, err := v.toReflect(msg)
+	if err != nil {
+		name := msg.GetMessageDescriptor().GetFullyQualifiedName()
+		v.warnf("[pb valid]convert msg[%s] err: %s", name, err)
+		fault := &Fault{Kind: FaultConversion, Message: name, Err: err}
+		v.fault(fault)
+		return nil, &InternalValidationError{Fault: fault}
+	}
+	return m, nil
+}
+
+// toReflect convert a dynamic message into a protoreflect message
+func (v *Validator) toReflect(msg *dynamic.Message) (protoreflect.Message, error) {
+	data, err := msg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	m := dynamicpb.NewMessage(msg.GetMessageDescriptor().UnwrapMessage())
+	if err := (proto.UnmarshalOptions{Resolver: v.resolver}).Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ValidFail error warp
+func ValidFail(field *desc.FieldDescriptor, validKey string, validValue interface{}, fieldValue interface{}) error {
+	return validFail(field.UnwrapField(), validKey, validValue, fieldValue)
+}
+
+// ValidBatch verify a batch of proto messages with the default validator
+func ValidBatch(msgs []*dynamic.Message) *BatchReport {
+	return std().ValidBatch(msgs)
+}
+
+// ValidBatch verify a batch of proto messages
+func (v *Validator) ValidBatch(msgs []*dynamic.Message) *BatchReport {
+	return v.batch(len(msgs), func(i int) error {
+		return v.ValidMsg(msgs[i])
+	})
+}
+
+// validLegacy verify a *dynamic.Message passed to ValidAny, ok is false for other types
+func (v *Validator) validLegacy(x interface{}) (ok bool, err error) {
+	msg, ok := x.(*dynamic.Message)
+	if !ok {
+		return false, nil
+	}
+	return true, v.ValidMsg(msg)
+}
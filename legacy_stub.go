@@ -0,0 +1,8 @@
+//go:build tinygo || purereflect
+
+package validator
+
+// validLegacy the jhump/protoreflect based API is excluded from this build
+func (v *Validator) validLegacy(interface{}) (ok bool, err error) {
+	return false, nil
+}
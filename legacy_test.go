@@ -0,0 +1,52 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"errors"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"testing"
+)
+
+func TestValidMsg(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto2"; package legacy; import "validator.proto";
+message Item {
+  required string sku = 1;
+  optional string name = 2 [(validator.field) = {length_gt: 1}];
+}`)
+	md, err := desc.WrapMessage(fd.Messages().ByName("Item"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := dynamic.NewMessage(md)
+	msg.SetFieldByName("sku", "a")
+	msg.SetFieldByName("name", "x")
+	for name, valid := range map[string]func(*dynamic.Message) error{"ValidMsg": New().ValidMsg, "ValidMsgAll": New().ValidMsgAll} {
+		if err := valid(msg); !MatchViolation(err, "name", "LengthGt") {
+			t.Errorf("%s: %v", name, err)
+		}
+		//the required sku is missing, the message can't be marshalled
+		var internal *InternalValidationError
+		if err := valid(dynamic.NewMessage(md)); !errors.As(err, &internal) || internal.Fault.Kind != FaultConversion {
+			t.Errorf("%s unconvertible: %v", name, err)
+		}
+	}
+}
+
+func TestValidAnyLegacy(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package legacy; import "validator.proto";
+message Item { string name = 1 [(validator.field) = {length_gt: 1}]; }`)
+	md, err := desc.WrapMessage(fd.Messages().ByName("Item"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := dynamic.NewMessage(md)
+	if err := New().ValidAny(msg); !MatchViolation(err, "name", "LengthGt") {
+		t.Fatal(err)
+	}
+	msg.SetFieldByName("name", "ab")
+	if err := New().ValidAny(msg); err != nil {
+		t.Fatal(err)
+	}
+}
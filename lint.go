@@ -0,0 +1,383 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"regexp"
+	"strings"
+)
+
+// LintIssue bad or contradictory rule annotation found by the linter
+type LintIssue struct {
+	// Field annotated field
+	Field protoreflect.FieldDescriptor
+	// Rule rule key, e.g. "IntGt"
+	Rule string
+	// Message description of the problem
+	Message string
+}
+
+// String implement fmt.Stringer
+func (i *LintIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Field.FullName(), i.Rule, i.Message)
+}
+
+// LintFile lint the rules of every message of a file, including nested messages
+func LintFile(fd protoreflect.FileDescriptor) []*LintIssue {
+	return lintMessages(fd.Messages(), nil)
+}
+
+// LintMessage lint the rules of the fields of a message
+func LintMessage(md protoreflect.MessageDescriptor) []*LintIssue {
+	var issues []*LintIssue
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		rule, err := loadRule(field, protoregistry.GlobalTypes)
+		if err != nil {
+			issues = append(issues, &LintIssue{Field: field, Rule: "RuleDecode", Message: err.Error()})
+		}
+		if rule == nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, issue := range lintRule(field, rule) {
+			seen[issue.String()] = true
+			issues = append(issues, issue)
+		}
+		//versioned rules are linted merged onto the rule, without repeating its issues
+		for _, versioned := range rule.Versioned {
+			for _, issue := range lintRule(field, overlayRule(rule, versioned)) {
+				if !seen[issue.String()] {
+					seen[issue.String()] = true
+					issues = append(issues, issue)
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// lintMessages lint messages and their nested messages
+func lintMessages(messages protoreflect.MessageDescriptors, issues []*LintIssue) []*LintIssue {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		issues = append(issues, LintMessage(md)...)
+		issues = lintMessages(md.Messages(), issues)
+	}
+	return issues
+}
+
+// ruleKinds field kinds a rule applies to, rules missing here apply to any field
+var ruleKinds = map[string]func(field protoreflect.FieldDescriptor) bool{
+	"IntGt":                  isInt,
+	"IntLt":                  isInt,
+	"IntGte":                 isInt,
+	"IntLte":                 isInt,
+	"IntPort":                isInt,
+	"IntPortAllowZero":       isInt,
+	"UintGt":                 isUint,
+	"UintLt":                 isUint,
+	"UintGte":                isUint,
+	"UintLte":                isUint,
+	"FloatGt":                isFloat,
+	"FloatLt":                isFloat,
+	"FloatGte":               isFloat,
+	"FloatLte":               isFloat,
+	"FloatEpsilon":           isFloat,
+	"Regex":                  isKind(protoreflect.StringKind, protoreflect.BytesKind),
+	"StringNotEmpty":         isKind(protoreflect.StringKind),
+	"Ip":                     isKind(protoreflect.StringKind),
+	"Ipv4":                   isKind(protoreflect.StringKind),
+	"Ipv6":                   isKind(protoreflect.StringKind),
+	"IpPrivate":              isKind(protoreflect.StringKind),
+	"IpPublic":               isKind(protoreflect.StringKind),
+	"LengthGt":               isKind(protoreflect.StringKind, protoreflect.BytesKind),
+	"LengthLt":               isKind(protoreflect.StringKind, protoreflect.BytesKind),
+	"LengthEq":               isKind(protoreflect.StringKind, protoreflect.BytesKind),
+	"LengthUnit":             isKind(protoreflect.StringKind),
+	"HashFormat":             isKind(protoreflect.StringKind),
+	"StringTimeOfDay":        isKind(protoreflect.StringKind),
+	"StringDayOfWeek":        isKind(protoreflect.StringKind),
+	"NumericString":          isKind(protoreflect.StringKind),
+	"StringChecksum":         isKind(protoreflect.StringKind),
+	"StringPostalCode":       isKind(protoreflect.StringKind),
+	"PostalCodeCountryField": isKind(protoreflect.StringKind),
+	"IsInEnum":               isKind(protoreflect.EnumKind),
+	"EnumIn":                 isKind(protoreflect.EnumKind),
+	"EnumNotIn":              isKind(protoreflect.EnumKind),
+	"MsgMaxBytes":            isKind(protoreflect.MessageKind),
+	"StringNotSimilar":       isKind(protoreflect.StringKind),
+	"StringPrefix":           isKind(protoreflect.StringKind, protoreflect.BytesKind),
+	"StringSuffix":           isKind(protoreflect.StringKind, protoreflect.BytesKind),
+	"StringContains":         isKind(protoreflect.StringKind),
+	"StringNotContains":      isKind(protoreflect.StringKind),
+	"Money":                  isMoney,
+	"FieldMask":              isFieldMask,
+	"FieldMaskTarget":        isFieldMask,
+	"LatLng":                 isLatLng,
+	"Date":                   isDate,
+	"TimeOfDay":              isTimeOfDay,
+}
+
+// typeIssues rules attached to a field of a kind they don't apply to
+func typeIssues(field protoreflect.FieldDescriptor, rule *FieldValidator) []*LintIssue {
+	var issues []*LintIssue
+	report := func(key, format string, args ...interface{}) {
+		issues = append(issues, &LintIssue{Field: field, Rule: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	//the rule of a map applies to its keys
+	elem := field
+	if field.IsMap() {
+		elem = field.MapKey()
+	}
+	//scalar rules of message types with a registered extractor apply to the extracted value
+	extracted := extractedKind(elem)
+	rule.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		key := goName(fd)
+		if extracted != nil && ruleKinds[key] != nil && ruleKinds[key](extracted) {
+			return true
+		}
+		if applies, ok := ruleKinds[key]; ok && !applies(elem) && !(elem.Kind() == protoreflect.StringKind && numericStringRules[rule.GetNumericString()][key]) {
+			report(key, "does not apply to a %s field", elem.Kind())
+		}
+		return true
+	})
+	if !field.IsList() && !field.IsMap() {
+		if rule.RepeatedCountMin != nil {
+			report("RepeatedCountMin", "does not apply to a singular field")
+		}
+		if rule.RepeatedCountMax != nil {
+			report("RepeatedCountMax", "does not apply to a singular field")
+		}
+	}
+	if rule.RepeatedUniqueBy != nil && (!field.IsList() || field.Kind() != protoreflect.MessageKind) {
+		report("RepeatedUniqueBy", "does not apply to a field other than a repeated message")
+	}
+	if !field.IsMap() {
+		if rule.MapKeyRegex != nil {
+			report("MapKeyRegex", "does not apply to a field other than a map")
+		}
+		if len(rule.MapKeyIn) > 0 {
+			report("MapKeyIn", "does not apply to a field other than a map")
+		}
+	} else if rule.MapKeyRegex != nil && field.MapKey().Kind() != protoreflect.StringKind {
+		report("MapKeyRegex", "does not apply to a map with %s keys", field.MapKey().Kind())
+	}
+	if rule.RepeatedMonotonic != nil && (!field.IsList() || !isOrdered(field)) {
+		report("RepeatedMonotonic", "does not apply to a field other than a repeated number, Timestamp or Duration")
+	}
+	return issues
+}
+
+// lintRule lint the rule of a field
+func lintRule(field protoreflect.FieldDescriptor, rule *FieldValidator) []*LintIssue {
+	issues := typeIssues(field, rule)
+	report := func(key, format string, args ...interface{}) {
+		issues = append(issues, &LintIssue{Field: field, Rule: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if rule.Regex != nil {
+		if _, err := regexp.Compile(*rule.Regex); err != nil {
+			report("Regex", "invalid regex: %s", err)
+		}
+	}
+	if rule.MapKeyRegex != nil {
+		if _, err := regexp.Compile(*rule.MapKeyRegex); err != nil {
+			report("MapKeyRegex", "invalid regex: %s", err)
+		}
+	}
+	if rule.IntGt != nil && rule.IntLt != nil && *rule.IntGt >= *rule.IntLt-1 {
+		report("IntLt", "no integer is greater than %d and smaller than %d", *rule.IntGt, *rule.IntLt)
+	}
+	if rule.IntGte != nil && rule.IntLte != nil && *rule.IntGte > *rule.IntLte {
+		report("IntLte", "no integer is between %d and %d", *rule.IntGte, *rule.IntLte)
+	}
+	if rule.UintLt != nil && *rule.UintLt == 0 {
+		report("UintLt", "no unsigned integer is smaller than 0")
+	} else if rule.UintGt != nil && rule.UintLt != nil && *rule.UintGt >= *rule.UintLt-1 {
+		report("UintLt", "no unsigned integer is greater than %d and smaller than %d", *rule.UintGt, *rule.UintLt)
+	}
+	if rule.UintGte != nil && rule.UintLte != nil && *rule.UintGte > *rule.UintLte {
+		report("UintLte", "no unsigned integer is between %d and %d", *rule.UintGte, *rule.UintLte)
+	}
+	if rule.FloatGt != nil && rule.FloatLt != nil && *rule.FloatGt >= *rule.FloatLt {
+		report("FloatLt", "no value is greater than %v and smaller than %v", *rule.FloatGt, *rule.FloatLt)
+	}
+	if rule.FloatGte != nil && rule.FloatLte != nil && *rule.FloatGte > *rule.FloatLte {
+		report("FloatLte", "no value is between %v and %v", *rule.FloatGte, *rule.FloatLte)
+	}
+	if rule.LengthGt != nil && rule.LengthLt != nil && *rule.LengthGt >= *rule.LengthLt-1 {
+		report("LengthLt", "no length is greater than %d and smaller than %d", *rule.LengthGt, *rule.LengthLt)
+	}
+	if rule.LengthEq != nil {
+		if (rule.LengthGt != nil && *rule.LengthEq <= *rule.LengthGt) || (rule.LengthLt != nil && *rule.LengthEq >= *rule.LengthLt) {
+			report("LengthEq", "length %d contradicts length_gt/length_lt", *rule.LengthEq)
+		}
+	}
+	if rule.StringChecksum != nil {
+		//algorithms registered with WithChecksum are only known to their validator
+		if _, ok := checksums.Load(*rule.StringChecksum); !ok {
+			report("StringChecksum", "checksum algorithm %s is not registered", *rule.StringChecksum)
+		}
+	}
+	if similar := rule.StringNotSimilar; similar != nil && (similar.GetDenylist() == "" || similar.GetMaxDistance() < 0) {
+		report("StringNotSimilar", "needs a denylist name and a non-negative max_distance")
+	}
+	if money := rule.Money; money != nil {
+		if err := checkMoneyRule(field, rule); err != nil {
+			report("Money", "%s", err)
+		} else if money.Min != nil && money.Max != nil {
+			min, _ := parseDecimal(*money.Min)
+			max, _ := parseDecimal(*money.Max)
+			if min.Cmp(max) > 0 {
+				report("Money", "no amount is between %s and %s", *money.Min, *money.Max)
+			}
+		}
+		for _, code := range money.CurrencyIn {
+			if !currencyRegex.MatchString(code) {
+				report("Money", "%s is not an ISO 4217 currency code", code)
+			}
+		}
+	}
+	if err := checkDateRule(field, rule); err != nil {
+		report("Date", "%s", err)
+	} else if date := rule.Date; date != nil && date.Min != nil && date.Max != nil && *date.Min > *date.Max {
+		//YYYY-MM-DD dates compare as strings
+		report("Date", "no date is between %s and %s", *date.Min, *date.Max)
+	}
+	if err := checkTimeOfDayRule(field, rule); err != nil {
+		report("TimeOfDay", "%s", err)
+	} else if clock := rule.TimeOfDay; clock != nil && clock.Min != nil && clock.Max != nil {
+		min, _ := parseTimeOfDay(*clock.Min)
+		max, _ := parseTimeOfDay(*clock.Max)
+		if min > max {
+			report("TimeOfDay", "no time of day is between %s and %s", *clock.Min, *clock.Max)
+		}
+	}
+	if notContains := rule.StringNotContains; notContains != nil {
+		for _, required := range []*string{rule.StringPrefix, rule.StringSuffix, rule.StringContains} {
+			if required != nil && strings.Contains(*required, *notContains) {
+				report("StringNotContains", "no string contains %q without %q", *required, *notContains)
+			}
+		}
+	}
+	if rule.MsgMaxBytes != nil && *rule.MsgMaxBytes < 0 {
+		report("MsgMaxBytes", "no message is smaller than %d bytes", *rule.MsgMaxBytes)
+	}
+	if rule.RepeatedCountMin != nil && rule.RepeatedCountMax != nil && *rule.RepeatedCountMin > *rule.RepeatedCountMax {
+		report("RepeatedCountMax", "max count %d is smaller than min count %d", *rule.RepeatedCountMax, *rule.RepeatedCountMin)
+	}
+	if rule.GetIpv4() && rule.GetIpv6() {
+		report("Ipv6", "an address can not be both IPv4 and IPv6")
+	}
+	if rule.GetIpPrivate() && rule.GetIpPublic() {
+		report("IpPublic", "an address can not be both private and public")
+	}
+	if rule.IntPortAllowZero != nil && !rule.GetIntPort() {
+		report("IntPortAllowZero", "has no effect without int_port")
+	}
+	if rule.FieldMaskTarget != nil && !rule.GetFieldMask() {
+		report("FieldMaskTarget", "has no effect without field_mask")
+	}
+	elem := field
+	if field.IsMap() {
+		elem = field.MapKey()
+	}
+	for _, n := range rule.EnumIn {
+		if containsInt32(rule.EnumNotIn, n) {
+			report("EnumNotIn", "value %d is both in enum_in and enum_not_in", n)
+		}
+		if elem.Kind() == protoreflect.EnumKind && elem.Enum().Values().ByNumber(protoreflect.EnumNumber(n)) == nil {
+			report("EnumIn", "value %d is not a value of %s", n, elem.Enum().FullName())
+		}
+	}
+	if err := checkUniqueByRule(field, rule); err != nil {
+		report("RepeatedUniqueBy", "%s is not a singular scalar field of %s", *rule.RepeatedUniqueBy, field.Message().FullName())
+	}
+	if err := checkPostalCodeRule(field, rule); err != nil {
+		report("PostalCodeCountryField", "%s is not a singular string field of %s", *rule.PostalCodeCountryField, field.ContainingMessage().FullName())
+	}
+	if rule.StringPostalCode != nil && postalCodes[strings.ToUpper(*rule.StringPostalCode)] == nil {
+		report("StringPostalCode", "no postal code format for country %q", *rule.StringPostalCode)
+	}
+	for _, nested := range rule.Nested {
+		if field.Kind() != protoreflect.MessageKind || field.IsMap() {
+			report("Nested", "does not apply to a field other than a message")
+			break
+		}
+		fields, err := resolveNestedPath(field.Message(), nested.GetFieldPath())
+		if err != nil {
+			report("Nested", "field_path %q: %s", nested.GetFieldPath(), err)
+			continue
+		}
+		if nested.Rule != nil {
+			issues = append(issues, lintRule(fields[len(fields)-1], nested.Rule)...)
+		}
+	}
+	if rule.SinceVersion != nil && rule.UntilVersion != nil && compareVersion(*rule.SinceVersion, *rule.UntilVersion) >= 0 {
+		report("UntilVersion", "version range [%s, %s) is empty", *rule.SinceVersion, *rule.UntilVersion)
+	}
+	return issues
+}
+
+// goName Go field name of a rule field, i.e. the rule key
+func goName(fd protoreflect.FieldDescriptor) string {
+	name := []byte(fd.Name())
+	out := make([]byte, 0, len(name))
+	upper := true
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		out = append(out, c)
+	}
+	return string(out)
+}
+
+// isInt whether the field is an integer
+func isInt(field protoreflect.FieldDescriptor) bool {
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	}
+	return false
+}
+
+// isUint whether the field is an unsigned integer
+var isUint = isKind(protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind)
+
+// isFloat whether the field is a float or a double
+func isFloat(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.FloatKind || field.Kind() == protoreflect.DoubleKind
+}
+
+// isKind whether the field is of one of kinds
+func isKind(kinds ...protoreflect.Kind) func(field protoreflect.FieldDescriptor) bool {
+	return func(field protoreflect.FieldDescriptor) bool {
+		for _, kind := range kinds {
+			if field.Kind() == kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isFieldMask whether the field is a google.protobuf.FieldMask
+func isFieldMask(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.MessageKind && field.Message().FullName() == fieldMaskFullName
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintFile(t *testing.T) {
+	for _, c := range []struct {
+		name, field string
+		issues      []string
+	}{
+		{"no rule", `string a = 1;`, nil},
+		{"consistent", `string a = 1 [(validator.field) = {length_gt: 1, length_lt: 3}];`, nil},
+		{"empty length range", `string a = 1 [(validator.field) = {length_gt: 1, length_lt: 2}];`, []string{"LengthLt"}},
+		{"empty int range", `int64 a = 1 [(validator.field) = {int_gte: 2, int_lte: 1}];`, []string{"IntLte"}},
+		{"single int", `int64 a = 1 [(validator.field) = {int_gte: 1, int_lte: 1}];`, nil},
+		{"uint below zero", `uint64 a = 1 [(validator.field) = {uint_lt: 0}];`, []string{"UintLt"}},
+		{"empty float range", `double a = 1 [(validator.field) = {float_gt: 1, float_lt: 1}];`, []string{"FloatLt"}},
+		{"length_eq out of range", `string a = 1 [(validator.field) = {length_gt: 3, length_eq: 3}];`, []string{"LengthEq"}},
+		{"wrong kind", `int32 a = 1 [(validator.field) = {regex: "x"}];`, []string{"Regex"}},
+		{"map key rule", `map<string, int32> a = 1 [(validator.field) = {regex: "^k"}];`, nil},
+		{"invalid regex", `string a = 1 [(validator.field) = {regex: "("}];`, []string{"Regex"}},
+		{"both ip versions", `string a = 1 [(validator.field) = {ipv4: true, ipv6: true}];`, []string{"Ipv6"}},
+		{"required contains forbidden", `string a = 1 [(validator.field) = {string_prefix: "ab", string_not_contains: "b"}];`, []string{"StringNotContains"}},
+		{"count on singular", `string a = 1 [(validator.field) = {repeated_count_max: 1}];`, []string{"RepeatedCountMax"}},
+		{"empty count range", `repeated string a = 1 [(validator.field) = {repeated_count_min: 2, repeated_count_max: 1}];`, []string{"RepeatedCountMax"}},
+		{"negative msg_max_bytes", `Other a = 1 [(validator.field) = {msg_max_bytes: -1}];`, []string{"MsgMaxBytes"}},
+		{"empty version range", `string a = 1 [(validator.field) = {since_version: "2", until_version: "1.5"}];`, []string{"UntilVersion"}},
+		{"versioned rule", `string a = 1 [(validator.field) = {length_gt: 1, versioned: [{length_lt: 2}]}];`, []string{"LengthLt"}},
+		{"versioned rule repeating an issue", `string a = 1 [(validator.field) = {regex: "(", versioned: [{length_gt: 1}]}];`, []string{"Regex"}},
+		{"nested rule", `Other a = 1 [(validator.field) = {nested: [{field_path: "b", rule: {int_gt: 1}}]}];`, []string{"IntGt"}},
+		{"unresolved nested path", `Other a = 1 [(validator.field) = {nested: [{field_path: "c"}]}];`, []string{"Nested"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fd := compileProto(t, `syntax = "proto3"; package lint; import "validator.proto";
+message Other { string b = 1; }
+message Linted { `+c.field+` }`)
+			var rules []string
+			for _, issue := range LintFile(fd) {
+				rules = append(rules, issue.Rule)
+			}
+			if !reflect.DeepEqual(rules, c.issues) {
+				t.Fatal(LintFile(fd))
+			}
+		})
+	}
+}
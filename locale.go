@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"strings"
+)
+
+// WithMessages register the messages of violations in locale, keyed by rule (e.g. "LengthGt"),
+// with the placeholders of error_message ({{value}}, {{rule}}). Registering a locale again replaces its messages.
+func WithMessages(locale string, messages map[string]string) Option {
+	return func(v *Validator) {
+		if v.messages == nil {
+			v.messages = make(map[string]map[string]string)
+		}
+		v.messages[locale] = messages
+	}
+}
+
+// WithLocale render the message of violations with the messages of locale, see WithMessages.
+// A locale without messages falls back to its language (e.g. "fr-CA" to "fr"),
+// rules without a message keep the default one and error_message takes precedence.
+func WithLocale(locale string) Option {
+	return func(v *Validator) {
+		v.locale = locale
+	}
+}
+
+// CallLocale override WithLocale for the call
+func CallLocale(locale string) CallOption {
+	return func(c *callOptions) {
+		c.locale = &locale
+	}
+}
+
+// localeMessages get the messages of locale, or of its language, nil if none
+func (v *Validator) localeMessages(locale string) map[string]string {
+	if locale == "" || v.messages == nil {
+		return nil
+	}
+	if messages, ok := v.messages[locale]; ok {
+		return messages
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		return v.messages[locale[:i]]
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"log"
+	"sync/atomic"
+)
+
+// Logger diagnostics logger, e.g. a *zap.SugaredLogger, see StdLogger and SlogLogger for the standard library loggers.
+// Diagnostics are discarded by default.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+// nopLogger discard every diagnostic
+type nopLogger struct{}
+
+// Debugf implement Logger
+func (nopLogger) Debugf(string, ...interface{}) {}
+
+// Warnf implement Logger
+func (nopLogger) Warnf(string, ...interface{}) {}
+
+// stdLogger write diagnostics to a standard library logger
+type stdLogger struct {
+	l     *log.Logger
+	debug bool
+}
+
+// StdLogger write diagnostics to l, or to the standard logger if l is nil. Debug diagnostics are dropped unless debug is set.
+func StdLogger(l *log.Logger, debug bool) Logger {
+	if l == nil {
+		l = log.Default()
+	}
+	return &stdLogger{l: l, debug: debug}
+}
+
+// Debugf implement Logger
+func (s *stdLogger) Debugf(format string, args ...interface{}) {
+	if s.debug {
+		s.l.Printf(format, args...)
+	}
+}
+
+// Warnf implement Logger
+func (s *stdLogger) Warnf(format string, args ...interface{}) {
+	s.l.Printf(format, args...)
+}
+
+// loggerBox holder of the package logger, atomic.Value needs a consistent concrete type
+type loggerBox struct {
+	Logger
+}
+
+// logger package logger, used by validators without WithLogger
+var logger atomic.Value
+
+func init() {
+	logger.Store(loggerBox{nopLogger{}})
+}
+
+// SetLogger set the logger of the validators without WithLogger, nil discards the diagnostics
+func SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	logger.Store(loggerBox{l})
+}
+
+// GetLogger get the logger set with SetLogger
+func GetLogger() Logger {
+	return logger.Load().(loggerBox).Logger
+}
+
+// WithLogger write the diagnostics of the validator to l instead of the logger of SetLogger
+func WithLogger(l Logger) Option {
+	return func(v *Validator) {
+		v.logger = l
+	}
+}
+
+// Logger logger of the validator, the logger of SetLogger without WithLogger
+func (v *Validator) Logger() Logger {
+	if v.logger != nil {
+		return v.logger
+	}
+	return GetLogger()
+}
+
+// debugf log a debug diagnostic with the logger of the validator
+func (v *Validator) debugf(format string, args ...interface{}) {
+	v.Logger().Debugf(format, args...)
+}
+
+// warnf log a warning with the logger of the validator
+func (v *Validator) warnf(format string, args ...interface{}) {
+	v.Logger().Warnf(format, args...)
+}
+
+// warnf log a warning with the package logger
+func warnf(format string, args ...interface{}) {
+	GetLogger().Warnf(format, args...)
+}
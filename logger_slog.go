@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package validator
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// slogLogger write diagnostics to a structured logger
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// SlogLogger write diagnostics to l at the debug and warn levels, or to slog.Default() if l is nil
+func SlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &slogLogger{l: l}
+}
+
+// Debugf implement Logger
+func (s *slogLogger) Debugf(format string, args ...interface{}) {
+	s.l.Debug(fmt.Sprintf(format, args...))
+}
+
+// Warnf implement Logger
+func (s *slogLogger) Warnf(format string, args ...interface{}) {
+	s.l.Warn(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,36 @@
+//go:build go1.21
+
+package validator
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	fd := compileProto(t, loggedProto)
+	msg := newMsg(t, fd, "Item", `{"sku":"a","name":"a"}`)
+	for _, c := range []struct {
+		name  string
+		level slog.Level
+		debug bool
+	}{
+		{"warn level", slog.LevelWarn, false},
+		{"debug level", slog.LevelDebug, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: c.level}))
+			_ = New(WithLogger(SlogLogger(l)), WithAggregation(AggregateAll)).Validate(msg)
+			out := buf.String()
+			if !strings.Contains(out, `level=WARN msg="[pb valid]rule func[missing] not found"`) {
+				t.Fatal(out)
+			}
+			if strings.Contains(out, `level=DEBUG msg="[pb valid]make regex[(]`) != c.debug {
+				t.Fatal(out)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+// loggedProto message whose validation logs a warning (the go_func is not registered)
+// and a debug diagnostic (the regex is invalid)
+const loggedProto = `syntax = "proto3"; package logged; import "validator.proto";
+message Item {
+  string sku = 1 [(validator.field) = {go_func: "missing"}];
+  string name = 2 [(validator.field) = {regex: "("}];
+}`
+
+func TestStdLogger(t *testing.T) {
+	fd := compileProto(t, loggedProto)
+	msg := newMsg(t, fd, "Item", `{"sku":"a","name":"a"}`)
+	for _, c := range []struct {
+		name  string
+		debug bool
+	}{
+		{"warnings", false},
+		{"debug", true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			_ = New(WithLogger(StdLogger(log.New(&buf, "", 0), c.debug)), WithAggregation(AggregateAll)).Validate(msg)
+			out := buf.String()
+			if !strings.Contains(out, "[pb valid]rule func[missing] not found") {
+				t.Fatal(out)
+			}
+			if strings.Contains(out, "[pb valid]make regex[(]") != c.debug {
+				t.Fatal(out)
+			}
+		})
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	defer SetLogger(nil)
+	fd := compileProto(t, loggedProto)
+	msg := newMsg(t, fd, "Item", `{"sku":"a"}`)
+	var buf bytes.Buffer
+	SetLogger(StdLogger(log.New(&buf, "", 0), false))
+	//validators without WithLogger write to the package logger
+	v := New()
+	if v.Logger() != GetLogger() {
+		t.Fatal("package logger not used")
+	}
+	_ = v.Validate(msg)
+	if !strings.Contains(buf.String(), "[pb valid]rule func[missing] not found") {
+		t.Fatal(buf.String())
+	}
+	//the logger of WithLogger takes precedence
+	buf.Reset()
+	logger := &testLogger{}
+	_ = New(WithLogger(logger)).Validate(msg)
+	if buf.Len() != 0 || len(logger.warnings) == 0 {
+		t.Fatalf("%q %v", buf.String(), logger.warnings)
+	}
+	//nil discards the diagnostics
+	SetLogger(nil)
+	_ = New().Validate(msg)
+	if buf.Len() != 0 {
+		t.Fatal(buf.String())
+	}
+}
@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkMapKey check the key of a map entry with the map_key_regex and map_key_in rules
+func (v *validator) checkMapKey(field protoreflect.FieldDescriptor, key protoreflect.MapKey, rule *FieldValidator) error {
+	if rule == nil {
+		return nil
+	}
+	keyField := field.MapKey()
+	if rule.MapKeyRegex != nil && keyField.Kind() == protoreflect.StringKind {
+		exp, err := r.Get(*rule.MapKeyRegex)
+		if err != nil {
+			//reported by Register, an invalid regex rejects every key instead of letting it through
+			v.debugf("[pb valid]make regex[%s] err: %s", *rule.MapKeyRegex, err)
+		}
+		if err != nil || !exp.MatchString(key.String()) {
+			if err := v.fail(keyField, "MapKeyRegex", *rule.MapKeyRegex, key.String()); err != nil {
+				return err
+			}
+		}
+	}
+	if len(rule.MapKeyIn) > 0 && !containsString(rule.MapKeyIn, key.String()) {
+		if err := v.fail(keyField, "MapKeyIn", rule.MapKeyIn, key.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMapKeyRule check the configuration of the map key rules
+func checkMapKeyRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	if rule.MapKeyRegex == nil {
+		return nil
+	}
+	if _, err := r.Get(*rule.MapKeyRegex); err != nil {
+		return fmt.Errorf("[proto valid]field[%s] invalid map_key_regex[%s]: %w", field.FullName(), *rule.MapKeyRegex, err)
+	}
+	return nil
+}
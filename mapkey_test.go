@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapKey(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package mapkey; import "validator.proto";
+message Labels {
+  map<string, string> labels = 1 [(validator.field) = {map_key_regex: "^[a-z0-9-]{1,63}$"}];
+  map<string, int32> sizes = 2 [(validator.field) = {map_key_in: ["s", "m", "l"]}];
+  map<int32, string> codes = 3 [(validator.field) = {map_key_in: ["-1", "200"]}];
+  map<bool, string> flags = 4 [(validator.field) = {map_key_in: ["true"]}];
+  map<string, string> both = 5 [(validator.field) = {map_key_regex: "^[a-z]+$", map_key_in: ["a", "B"]}];
+}`)
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"empty maps", `{}`, "", ""},
+		{"legal keys", `{"labels":{"app":"x","tier-1":"y"},"sizes":{"s":1,"l":2},"codes":{"-1":"x","200":"y"},"flags":{"true":"x"}}`, "", ""},
+		{"longest key", `{"labels":{"` + strings.Repeat("a", 63) + `":"x"}}`, "", ""},
+		{"key too long", `{"labels":{"` + strings.Repeat("a", 64) + `":"x"}}`, `labels["` + strings.Repeat("a", 64) + `"]`, "MapKeyRegex"},
+		{"empty key", `{"labels":{"":"x"}}`, `labels[""]`, "MapKeyRegex"},
+		{"upper case key", `{"labels":{"App":"x"}}`, `labels["App"]`, "MapKeyRegex"},
+		{"key not in", `{"sizes":{"xl":1}}`, `sizes["xl"]`, "MapKeyIn"},
+		{"key in other case", `{"sizes":{"S":1}}`, `sizes["S"]`, "MapKeyIn"},
+		{"int key not in", `{"codes":{"404":"x"}}`, "codes[404]", "MapKeyIn"},
+		{"bool key not in", `{"flags":{"false":"x"}}`, "flags[false]", "MapKeyIn"},
+		{"both rules", `{"both":{"a":"x"}}`, "", ""},
+		{"in but not matching", `{"both":{"B":"x"}}`, `both["B"]`, "MapKeyRegex"},
+		{"matching but not in", `{"both":{"b":"x"}}`, `both["b"]`, "MapKeyIn"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().Validate(newMsg(t, fd, "Labels", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+	//every key is reported
+	err := New().ValidateAll(newMsg(t, fd, "Labels", `{"labels":{"A":"x","ok":"y","B":"z"}}`))
+	if errs := ValidErrors(err); len(errs) != 2 || !MatchViolation(err, `labels["A"]`, "MapKeyRegex") || !MatchViolation(err, `labels["B"]`, "MapKeyRegex") {
+		t.Fatal(err)
+	}
+}
+
+func TestMapKeyRuleConfig(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package mapkey; import "validator.proto";
+message Labels { map<string, string> labels = 1 [(validator.field) = {map_key_regex: "^[a-z"}]; }`)
+	md := fd.Messages().ByName("Labels")
+	if err := New().Register(md); err == nil {
+		t.Fatal("want an invalid map_key_regex error")
+	}
+	//an invalid regex rejects every key
+	if err := New().Validate(newMsg(t, fd, "Labels", `{"labels":{"a":"x"}}`)); !MatchViolation(err, `labels["a"]`, "MapKeyRegex") {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"reflect"
+	"testing"
+)
+
+// testMapPathProto map value messages nesting repeated fields and other maps
+const testMapPathProto = `syntax = "proto3"; package mappath; import "validator.proto";
+message Endpoint { string host = 1 [(validator.field) = {length_gt: 1}]; }
+message Cluster {
+  repeated Endpoint endpoints = 1;
+  map<int64, Endpoint> shards = 2;
+  map<string, string> labels = 3 [(validator.field) = {map_key_regex: "^[a-z]+$"}];
+}
+message Config { map<string, Cluster> clusters = 1; string name = 2; }`
+
+func TestMapMessagePath(t *testing.T) {
+	fd := compileProto(t, testMapPathProto)
+	for _, c := range []struct {
+		name, json                 string
+		dotted, pointer, fieldMask string
+		keys                       []interface{}
+	}{
+		{"element of a map value", `{"clusters":{"redis":{"endpoints":[{"host":"ab"},{"host":"a"}]}}}`,
+			`clusters["redis"].endpoints[1].host`, "/clusters/redis/endpoints/1/host", "clusters.endpoints.host", []interface{}{"redis", nil, nil}},
+		{"map of a map value", `{"clusters":{"redis":{"shards":{"-3":{"host":"a"}}}}}`,
+			`clusters["redis"].shards[-3].host`, "/clusters/redis/shards/-3/host", "clusters.shards.host", []interface{}{"redis", int64(-3), nil}},
+		{"key of a map of a map value", `{"clusters":{"a/b":{"labels":{"Bad":"x"}}}}`,
+			`clusters["a/b"].labels["Bad"]`, "/clusters/a~1b/labels/Bad", "clusters.labels", []interface{}{"a/b", "Bad"}},
+		{"escaped key", `{"clusters":{"say \"hi\"":{"endpoints":[{"host":"a"}]}}}`,
+			`clusters["say \"hi\""].endpoints[0].host`, `/clusters/say "hi"/endpoints/0/host`, "clusters.endpoints.host", []interface{}{`say "hi"`, nil, nil}},
+		{"empty key", `{"clusters":{"":{"endpoints":[{"host":"a"}]}}}`,
+			`clusters[""].endpoints[0].host`, "/clusters//endpoints/0/host", "clusters.endpoints.host", []interface{}{"", nil, nil}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			msg := newMsg(t, fd, "Config", c.json)
+			for format, want := range map[PathFormat]string{PathDotted: c.dotted, PathJSONPointer: c.pointer, PathFieldMask: c.fieldMask} {
+				errs := ValidErrors(New(WithPathFormat(format)).ValidateAll(msg))
+				if len(errs) != 1 || errs[0].Path() != want {
+					t.Fatalf("%s: got %v, want %s", format, errs, want)
+				}
+			}
+			e := ValidErrors(New().ValidateAll(msg))[0]
+			var keys []interface{}
+			for _, elem := range e.PathElements() {
+				var key interface{}
+				if elem.Key.IsValid() {
+					key = elem.Key.Interface()
+				}
+				keys = append(keys, key)
+			}
+			if !reflect.DeepEqual(keys, c.keys) {
+				t.Fatalf("got keys %v, want %v", keys, c.keys)
+			}
+			//only the last element is the key of the violating value
+			if last := c.keys[len(c.keys)-1]; e.Key().IsValid() != (last != nil) {
+				t.Fatal(e.Key())
+			}
+		})
+	}
+}
+
+func TestMapMessagePathChanged(t *testing.T) {
+	fd := compileProto(t, testMapPathProto)
+	old := newMsg(t, fd, "Config", `{"clusters":{"redis":{"endpoints":[{"host":"a"}]},"pg":{"endpoints":[{"host":"ab"}]}}}`)
+	msg := proto.Clone(old)
+	proto.Merge(msg, newMsg(t, fd, "Config", `{"clusters":{"pg":{"endpoints":[{"host":"b"}]}}}`))
+	//the redis entry is unchanged, the replaced pg entry is verified with its key
+	errs := ValidErrors(New().ValidateChanged(old, msg))
+	if len(errs) != 1 || errs[0].Path() != `clusters["pg"].endpoints[0].host` {
+		t.Fatal(errs)
+	}
+}
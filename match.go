@@ -0,0 +1,79 @@
+package validator
+
+// Is whether target is a *ValidError of the same rule at the same path, for errors.Is.
+// The rule and the path of target are ignored if empty, e.g. RuleError("Regex") matches any regex violation.
+func (e *ValidError) Is(target error) bool {
+	t, ok := target.(*ValidError)
+	if !ok {
+		return false
+	}
+	if t.validKey != "" && t.validKey != e.validKey {
+		return false
+	}
+	if len(t.path) == 0 {
+		return true
+	}
+	return len(t.path) == len(e.path) && formatPath(t.path, PathDotted) == formatPath(e.path, PathDotted)
+}
+
+// RuleError target matching every violation of a rule with errors.Is, e.g. errors.Is(err, RuleError("Regex"))
+func RuleError(ruleKind string) error {
+	return &ValidError{validKey: ruleKind}
+}
+
+// MatchViolation whether err holds a violation of ruleKind at path, e.g. to assert on the failed rule in tests
+// without comparing Error() strings. The path is compared in the format of the validator and in dotted format,
+// an empty path or ruleKind matches any. Joined errors and *ValidationError are searched.
+func MatchViolation(err error, path, ruleKind string) bool {
+	return FindViolation(err, path, ruleKind) != nil
+}
+
+// FindViolation first violation of ruleKind at path held by err, nil if none, see MatchViolation
+func FindViolation(err error, path, ruleKind string) (found *ValidError) {
+	walkErrors(err, func(err error) bool {
+		e, ok := err.(*ValidError)
+		if !ok || ruleKind != "" && e.validKey != ruleKind {
+			return true
+		}
+		if path != "" && e.Path() != path && e.FormatPath(PathDotted) != path {
+			return true
+		}
+		found = e
+		return false
+	})
+	return found
+}
+
+// walkErrors call fn on err and every error it wraps, depth first, until fn returns false
+func walkErrors(err error, fn func(err error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !fn(err) {
+		return false
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkErrors(x.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, err := range x.Unwrap() {
+			if !walkErrors(err, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ValidErrors every violation held by err, in order, e.g. to build an API response from an aggregated error.
+// Joined errors and *ValidationError are searched.
+func ValidErrors(err error) []*ValidError {
+	var errs []*ValidError
+	walkErrors(err, func(err error) bool {
+		if e, ok := err.(*ValidError); ok {
+			errs = append(errs, e)
+		}
+		return true
+	})
+	return errs
+}
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRuleErrorIs(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package match; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 1, regex: "^[A-Z]+$"}]; }
+message Order { Item item = 1; repeated Item items = 2; string name = 3 [(validator.field) = {regex: "^[a-z]+$"}]; }`)
+	all := New().ValidateAll(newMsg(t, fd, "Order", `{"item":{"sku":"a"},"items":[{"sku":"AB"},{"sku":"b"}],"name":"ok"}`))
+	//a violation of another validation, to match the path as well
+	target := func(json, path, rule string) error {
+		e := FindViolation(New().ValidateAll(newMsg(t, fd, "Order", json)), path, rule)
+		if e == nil {
+			t.Fatalf("no %s violation at %s", rule, path)
+		}
+		return e
+	}
+	for _, c := range []struct {
+		name   string
+		err    error
+		target error
+		is     bool
+	}{
+		{"rule", all, RuleError("Regex"), true},
+		{"other rule", all, RuleError("LengthLt"), false},
+		{"any rule", all, RuleError(""), true},
+		{"path and rule", all, target(`{"items":[{},{"sku":"b"}]}`, "items[1].sku", "Regex"), true},
+		{"path without violation", all, target(`{"name":"A"}`, "name", "Regex"), false},
+		{"other path", all, target(`{"items":[{"sku":"b"}]}`, "items[0].sku", "Regex"), false},
+		{"path of a sub-message", all, target(`{"item":{"sku":"a"}}`, "item.sku", "LengthGt"), true},
+		{"single violation", FindViolation(all, "item.sku", "LengthGt"), RuleError("LengthGt"), true},
+		{"wrapped", fmt.Errorf("create order: %w", FindViolation(all, "item.sku", "LengthGt")), RuleError("LengthGt"), true},
+		{"joined other rule", errors.Join(errors.New("other"), FindViolation(all, "item.sku", "LengthGt")), RuleError("Regex"), false},
+		{"joined violation", errors.Join(errors.New("other"), FindViolation(all, "items[1].sku", "LengthGt")), RuleError("LengthGt"), true},
+		{"no violation", errors.New("Regex"), RuleError("Regex"), false},
+		{"nil", nil, RuleError("Regex"), false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if errors.Is(c.err, c.target) != c.is {
+				t.Fatalf("%v is %v: want %v", c.err, c.target, c.is)
+			}
+		})
+	}
+	//a target prints without field
+	if s := fmt.Sprint(RuleError("Regex")); s != "[proto valid]error: valid[Regex]" {
+		t.Fatal(s)
+	}
+	if e := RuleError("Regex").(*ValidError); e.Key().IsValid() || e.Path() != "" {
+		t.Fatal(e.Key(), e.Path())
+	}
+}
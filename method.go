@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"strings"
+	"sync"
+)
+
+// WithMethodRule scope the validation of the requests of the method named fullName (e.g. "pkg.Service.Create"),
+// taking precedence over its validator.method option, for methods of services that can't be annotated
+func WithMethodRule(fullName protoreflect.FullName, rule *MethodValidator) Option {
+	return func(v *Validator) {
+		if v.methodRules == nil {
+			v.methodRules = make(map[protoreflect.FullName]*MethodValidator)
+		}
+		v.methodRules[fullName] = rule
+	}
+}
+
+// ContextForMethod select the validation groups and the overlay of the requests of method with the default validator
+func ContextForMethod(ctx context.Context, method protoreflect.MethodDescriptor) context.Context {
+	return std().ContextForMethod(ctx, method)
+}
+
+// ContextForMethod select the validation groups and the overlay of the requests of method,
+// from WithMethodRule or the validator.method option of the method, for the validations using the returned context
+func (v *Validator) ContextForMethod(ctx context.Context, method protoreflect.MethodDescriptor) context.Context {
+	rule := v.methodRule(method)
+	if len(rule.GetGroups()) > 0 {
+		ctx = ContextWithCallOptions(ctx, CallGroups(rule.Groups...))
+	}
+	if rule != nil && rule.Overlay != nil {
+		ctx = ContextWithOverlay(ctx, *rule.Overlay)
+	}
+	return ctx
+}
+
+// ContextForFullMethod ContextForMethod of the method named by a gRPC full method name, e.g. "/pkg.Service/Create",
+// resolved in protoregistry.GlobalFiles. ctx is returned unchanged if the method is unknown.
+func ContextForFullMethod(ctx context.Context, fullMethod string) context.Context {
+	return std().ContextForFullMethod(ctx, fullMethod)
+}
+
+// ContextForFullMethod ContextForMethod of the method named by a gRPC full method name, e.g. "/pkg.Service/Create",
+// resolved in protoregistry.GlobalFiles. ctx is returned unchanged if the method is unknown.
+func (v *Validator) ContextForFullMethod(ctx context.Context, fullMethod string) context.Context {
+	method := findMethod(fullMethod)
+	if method == nil {
+		return ctx
+	}
+	return v.ContextForMethod(ctx, method)
+}
+
+// findMethod find the descriptor of a gRPC full method name, nil if unknown
+func findMethod(fullMethod string) protoreflect.MethodDescriptor {
+	service, name, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	return sd.Methods().ByName(protoreflect.Name(name))
+}
+
+// methodRule get the validation scope of a method, nil if none.
+// Decoded options are cached per method.
+func (v *Validator) methodRule(method protoreflect.MethodDescriptor) *MethodValidator {
+	if rule, ok := v.methodRules[method.FullName()]; ok {
+		return rule
+	}
+	if x, ok := v.methods.Load(method); ok {
+		return x.(*MethodValidator)
+	}
+	rule, err := decodeMethodRule(method)
+	if err != nil {
+		v.warnf("[pb valid]decode rule of method[%s] err: %s", method.FullName(), err)
+		v.fault(&Fault{Kind: FaultConfig, Err: fmt.Errorf("method[%s] decode rule: %w", method.FullName(), err)})
+	}
+	v.methods.Store(method, rule)
+	return rule
+}
+
+var (
+	// optionsResolver resolver of the validator.method and validator.message extensions only, built on first use
+	optionsResolver     *protoregistry.Types
+	optionsResolverOnce sync.Once
+)
+
+// decodeMethodRule decode the validator.method option of a method
+func decodeMethodRule(method protoreflect.MethodDescriptor) (*MethodValidator, error) {
+	x, err := decodeOption(method.Options(), &descriptorpb.MethodOptions{}, E_Method)
+	rule, _ := x.(*MethodValidator)
+	return rule, err
+}
+
+// decodeOption decode the extension xt of descriptor options opt, nil if not set.
+// The options are re-read into resolved, the extension may be unknown to the descriptor or resolved as another type.
+func decodeOption(opt, resolved proto.Message, xt protoreflect.ExtensionType) (interface{}, error) {
+	if opt == nil || !opt.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	data, err := proto.Marshal(opt)
+	if err != nil {
+		return nil, err
+	}
+	optionsResolverOnce.Do(func() {
+		optionsResolver = new(protoregistry.Types)
+		for _, xt := range []protoreflect.ExtensionType{E_Method, E_Message} {
+			if err := optionsResolver.RegisterExtension(xt); err != nil {
+				panic(err)
+			}
+		}
+	})
+	if err := (proto.UnmarshalOptions{Resolver: optionsResolver}).Unmarshal(data, resolved); err != nil {
+		return nil, err
+	}
+	if !proto.HasExtension(resolved, xt) {
+		return nil, nil
+	}
+	return proto.GetExtension(resolved, xt), nil
+}
@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+)
+
+func TestContextForMethod(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package method; import "validator.proto";
+message User {
+  string id = 1 [(validator.field) = {length_gt: 1}];
+  string email = 2 [(validator.field) = {length_gt: 1, groups: ["create"]}];
+  string name = 3;
+}
+service Users {
+  rpc Create(User) returns (User) { option (validator.method) = {groups: ["create"]}; }
+  rpc Rename(User) returns (User) { option (validator.method) = {overlay: "rename"}; }
+  rpc Update(User) returns (User) { option (validator.method) = {groups: ["create"]}; }
+  rpc Get(User) returns (User);
+}`)
+	gt := int64(2)
+	rename := &RuleSet{
+		Name:     proto.String("rename"),
+		Messages: map[string]*MessageRules{"method.User": {Fields: map[string]*FieldValidator{"name": {LengthGt: &gt}}}},
+	}
+	methods := fd.Services().ByName("Users").Methods()
+	for _, c := range []struct {
+		name, method, json, path, rule string
+		opts                           []Option
+	}{
+		{"no option", "Get", `{"id":"ab"}`, "", "", nil},
+		{"option groups", "Create", `{"id":"ab"}`, "email", "LengthGt", nil},
+		{"option groups legal", "Create", `{"id":"ab","email":"ab"}`, "", "", nil},
+		{"option groups replace WithGroups", "Get", `{"id":"ab"}`, "email", "LengthGt", []Option{WithGroups("create")}},
+		{"option overlay", "Rename", `{"id":"ab","name":"ab"}`, "name", "LengthGt", nil},
+		{"option overlay legal", "Rename", `{"id":"ab","name":"abc"}`, "", "", nil},
+		{"option overlay not on other methods", "Get", `{"id":"ab","name":"ab"}`, "", "", nil},
+		{"method rule", "Get", `{"id":"ab","name":"ab"}`, "name", "LengthGt",
+			[]Option{WithMethodRule("method.Users.Get", &MethodValidator{Overlay: proto.String("rename")})}},
+		{"method rule over the option", "Update", `{"id":"ab"}`, "", "",
+			[]Option{WithMethodRule("method.Users.Update", &MethodValidator{})}},
+		{"method rule of another method", "Create", `{"id":"ab"}`, "email", "LengthGt",
+			[]Option{WithMethodRule("method.Users.Update", &MethodValidator{})}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(append([]Option{WithOverlay(rename)}, c.opts...)...)
+			//twice, the decoded option is cached
+			for i := 0; i < 2; i++ {
+				ctx := v.ContextForMethod(context.Background(), methods.ByName(protoreflect.Name(c.method)))
+				err := v.ValidateContext(ctx, newMsg(t, fd, "User", c.json))
+				if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+
+	//the default validator reads the option too
+	ctx := ContextForMethod(context.Background(), methods.ByName("Create"))
+	if err := ValidateContext(ctx, newMsg(t, fd, "User", `{"id":"ab"}`)); !MatchViolation(err, "email", "LengthGt") {
+		t.Fatal(err)
+	}
+	//unknown methods leave the context alone
+	ctx = context.Background()
+	if got := ContextForFullMethod(ctx, "/method.Users/Create"); got != ctx {
+		t.Fatal("context of a method missing from the global registry changed")
+	}
+	if got := ContextForFullMethod(ctx, "Create"); got != ctx {
+		t.Fatal("context of a malformed method name changed")
+	}
+}
@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"math/big"
+	"regexp"
+)
+
+const (
+	// moneyFullName full name of google.type.Money
+	moneyFullName protoreflect.FullName = "google.type.Money"
+	// decimalFullName full name of google.type.Decimal
+	decimalFullName protoreflect.FullName = "google.type.Decimal"
+)
+
+// decimalRegex plain decimal amount, e.g. "-10.50"
+var decimalRegex = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// currencyRegex ISO 4217 currency code, e.g. "EUR"
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// parseDecimal parse a plain decimal amount exactly
+func parseDecimal(s string) (*big.Rat, bool) {
+	if !decimalRegex.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// moneyAmount amount of a google.type.Money, false if units and nanos are inconsistent
+func moneyAmount(msg protoreflect.Message) (*big.Rat, bool) {
+	fields := msg.Descriptor().Fields()
+	units := msg.Get(fields.ByName("units")).Int()
+	nanos := msg.Get(fields.ByName("nanos")).Int()
+	if nanos <= -1e9 || nanos >= 1e9 || units > 0 && nanos < 0 || units < 0 && nanos > 0 {
+		return nil, false
+	}
+	amount := new(big.Rat).SetInt64(units)
+	return amount.Add(amount, big.NewRat(nanos, 1e9)), true
+}
+
+// isMoney whether the money rule applies to the field
+func isMoney(field protoreflect.FieldDescriptor) bool {
+	if field.Kind() == protoreflect.MessageKind {
+		name := field.Message().FullName()
+		return name == moneyFullName || name == decimalFullName
+	}
+	return field.Kind() == protoreflect.StringKind
+}
+
+// checkMoney check a google.type.Money or google.type.Decimal sub-message with the money rule
+func (v *validator) checkMoney(field protoreflect.FieldDescriptor, subMsg protoreflect.Message, rule *FieldValidator) error {
+	if rule.GetMoney() == nil {
+		return nil
+	}
+	switch subMsg.Descriptor().FullName() {
+	case moneyFullName:
+		code := subMsg.Get(subMsg.Descriptor().Fields().ByName("currency_code")).String()
+		if in := rule.GetMoney().GetCurrencyIn(); len(in) > 0 && !containsString(in, code) {
+			if err := v.fail(field, "MoneyCurrency", in, code); err != nil {
+				return err
+			}
+		}
+		amount, ok := moneyAmount(subMsg)
+		if !ok {
+			return v.fail(field, "Money", "units and nanos of the same sign", subMsg.Interface())
+		}
+		return v.checkAmount(field, amount, rule)
+	case decimalFullName:
+		return v.checkDecimal(field, subMsg.Get(subMsg.Descriptor().Fields().ByName("value")).String(), rule)
+	}
+	return nil
+}
+
+// checkDecimal check a decimal amount string with the money rule
+func (v *validator) checkDecimal(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.GetMoney() == nil {
+		return nil
+	}
+	amount, ok := parseDecimal(value)
+	if !ok {
+		return v.fail(field, "Money", "decimal amount", value)
+	}
+	return v.checkAmount(field, amount, rule)
+}
+
+// checkAmount check an amount with the bounds of the money rule
+func (v *validator) checkAmount(field protoreflect.FieldDescriptor, amount *big.Rat, rule *FieldValidator) error {
+	money := rule.GetMoney()
+	if money.GetNonNegative() && amount.Sign() < 0 {
+		if err := v.fail(field, "MoneyNonNegative", true, amount.FloatString(9)); err != nil {
+			return err
+		}
+	}
+	if min, ok := parseDecimal(money.GetMin()); ok && amount.Cmp(min) < 0 {
+		if err := v.fail(field, "MoneyMin", money.GetMin(), amount.FloatString(9)); err != nil {
+			return err
+		}
+	}
+	if max, ok := parseDecimal(money.GetMax()); ok && amount.Cmp(max) > 0 {
+		if err := v.fail(field, "MoneyMax", money.GetMax(), amount.FloatString(9)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMoneyRule check that the bounds of the money rule are decimal amounts
+func checkMoneyRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	money := rule.GetMoney()
+	if money == nil {
+		return nil
+	}
+	if _, ok := parseDecimal(money.GetMin()); money.Min != nil && !ok {
+		return fmt.Errorf("[proto valid]field[%s] money min[%s] is not a decimal amount", field.FullName(), money.GetMin())
+	}
+	if _, ok := parseDecimal(money.GetMax()); money.Max != nil && !ok {
+		return fmt.Errorf("[proto valid]field[%s] money max[%s] is not a decimal amount", field.FullName(), money.GetMax())
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestMoney(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package money; import "validator.proto"; import "google/type/types.proto";
+message Order {
+  google.type.Money price = 1 [(validator.field) = {money: {currency_in: ["EUR", "USD"], min: "0.5", max: "100"}}];
+  google.type.Decimal discount = 2 [(validator.field) = {money: {non_negative: true}}];
+  string fee = 3 [(validator.field) = {money: {max: "9.99"}}];
+}`)
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"legal", `{"price":{"currencyCode":"EUR","units":"10","nanos":500000000},"discount":{"value":"0"},"fee":"9.99"}`, "", ""},
+		{"unset", `{"fee":"0"}`, "", ""},
+		{"min bound", `{"price":{"currencyCode":"EUR","nanos":500000000},"fee":"0"}`, "", ""},
+		{"max bound", `{"price":{"currencyCode":"USD","units":"100"},"fee":"0"}`, "", ""},
+		{"below min", `{"price":{"currencyCode":"EUR","nanos":499999999}}`, "price", "MoneyMin"},
+		{"above max", `{"price":{"currencyCode":"USD","units":"100","nanos":1}}`, "price", "MoneyMax"},
+		{"negative below min", `{"price":{"currencyCode":"USD","units":"-1","nanos":-500000000}}`, "price", "MoneyMin"},
+		{"currency", `{"price":{"currencyCode":"GBP","units":"10"}}`, "price", "MoneyCurrency"},
+		{"lower case currency", `{"price":{"currencyCode":"eur","units":"10"}}`, "price", "MoneyCurrency"},
+		{"zero money", `{"price":{}}`, "price", "MoneyCurrency"},
+		{"zero amount", `{"price":{"currencyCode":"EUR"}}`, "price", "MoneyMin"},
+		{"mixed signs", `{"price":{"currencyCode":"USD","units":"1","nanos":-1}}`, "price", "Money"},
+		{"nanos overflow", `{"price":{"currencyCode":"USD","nanos":1000000000}}`, "price", "Money"},
+		{"zero decimal", `{"discount":{"value":"0"},"fee":"0"}`, "", ""},
+		{"negative zero decimal", `{"discount":{"value":"-0.00"},"fee":"0"}`, "", ""},
+		{"empty decimal", `{"discount":{}}`, "discount", "Money"},
+		{"negative decimal", `{"discount":{"value":"-0.000000001"}}`, "discount", "MoneyNonNegative"},
+		{"malformed decimal", `{"discount":{"value":"1,5"}}`, "discount", "Money"},
+		{"exponent decimal", `{"discount":{"value":"1e3"}}`, "discount", "Money"},
+		{"decimal string bound", `{"fee":"9.990"}`, "", ""},
+		{"decimal string", `{"fee":"10"}`, "fee", "MoneyMax"},
+		{"unset decimal string", `{}`, "fee", "Money"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Order", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestMoneyRuleConfig(t *testing.T) {
+	for _, c := range []struct {
+		name, rule string
+		invalid    bool
+	}{
+		{"decimal bounds", `min: "-0.5", max: "+100.25"`, false},
+		{"no bounds", `non_negative: true`, false},
+		{"empty min", `min: ""`, true},
+		{"exponent max", `max: "1e3"`, true},
+		{"comma min", `min: "0,5"`, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fd := compileProto(t, `syntax = "proto3"; package money; import "validator.proto"; import "google/type/types.proto";
+message Order { google.type.Money price = 1 [(validator.field) = {money: {`+c.rule+`}}]; }`)
+			if err := New().Register(fd.Messages().ByName("Order")); (err != nil) != c.invalid {
+				t.Fatal(err)
+			}
+		})
+	}
+}
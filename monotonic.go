@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"math"
+)
+
+const (
+	// timestampFullName full name of google.protobuf.Timestamp
+	timestampFullName protoreflect.FullName = "google.protobuf.Timestamp"
+	// durationFullName full name of google.protobuf.Duration
+	durationFullName protoreflect.FullName = "google.protobuf.Duration"
+)
+
+// checkMonotonic check that the elements of a repeated field are ordered,
+// the violation is reported on the first element out of order
+func (v *validator) checkMonotonic(field protoreflect.FieldDescriptor, list protoreflect.List, rule *FieldValidator) error {
+	if rule.GetRepeatedMonotonic() == Monotonic_MONOTONIC_UNSPECIFIED || !isOrdered(field) {
+		return nil
+	}
+	for i := 1; i < list.Len(); i++ {
+		c, ok := compareElems(field, list.Get(i-1), list.Get(i))
+		if ok && (c < 0 || c == 0 && rule.GetRepeatedMonotonic() == Monotonic_MONOTONIC_NON_DECREASING) {
+			continue
+		}
+		v.elem = PathElement{Field: field, Index: i}
+		err := v.fail(field, "RepeatedMonotonic", rule.GetRepeatedMonotonic(), list.Get(i).Interface())
+		v.elem = PathElement{}
+		return err
+	}
+	return nil
+}
+
+// isOrdered whether the elements of a field can be ordered by checkMonotonic
+func isOrdered(field protoreflect.FieldDescriptor) bool {
+	if field.Kind() == protoreflect.MessageKind {
+		name := field.Message().FullName()
+		return name == timestampFullName || name == durationFullName
+	}
+	return isInt(field) || isFloat(field)
+}
+
+// compareElems compare two elements of an ordered field, ok is false if they are not comparable (NaN)
+func compareElems(field protoreflect.FieldDescriptor, a, b protoreflect.Value) (c int, ok bool) {
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return compareInt(a.Int(), b.Int()), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		x, y := a.Uint(), b.Uint()
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		x, y := a.Float(), b.Float()
+		switch {
+		case math.IsNaN(x) || math.IsNaN(y):
+			return 0, false
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case protoreflect.MessageKind:
+		//Timestamp and Duration share the seconds and nanos fields
+		ma, mb := a.Message(), b.Message()
+		fields := ma.Descriptor().Fields()
+		seconds, nanos := fields.ByName("seconds"), fields.ByName("nanos")
+		if c := compareInt(ma.Get(seconds).Int(), mb.Get(seconds).Int()); c != 0 {
+			return c, true
+		}
+		return compareInt(ma.Get(nanos).Int(), mb.Get(nanos).Int()), true
+	}
+	return 0, false
+}
+
+// compareInt compare two integers
+func compareInt(x, y int64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
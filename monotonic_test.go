@@ -0,0 +1,50 @@
+package validator
+
+import "testing"
+
+func TestRepeatedMonotonic(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package monotonic; import "validator.proto";
+import "google/protobuf/timestamp.proto"; import "google/protobuf/duration.proto";
+message Series {
+  repeated int64 ids = 1 [(validator.field) = {repeated_monotonic: MONOTONIC_INCREASING}];
+  repeated uint64 offsets = 2 [(validator.field) = {repeated_monotonic: MONOTONIC_NON_DECREASING}];
+  repeated double values = 3 [(validator.field) = {repeated_monotonic: MONOTONIC_INCREASING}];
+  repeated google.protobuf.Timestamp times = 4 [(validator.field) = {repeated_monotonic: MONOTONIC_INCREASING}];
+  repeated google.protobuf.Duration delays = 5 [(validator.field) = {repeated_monotonic: MONOTONIC_NON_DECREASING}];
+  repeated string names = 6 [(validator.field) = {repeated_monotonic: MONOTONIC_INCREASING}];
+  repeated int32 any = 7 [(validator.field) = {repeated_monotonic: MONOTONIC_UNSPECIFIED}];
+}`)
+	for _, c := range []struct {
+		name, json, path string
+	}{
+		{"empty", `{}`, ""},
+		{"single element", `{"ids":["5"]}`, ""},
+		{"increasing", `{"ids":["-2","0","9223372036854775807"]}`, ""},
+		{"equal elements", `{"ids":["1","1"]}`, "ids[1]"},
+		{"decreasing", `{"ids":["1","3","2","1"]}`, "ids[2]"},
+		{"non-decreasing", `{"offsets":["0","0","18446744073709551615"]}`, ""},
+		{"uint64 above int64", `{"offsets":["18446744073709551615","1"]}`, "offsets[1]"},
+		{"floats", `{"values":[-0.5,0,1e-9]}`, ""},
+		{"equal floats", `{"values":[0.1,0.1]}`, "values[1]"},
+		{"NaN", `{"values":[0,"NaN",1]}`, "values[1]"},
+		{"infinities", `{"values":["-Infinity",0,"Infinity"]}`, ""},
+		{"timestamps", `{"times":["2024-01-01T00:00:00Z","2024-01-01T00:00:00.000000001Z","2024-01-01T00:00:01Z"]}`, ""},
+		{"equal timestamps", `{"times":["2024-01-01T00:00:00Z","2024-01-01T00:00:00Z"]}`, "times[1]"},
+		{"earlier nanos", `{"times":["2024-01-01T00:00:00.5Z","2024-01-01T00:00:00.4Z"]}`, "times[1]"},
+		{"durations", `{"delays":["-1s","0s","0s","1.5s"]}`, ""},
+		{"shorter duration", `{"delays":["1.5s","1.4s"]}`, "delays[1]"},
+		{"strings are not ordered", `{"names":["b","a"]}`, ""},
+		{"unspecified", `{"any":[2,1]}`, ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().Validate(newMsg(t, fd, "Series", c.json))
+			if c.path == "" && err != nil || c.path != "" && !MatchViolation(err, c.path, "RepeatedMonotonic") {
+				t.Fatal(err)
+			}
+		})
+	}
+	//only the first element out of order is reported
+	if errs := ValidErrors(New().ValidateAll(newMsg(t, fd, "Series", `{"ids":["3","2","1"]}`))); len(errs) != 1 || errs[0].Path() != "ids[1]" {
+		t.Fatal(errs)
+	}
+}
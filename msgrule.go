@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// msgProgram compiled message-level rules of a message, see MsgValidator
+type msgProgram struct {
+	//exists singular message fields that must be set
+	exists []protoreflect.FieldDescriptor
+	//atLeastOneOf sets of fields of which at least one field must be set
+	atLeastOneOf [][]protoreflect.FieldDescriptor
+}
+
+// decodeMsgRule decode the validator.message option of a message
+func decodeMsgRule(md protoreflect.MessageDescriptor) (*MsgValidator, error) {
+	x, err := decodeOption(md.Options(), &descriptorpb.MessageOptions{}, E_Message)
+	rule, _ := x.(*MsgValidator)
+	return rule, err
+}
+
+// compileMsgRule resolve the fields of the message-level rules of md, nil if none.
+// Unknown fields are configuration problems, they are left out of the program.
+func compileMsgRule(md protoreflect.MessageDescriptor) (*msgProgram, error) {
+	rule, err := decodeMsgRule(md)
+	if err != nil {
+		return nil, fmt.Errorf("[proto valid]msg[%s] decode rule: %w", md.FullName(), err)
+	}
+	if rule == nil {
+		return nil, nil
+	}
+	mp := &msgProgram{}
+	var errs []error
+	for _, name := range rule.MsgExists {
+		field := md.Fields().ByName(protoreflect.Name(name))
+		if field == nil || field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+			errs = append(errs, fmt.Errorf("[proto valid]msg[%s] msg_exists[%s] is not a singular message field", md.FullName(), name))
+			continue
+		}
+		mp.exists = append(mp.exists, field)
+	}
+	for _, set := range rule.AtLeastOneOf {
+		var fields []protoreflect.FieldDescriptor
+		for _, name := range set.GetFields() {
+			field := md.Fields().ByName(protoreflect.Name(name))
+			if field == nil {
+				errs = append(errs, fmt.Errorf("[proto valid]msg[%s] at_least_one_of field[%s] not found", md.FullName(), name))
+				continue
+			}
+			fields = append(fields, field)
+		}
+		if len(fields) > 0 {
+			mp.atLeastOneOf = append(mp.atLeastOneOf, fields)
+		}
+	}
+	return mp, errors.Join(errs...)
+}
+
+// checkMsgRule check the message-level rules of the message being walked
+func (v *validator) checkMsgRule(mp *msgProgram) error {
+	if mp == nil {
+		return nil
+	}
+	for _, field := range mp.exists {
+		if !v.msg.Has(field) {
+			if err := v.fail(field, "MsgExists", true, nil); err != nil {
+				return err
+			}
+		}
+	}
+	for _, fields := range mp.atLeastOneOf {
+		set := false
+		names := make([]string, len(fields))
+		for i, field := range fields {
+			set = set || v.msg.Has(field)
+			names[i] = string(field.Name())
+		}
+		if !set {
+			//reported on the first field of the set
+			if err := v.fail(fields[0], "AtLeastOneOf", names, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMsgRule(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package msgrule; import "validator.proto";
+message Address { string city = 1; }
+message Contact {
+  option (validator.message) = {at_least_one_of: [{fields: ["email", "phone", "tags"]}]};
+  string email = 1;
+  int64 phone = 2;
+  repeated string tags = 3;
+}
+message Order {
+  option (validator.message) = {msg_exists: ["address"], at_least_one_of: [{fields: ["sku", "contact"]}]};
+  Address address = 1;
+  string sku = 2;
+  Contact contact = 3;
+}`)
+	for _, c := range []struct {
+		name, json string
+		keys       []string
+	}{
+		{"legal", `{"address":{"city":"a"},"sku":"a"}`, nil},
+		{"empty message exists", `{"address":{},"sku":"a"}`, nil},
+		{"missing message", `{"sku":"a"}`, []string{"address:MsgExists"}},
+		{"none of the set", `{"address":{}}`, []string{"sku:AtLeastOneOf"}},
+		{"other field of the set", `{"address":{},"contact":{"email":"a"}}`, nil},
+		{"both", `{}`, []string{"address:MsgExists", "sku:AtLeastOneOf"}},
+		{"nested none of the set", `{"address":{},"contact":{"phone":"0","tags":[]}}`, []string{"contact.email:AtLeastOneOf"}},
+		{"nested scalar", `{"address":{},"contact":{"phone":"1"}}`, nil},
+		{"nested list", `{"address":{},"contact":{"tags":[""]}}`, nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New(WithAggregation(AggregateAll)).Validate(newMsg(t, fd, "Order", c.json))
+			if keys := violationKeys(err); strings.Join(keys, ",") != strings.Join(c.keys, ",") {
+				t.Fatalf("got %v, want %v", keys, c.keys)
+			}
+		})
+	}
+}
+
+func TestMsgRuleConfig(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package msgrule; import "validator.proto";
+message Address { string city = 1; }
+message Order {
+  option (validator.message) = {msg_exists: ["address", "missing", "sku", "history"], at_least_one_of: [{fields: ["sku", "unknown"]}]};
+  Address address = 1;
+  string sku = 2;
+  repeated Address history = 3;
+}`)
+	md := fd.Messages().ByName("Order")
+	err := New().Register(md)
+	for _, want := range []string{"msg_exists[missing] is not a singular message field", "msg_exists[sku] is not a singular message field",
+		"msg_exists[history] is not a singular message field", "at_least_one_of field[unknown] not found"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%v, want %q", err, want)
+		}
+	}
+	//the resolved fields are still enforced
+	v := New(WithAggregation(AggregateAll), WithLogger(&testLogger{}))
+	if keys := violationKeys(v.Validate(newMsg(t, fd, "Order", `{}`))); strings.Join(keys, ",") != "address:MsgExists,sku:AtLeastOneOf" {
+		t.Fatal(keys)
+	}
+	if err := v.Validate(newMsg(t, fd, "Order", `{"address":{},"sku":"a"}`)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,205 @@
+// Package natsvalid NATS message handler decoding and validating subject-mapped proto messages
+package natsvalid
+
+import (
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+	"sync/atomic"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+const (
+	// HeaderError header of a forwarded invalid message holding the validation error
+	HeaderError = "Validation-Error"
+	// HeaderSubject header of a forwarded invalid message holding its original subject
+	HeaderSubject = "Original-Subject"
+)
+
+// Route map a subject to the message type of its payload
+type Route struct {
+	// Subject subject, may contain the * and > wildcards
+	Subject string
+	// Message type of the payload
+	Message protoreflect.MessageType
+}
+
+// Counters handled message counters
+type Counters struct {
+	// Received messages of a mapped subject
+	Received uint64
+	// Valid legal messages passed to the handler
+	Valid uint64
+	// Invalid messages failing the decoding or the validation
+	Invalid uint64
+	// Forwarded invalid messages forwarded to the invalid subject
+	Forwarded uint64
+}
+
+// Handler validating wrapper of NATS message handlers
+type Handler struct {
+	conn           *nats.Conn
+	validator      *validator.Validator
+	routes         []Route
+	invalidSubject string
+	nak            bool
+
+	received  atomic.Uint64
+	valid     atomic.Uint64
+	invalid   atomic.Uint64
+	forwarded atomic.Uint64
+}
+
+// Option handler option
+type Option func(*Handler)
+
+// WithValidator validate with v instead of the default validator
+func WithValidator(v *validator.Validator) Option {
+	return func(h *Handler) {
+		h.validator = v
+	}
+}
+
+// WithInvalidSubject forward invalid messages to subject (e.g. a dead letter subject) instead of terminating them,
+// with the HeaderError and HeaderSubject headers
+func WithInvalidSubject(subject string) Option {
+	return func(h *Handler) {
+		h.invalidSubject = subject
+	}
+}
+
+// WithNak NAK invalid JetStream messages so they are redelivered, instead of terminating them.
+// Terminating is the default since a message failing the validation never becomes valid by itself,
+// NAKing only helps if the rules may change before the redelivery, e.g. while rolling them out.
+func WithNak() Option {
+	return func(h *Handler) {
+		h.nak = true
+	}
+}
+
+// New create a handler, conn is used to forward invalid messages
+func New(conn *nats.Conn, routes []Route, opts ...Option) *Handler {
+	h := &Handler{
+		conn:   conn,
+		routes: routes,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Handle wrap next into a nats.MsgHandler. Messages of mapped subjects are decoded and validated,
+// next receives the legal ones with the decoded message; invalid ones are forwarded, or terminated (see WithNak).
+// Messages of unmapped subjects are passed to next with a nil message.
+func (h *Handler) Handle(next func(msg *nats.Msg, m proto.Message)) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		mt := h.route(msg.Subject)
+		if mt == nil {
+			next(msg, nil)
+			return
+		}
+		h.received.Add(1)
+
+		m := mt.New().Interface()
+		err := proto.Unmarshal(msg.Data, m)
+		if err != nil {
+			err = fmt.Errorf("[proto valid]decode subject[%s] as %s: %w", msg.Subject, mt.Descriptor().FullName(), err)
+		} else if h.validator != nil {
+			err = h.validator.Validate(m)
+		} else {
+			err = validator.Validate(m)
+		}
+		if err != nil {
+			h.invalid.Add(1)
+			h.reject(msg, err)
+			return
+		}
+		h.valid.Add(1)
+		next(msg, m)
+	}
+}
+
+// Counters snapshot of the counters
+func (h *Handler) Counters() Counters {
+	return Counters{
+		Received:  h.received.Load(),
+		Valid:     h.valid.Load(),
+		Invalid:   h.invalid.Load(),
+		Forwarded: h.forwarded.Load(),
+	}
+}
+
+// route message type of a subject, nil if it is not mapped
+func (h *Handler) route(subject string) protoreflect.MessageType {
+	for _, route := range h.routes {
+		if matchSubject(route.Subject, subject) {
+			return route.Message
+		}
+	}
+	return nil
+}
+
+// reject forward an invalid message to the invalid subject, or terminate it (NAK it with WithNak).
+// A message failing to be forwarded is NAKed, so the forwarding is retried on redelivery.
+func (h *Handler) reject(msg *nats.Msg, err error) {
+	if h.invalidSubject != "" && h.conn != nil {
+		out := nats.NewMsg(h.invalidSubject)
+		for key, values := range msg.Header {
+			out.Header[key] = values
+		}
+		out.Header.Set(HeaderError, err.Error())
+		out.Header.Set(HeaderSubject, msg.Subject)
+		out.Data = msg.Data
+		pubErr := h.conn.PublishMsg(out)
+		if pubErr == nil {
+			h.forwarded.Add(1)
+			h.ack(msg, msg.Ack)
+			return
+		}
+		h.logger().Warnf("[pb valid]forward invalid msg of subject[%s] err: %s", msg.Subject, pubErr)
+		h.ack(msg, msg.Nak)
+		return
+	}
+	if h.nak {
+		h.ack(msg, msg.Nak)
+	} else {
+		h.ack(msg, msg.Term)
+	}
+}
+
+// ack acknowledge a JetStream message with ack (i.e. msg.Ack, msg.Nak or msg.Term), core NATS messages are left untouched
+func (h *Handler) ack(msg *nats.Msg, ack func(opts ...nats.AckOpt) error) {
+	if _, err := msg.Metadata(); err != nil {
+		//not delivered by JetStream
+		return
+	}
+	if err := ack(); err != nil {
+		h.logger().Warnf("[pb valid]ack msg of subject[%s] err: %s", msg.Subject, err)
+	}
+}
+
+// logger logger of the validator of the handler
+func (h *Handler) logger() validator.Logger {
+	if h.validator != nil {
+		return h.validator.Logger()
+	}
+	return validator.GetLogger()
+}
+
+// matchSubject whether subject matches pattern with the * and > wildcards
+func matchSubject(pattern, subject string) bool {
+	patterns, tokens := strings.Split(pattern, "."), strings.Split(subject, ".")
+	for i, p := range patterns {
+		if p == ">" {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) || (p != "*" && p != tokens[i]) {
+			return false
+		}
+	}
+	return len(patterns) == len(tokens)
+}
@@ -0,0 +1,221 @@
+package natsvalid
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	validator "github.com/bafflingbug/go-proto-reflect-validators"
+)
+
+// published message published to the fake server
+type published struct {
+	subject string
+	header  string
+	data    string
+}
+
+// fakeServer NATS server speaking enough of the protocol to record the published messages
+type fakeServer struct {
+	net.Listener
+	mu        sync.Mutex
+	published []published
+}
+
+// startServer start a fake server, closed with the test
+func startServer(t *testing.T) *fakeServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{Listener: l}
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// serve answer the pings of a client and record its publications
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"fake\",\"version\":\"2.10.0\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n")
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+		switch args[0] {
+		case "PING":
+			fmt.Fprintf(conn, "PONG\r\n")
+		case "PUB", "HPUB":
+			total, _ := strconv.Atoi(args[len(args)-1])
+			headerLen := 0
+			if args[0] == "HPUB" {
+				headerLen, _ = strconv.Atoi(args[len(args)-2])
+			}
+			payload := make([]byte, total+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.published = append(s.published, published{
+				subject: args[1],
+				header:  string(payload[:headerLen]),
+				data:    string(payload[headerLen:total]),
+			})
+			s.mu.Unlock()
+		}
+	}
+}
+
+// take the messages published since the last call
+func (s *fakeServer) take() []published {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.published
+	s.published = nil
+	return p
+}
+
+// testRoutes routes mapping the subjects of orders.> to google.protobuf.StringValue
+var testRoutes = []Route{{Subject: "orders.>", Message: (&wrapperspb.StringValue{}).ProtoReflect().Type()}}
+
+// testValidator validator limiting strings to 4 bytes
+func testValidator() *validator.Validator {
+	return validator.New(validator.WithDefaultMaxStringBytes(4))
+}
+
+// testMsg message of subject with the payload of a google.protobuf.StringValue, delivered by JetStream if sub is set
+func testMsg(t *testing.T, sub *nats.Subscription, subject, value string) *nats.Msg {
+	t.Helper()
+	data, err := proto.Marshal(wrapperspb.String(value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	if sub != nil {
+		msg.Sub, msg.Reply = sub, "$JS.ACK.ORDERS.consumer.1.2.3.1700000000000000000.0"
+	}
+	return msg
+}
+
+func TestHandle(t *testing.T) {
+	server := startServer(t)
+	nc, err := nats.Connect("nats://" + server.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	sub, err := nc.SubscribeSync("orders.>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name      string
+		opts      []Option
+		jetStream bool
+		subject   string
+		value     string
+		handled   bool
+		published []string
+	}{
+		{"unmapped subject", nil, true, "other", "too long", true, nil},
+		{"legal core message", nil, false, "orders.new", "ok", true, nil},
+		{"invalid core message", nil, false, "orders.new", "too long", false, nil},
+		{"legal", nil, true, "orders.new", "ok", true, nil},
+		{"invalid terminated", nil, true, "orders.new", "too long", false, []string{"$JS.ACK.ORDERS.consumer.1.2.3.1700000000000000000.0 +TERM"}},
+		{"invalid NAKed", []Option{WithNak()}, true, "orders.new", "too long", false, []string{"$JS.ACK.ORDERS.consumer.1.2.3.1700000000000000000.0 -NAK"}},
+		{"invalid forwarded", []Option{WithInvalidSubject("orders.invalid")}, true, "orders.new", "too long", false,
+			[]string{"orders.invalid", "$JS.ACK.ORDERS.consumer.1.2.3.1700000000000000000.0 +ACK"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			h := New(nc, testRoutes, append([]Option{WithValidator(testValidator())}, c.opts...)...)
+			var jsSub *nats.Subscription
+			if c.jetStream {
+				jsSub = sub
+			}
+			handled := false
+			h.Handle(func(msg *nats.Msg, m proto.Message) {
+				handled = true
+			})(testMsg(t, jsSub, c.subject, c.value))
+			if handled != c.handled {
+				t.Fatalf("handled: %v", handled)
+			}
+			if err := nc.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range server.take() {
+				if p.data == "+TERM" || p.data == "-NAK" || p.data == "+ACK" {
+					got = append(got, p.subject+" "+p.data)
+					continue
+				}
+				got = append(got, p.subject)
+				if !strings.Contains(p.header, HeaderError) || !strings.Contains(p.header, HeaderSubject+": "+c.subject) {
+					t.Errorf("forwarded headers %q", p.header)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(c.published) {
+				t.Fatalf("published %v, want %v", got, c.published)
+			}
+		})
+	}
+}
+
+func TestCounters(t *testing.T) {
+	h := New(nil, testRoutes, WithValidator(testValidator()))
+	handle := h.Handle(func(*nats.Msg, proto.Message) {})
+	for _, msg := range []*nats.Msg{
+		testMsg(t, nil, "orders.new", "ok"),
+		testMsg(t, nil, "orders.new", "too long"),
+		{Subject: "orders.new", Data: []byte{0xff}},
+		testMsg(t, nil, "other", "ok"),
+	} {
+		handle(msg)
+	}
+	if got, want := h.Counters(), (Counters{Received: 3, Valid: 1, Invalid: 2}); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMatchSubject(t *testing.T) {
+	for _, c := range []struct {
+		pattern, subject string
+		match            bool
+	}{
+		{"orders.new", "orders.new", true},
+		{"orders.new", "orders.old", false},
+		{"orders.*", "orders.new", true},
+		{"orders.*", "orders.new.eu", false},
+		{"orders.>", "orders.new.eu", true},
+		{"orders.>", "orders", false},
+		{"orders", "orders.new", false},
+	} {
+		if got := matchSubject(c.pattern, c.subject); got != c.match {
+			t.Errorf("matchSubject(%s, %s) = %v", c.pattern, c.subject, got)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// nestedProgram compiled nested rule of a field
+type nestedProgram struct {
+	//fields resolved field_path, nil if it does not resolve
+	fields []protoreflect.FieldDescriptor
+	//rule rule of the last field of the path in scope of the schema version and validation groups, nil if none applies
+	rule *FieldValidator
+}
+
+// compileNested resolve the paths of the nested rules of a field, and of the nested rules of their rules.
+// Paths that don't resolve are reported by checkNestedRule.
+func (p *program) compileNested(v *Validator, field protoreflect.FieldDescriptor, rule *FieldValidator) {
+	for _, nested := range rule.GetNested() {
+		np := &nestedProgram{
+			rule: p.groups.scope(v.scopeRule(nested.GetRule())),
+		}
+		if field.Kind() == protoreflect.MessageKind && !field.IsMap() {
+			np.fields, _ = resolveNestedPath(field.Message(), nested.GetFieldPath())
+		}
+		if p.nested == nil {
+			p.nested = make(map[*NestedRule]*nestedProgram)
+		}
+		p.nested[nested] = np
+		if np.fields != nil {
+			p.compileNested(v, np.fields[len(np.fields)-1], np.rule)
+		}
+	}
+}
+
+// checkNested check the nested rules of a field on its sub-message msg, v walks msg.
+// A nested rule whose field_path does not resolve rejects the sub-message instead of being skipped.
+func (v *validator) checkNested(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	if len(rule.GetNested()) == 0 {
+		return nil
+	}
+	prog := v.nestedProg
+	if prog == nil {
+		prog = v.program(field.ContainingMessage())
+	}
+	for _, nested := range rule.GetNested() {
+		np := prog.nested[nested]
+		if np == nil || np.fields == nil {
+			//reported when compiling the program, fail closed instead of skipping the rule
+			v.warnf("[pb valid]field[%s] nested field_path[%s] does not resolve", field.FullName(), nested.GetFieldPath())
+			v.fault(&Fault{
+				Kind:    FaultConfig,
+				Message: string(field.ContainingMessage().FullName()),
+				Err:     fmt.Errorf("field[%s] nested field_path[%s] does not resolve", field.FullName(), nested.GetFieldPath()),
+			})
+			//v walks the sub-message, report the violation on the field
+			parent := *v
+			parent.path, parent.elem = v.path[:len(v.path)-1], v.path[len(v.path)-1]
+			if err := parent.fail(field, "Nested", nested.GetFieldPath(), "field_path does not resolve"); err != nil {
+				return err
+			}
+			if v.stop() {
+				return nil
+			}
+			continue
+		}
+		if np.rule == nil {
+			continue
+		}
+		sub := *v
+		sub.nested, sub.nestedProg = true, prog
+		sub.errorMessage, sub.humanError = np.rule.GetErrorMessage(), np.rule.GetHumanError()
+		set := true
+		for _, step := range np.fields[:len(np.fields)-1] {
+			if set = sub.msg.Has(step); !set {
+				break
+			}
+			sub.path = appendPath(sub.path, PathElement{Field: step, Index: -1})
+			sub.msg = sub.msg.Get(step).Message()
+		}
+		if !set {
+			continue
+		}
+		leaf := np.fields[len(np.fields)-1]
+		if err := sub.validValue(leaf, sub.msg.Get(leaf), np.rule); err != nil {
+			return err
+		}
+		if v.stop() {
+			return nil
+		}
+	}
+	return nil
+}
+
+// resolveNestedPath resolve the fields of a dot-separated path from md,
+// every field but the last is a singular message field
+func resolveNestedPath(md protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	var fields []protoreflect.FieldDescriptor
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 {
+			prev := fields[i-1]
+			if prev.Kind() != protoreflect.MessageKind || prev.IsList() || prev.IsMap() {
+				return nil, fmt.Errorf("%s is not a singular message field", prev.FullName())
+			}
+			md = prev.Message()
+		}
+		field := md.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("%s has no field %q", md.FullName(), name)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// checkNestedRule check that the paths of the nested rules resolve and the configuration of their rules
+func (v *Validator) checkNestedRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	if len(rule.Nested) == 0 {
+		return nil
+	}
+	if field.Kind() != protoreflect.MessageKind || field.IsMap() {
+		return fmt.Errorf("[proto valid]field[%s] nested rules apply to message fields only", field.FullName())
+	}
+	for _, nested := range rule.Nested {
+		fields, err := resolveNestedPath(field.Message(), nested.GetFieldPath())
+		if err != nil {
+			return fmt.Errorf("[proto valid]field[%s] nested field_path[%s]: %w", field.FullName(), nested.GetFieldPath(), err)
+		}
+		if err := v.checkRule(fields[len(fields)-1], nested.GetRule()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
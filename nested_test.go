@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestNested(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package nested; import "validator.proto";
+message Geo { string country = 1; repeated string tags = 2; }
+message Address { string city = 1; Geo geo = 2; repeated Geo areas = 3; }
+message User {
+  Address address = 1 [(validator.field) = {nested: [
+    {field_path: "city", rule: {string_not_empty: true}},
+    {field_path: "geo.country", rule: {length_eq: 2}},
+    {field_path: "geo", rule: {nested: [{field_path: "tags", rule: {repeated_count_max: 1}}]}}
+  ]}];
+  repeated Address previous = 2 [(validator.field) = {nested: [{field_path: "city", rule: {length_gt: 1}}]}];
+  Address missing = 3 [(validator.field) = {nested: [{field_path: "geo.zip", rule: {length_gt: 1}}]}];
+  Address list_step = 4 [(validator.field) = {nested: [{field_path: "areas.country", rule: {length_gt: 1}}]}];
+}`)
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"legal", `{"address":{"city":"Paris","geo":{"country":"FR"}}}`, "", ""},
+		{"unset field", `{"address":{"geo":{"country":"FR"}}}`, "address.city", "StringNotEmpty"},
+		{"zero field", `{"address":{"city":"","geo":{"country":"FR"}}}`, "address.city", "StringNotEmpty"},
+		{"field path", `{"address":{"city":"Paris","geo":{"country":"FRA"}}}`, "address.geo.country", "LengthEq"},
+		{"unset step", `{"address":{"city":"Paris"}}`, "", ""},
+		{"unset message", `{}`, "", ""},
+		{"nested rule of a nested rule", `{"address":{"city":"Paris","geo":{"country":"FR","tags":["a","b"]}}}`, "address.geo.tags", "RepeatedCountMax"},
+		{"element of a repeated field", `{"address":{"city":"Paris"},"previous":[{"city":"Lyon"},{"city":"X"}]}`, "previous[1].city", "LengthGt"},
+		{"unresolved path", `{"address":{"city":"Paris"},"missing":{}}`, "missing", "Nested"},
+		{"path through a repeated field", `{"address":{"city":"Paris"},"listStep":{}}`, "list_step", "Nested"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "User", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNestedCompileError(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package nested; import "validator.proto";
+message Address { string city = 1; }
+message User { Address address = 1 [(validator.field) = {nested: [{field_path: "zip", rule: {length_gt: 1}}]}]; }`)
+	if err := New().Register(fd.Messages().ByName("User")); err == nil {
+		t.Fatal("want the unresolved path reported")
+	}
+}
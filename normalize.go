@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// Normalizer normalization hook of ValidateNormalized, returns the normalized value of a scalar field
+// or of an element of a repeated scalar field. Map keys and scalar map values are not normalized.
+type Normalizer func(field protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value
+
+// NormalizeTrimSpace trim the leading and trailing white space of strings
+func NormalizeTrimSpace(field protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value {
+	if field.Kind() != protoreflect.StringKind {
+		return value
+	}
+	return protoreflect.ValueOfString(strings.TrimSpace(value.String()))
+}
+
+// WithNormalizer add a normalization hook applied by ValidateNormalized, hooks are applied in the order they are added
+func WithNormalizer(fn Normalizer) Option {
+	return func(v *Validator) {
+		v.normalizers = append(v.normalizers, fn)
+	}
+}
+
+// ValidateNormalized normalize a copy of msg and validate it with the default validator, see Validator.ValidateNormalized
+func ValidateNormalized(msg proto.Message) (proto.Message, error) {
+	return std().ValidateNormalized(msg)
+}
+
+// ValidateNormalized normalize a copy of msg with the hooks of WithNormalizer and validate it,
+// returning the normalized copy with the validation error so handlers use exactly the values that were validated.
+// msg is left untouched.
+func (v *Validator) ValidateNormalized(msg proto.Message) (proto.Message, error) {
+	if msg == nil {
+		return nil, nil
+	}
+	normalized := proto.Clone(msg)
+	v.normalize(normalized.ProtoReflect())
+	return normalized, v.Validate(normalized)
+}
+
+// normalize apply the normalization hooks to the set fields of a message and of its sub-messages
+func (v *Validator) normalize(m protoreflect.Message) {
+	if len(v.normalizers) == 0 || !m.IsValid() {
+		return
+	}
+	m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsMap():
+			if field.MapValue().Kind() == protoreflect.MessageKind {
+				value.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+					v.normalize(item.Message())
+					return true
+				})
+			}
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				if field.Kind() == protoreflect.MessageKind {
+					v.normalize(list.Get(i).Message())
+				} else {
+					list.Set(i, v.normalizeValue(field, list.Get(i)))
+				}
+			}
+		case field.Kind() == protoreflect.MessageKind:
+			v.normalize(value.Message())
+		default:
+			m.Set(field, v.normalizeValue(field, value))
+		}
+		return true
+	})
+}
+
+// normalizeValue apply the normalization hooks to a scalar value
+func (v *Validator) normalizeValue(field protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value {
+	for _, fn := range v.normalizers {
+		value = fn(field, value)
+	}
+	return value
+}
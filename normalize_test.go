@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateNormalized(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package normalize; import "validator.proto";
+message Tag { string name = 1 [(validator.field) = {length_lt: 4}]; }
+message Item {
+  string sku = 1 [(validator.field) = {length_lt: 4}];
+  repeated string labels = 2 [(validator.field) = {length_lt: 4}];
+  map<string, string> attrs = 3 [(validator.field) = {map_key_regex: "^[a-z]+$"}];
+  map<string, Tag> tags = 4;
+  Tag tag = 5;
+  int64 qty = 6;
+}`)
+	for _, c := range []struct {
+		name, json, normalized, path, rule string
+	}{
+		{"singular", `{"sku":" ab "}`, `{"sku":"ab"}`, "", ""},
+		{"singular still too long", `{"sku":" abcd "}`, `{"sku":"abcd"}`, "sku", "LengthLt"},
+		{"repeated", `{"labels":[" a ","b  "]}`, `{"labels":["a","b"]}`, "", ""},
+		{"sub-message", `{"tag":{"name":" ab "}}`, `{"tag":{"name":"ab"}}`, "", ""},
+		{"map message values", `{"tags":{" k ":{"name":" ab "}}}`, `{"tags":{" k ":{"name":"ab"}}}`, "", ""},
+		//map keys and scalar values are validated as sent
+		{"map string values", `{"attrs":{"k":" ab "}}`, `{"attrs":{"k":" ab "}}`, "", ""},
+		{"map string keys", `{"attrs":{" k ":"ab"}}`, `{"attrs":{" k ":"ab"}}`, `attrs[" k "]`, "MapKeyRegex"},
+		{"other kinds", `{"qty":"3"}`, `{"qty":"3"}`, "", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			msg := newMsg(t, fd, "Item", c.json)
+			normalized, err := New(WithNormalizer(NormalizeTrimSpace)).ValidateNormalized(msg)
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+			if want := newMsg(t, fd, "Item", c.normalized); !proto.Equal(normalized, want) {
+				t.Fatalf("got %v, want %v", normalized, want)
+			}
+			//the message itself is left untouched
+			if !proto.Equal(msg, newMsg(t, fd, "Item", c.json)) {
+				t.Fatal(msg)
+			}
+		})
+	}
+}
+
+func TestNormalizerOrder(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package normalize;
+message Item { string sku = 1; }`)
+	upper := func(field protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value {
+		return protoreflect.ValueOfString(strings.ToUpper(value.String()) + ".")
+	}
+	normalized, err := New(WithNormalizer(NormalizeTrimSpace), WithNormalizer(upper)).ValidateNormalized(newMsg(t, fd, "Item", `{"sku":" ab "}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sku := normalized.ProtoReflect().Get(fd.Messages().ByName("Item").Fields().ByName("sku")).String(); sku != "AB." {
+		t.Fatal(sku)
+	}
+	//nil messages and validators without hooks
+	if normalized, err := New().ValidateNormalized(nil); normalized != nil || err != nil {
+		t.Fatal(normalized, err)
+	}
+	msg := newMsg(t, fd, "Item", `{"sku":" ab "}`)
+	if normalized, err := ValidateNormalized(msg); err != nil || !proto.Equal(normalized, msg) {
+		t.Fatal(normalized, err)
+	}
+}
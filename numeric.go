@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"math"
+	"strconv"
+)
+
+// numericStringRules rules applied to the parsed value of a numeric string, by number type
+var numericStringRules = map[NumericString]map[string]bool{
+	NumericString_NUMERIC_STRING_INT: {
+		"IntGt": true, "IntLt": true, "IntGte": true, "IntLte": true, "IntPort": true, "IntPortAllowZero": true,
+	},
+	NumericString_NUMERIC_STRING_FLOAT: {
+		"FloatGt": true, "FloatLt": true, "FloatGte": true, "FloatLte": true, "FloatEpsilon": true,
+	},
+}
+
+// checkNumericString parse a numeric string and check the parsed value with the int or float rules
+func (v *validator) checkNumericString(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	switch rule.GetNumericString() {
+	case NumericString_NUMERIC_STRING_INT:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			//syntax error or overflow
+			return v.fail(field, "NumericString", rule.GetNumericString(), value)
+		}
+		return v.checkInt(field, n, rule)
+	case NumericString_NUMERIC_STRING_FLOAT:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return v.fail(field, "NumericString", rule.GetNumericString(), value)
+		}
+		return v.checkFloat(field, f, rule)
+	}
+	return nil
+}
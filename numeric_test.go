@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"encoding/json"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+)
+
+func TestNumericString(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package numeric; import "validator.proto";
+message Quote {
+  string count = 1 [(validator.field) = {numeric_string: NUMERIC_STRING_INT, int_gte: 1, int_lte: 100}];
+  string price = 2 [(validator.field) = {numeric_string: NUMERIC_STRING_FLOAT, float_gt: 0, float_lt: 10}];
+  string id = 3 [(validator.field) = {numeric_string: NUMERIC_STRING_INT}];
+  string port = 4 [(validator.field) = {numeric_string: NUMERIC_STRING_INT, int_port: true}];
+  string ratio = 5 [(validator.field) = {numeric_string: NUMERIC_STRING_FLOAT, float_lte: 1, float_epsilon: 0.01}];
+  repeated string counts = 6 [(validator.field) = {numeric_string: NUMERIC_STRING_INT, int_gt: 0}];
+}`)
+	for _, c := range []struct {
+		name, field string
+		value       interface{}
+		path, rule  string
+	}{
+		{"legal", "count", "1", "", ""},
+		{"upper bound", "count", "100", "", ""},
+		{"float lower bound", "price", "0.000001", "", ""},
+		{"float upper bound", "price", "9.999", "", ""},
+		{"below minimum", "count", "0", "count", "IntGte"},
+		{"above maximum", "count", "101", "count", "IntLte"},
+		{"negative", "count", "-1", "count", "IntGte"},
+		{"leading plus", "count", "+5", "", ""},
+		{"leading zero", "count", "007", "", ""},
+		{"fraction", "count", "1.0", "count", "NumericString"},
+		{"exponent", "count", "1e2", "count", "NumericString"},
+		{"hexadecimal", "count", "0x10", "count", "NumericString"},
+		{"spaces", "count", " 5", "count", "NumericString"},
+		{"unset", "count", nil, "count", "NumericString"},
+		{"max int64", "id", "9223372036854775807", "", ""},
+		{"min int64", "id", "-9223372036854775808", "", ""},
+		{"int64 overflow", "id", "9223372036854775808", "id", "NumericString"},
+		{"port", "port", "0", "port", "IntPort"},
+		{"float below minimum", "price", "0", "price", "FloatGt"},
+		{"float above maximum", "price", "10", "price", "FloatLt"},
+		{"float exponent", "price", "1e-3", "", ""},
+		{"negative float", "price", "-1.5", "price", "FloatGt"},
+		{"NaN", "price", "NaN", "price", "NumericString"},
+		{"infinity", "price", "Inf", "price", "NumericString"},
+		{"float overflow", "price", "1e309", "price", "NumericString"},
+		{"float syntax", "price", "1,5", "price", "NumericString"},
+		{"within epsilon", "ratio", "1.005", "", ""},
+		{"beyond epsilon", "ratio", "1.02", "ratio", "FloatLte"},
+		{"elements", "counts", []string{"1", "2"}, "", ""},
+		{"illegal element", "counts", []string{"1", "x"}, "counts[1]", "NumericString"},
+		{"element bound", "counts", []string{"0"}, "counts[0]", "IntGt"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			//every other field is legal
+			fields := map[string]interface{}{"count": "1", "price": "1.5", "id": "0", "port": "80", "ratio": "1"}
+			fields[c.field] = c.value
+			if c.value == nil {
+				delete(fields, c.field)
+			}
+			data, err := json.Marshal(fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = New().Validate(newMsg(t, fd, "Quote", string(data)))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNumericStringRuleConfig(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package numeric; import "validator.proto";
+message Int { string a = 1 [(validator.field) = {numeric_string: NUMERIC_STRING_INT, int_gt: 0}]; }
+message Float { string a = 1 [(validator.field) = {numeric_string: NUMERIC_STRING_FLOAT, float_gt: 0}]; }
+message Mismatch { string a = 1 [(validator.field) = {numeric_string: NUMERIC_STRING_INT, float_gt: 0}]; }
+message Plain { string a = 1 [(validator.field) = {int_gt: 0}]; }`)
+	for _, c := range []struct {
+		message string
+		invalid bool
+	}{
+		{"Int", false},
+		{"Float", false},
+		{"Mismatch", true},
+		{"Plain", true},
+	} {
+		err := New(WithStrictTyping()).Register(fd.Messages().ByName(protoreflect.Name(c.message)))
+		if (err != nil) != c.invalid {
+			t.Errorf("%s: %v", c.message, err)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Resolver resolve extension types (e.g. the rule extension) and message types (e.g. google.protobuf.Any payloads)
+type Resolver interface {
+	protoregistry.ExtensionTypeResolver
+	protoregistry.MessageTypeResolver
+}
+
+// Validator proto validator with options
+type Validator struct {
+	resolver              Resolver
+	batchWorkers          int
+	defaultMaxStringBytes int64
+	defaultMaxRepeated    int64
+	progs                 progCache
+	overlays              sync.Map
+	source                RuleSource
+	sourced               sync.Map
+	ruleFilter            RuleFilter
+	schemaVersion         string
+	onViolation           func(err *ValidError)
+	clock                 func() time.Time
+	openEnumPolicy        EnumPolicy
+	auditSink             AuditSink
+	faultReporter         func(fault *Fault)
+	descriptor            protoreflect.MessageDescriptor
+	pathFormat            PathFormat
+	aggregation           Aggregation
+	maxViolations         int
+	collapseSamples       int
+	lenientPanics         bool
+	driftPolicy           DriftPolicy
+	logger                Logger
+	funcs                 map[string]RuleFunc
+	checksums             map[string]ChecksumFunc
+	extractors            map[protoreflect.FullName]*extractor
+	denylists             DenylistSource
+	messages              map[string]map[string]string
+	locale                string
+	budget                time.Duration
+	normalizers           []Normalizer
+	strictTyping          bool
+	trustKey              []byte
+	sampler               Sampler
+	groups                groupSet
+	ruleOrder             RuleOrder
+	decodeViolations      bool
+	statsEnabled          bool
+	stats                 sync.Map
+	methodRules           map[protoreflect.FullName]*MethodValidator
+	methods               sync.Map
+	wires                 sync.Map
+	hasRules              sync.Map
+}
+
+// Option validator option
+type Option func(*Validator)
+
+// New create a validator
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		resolver: protoregistry.GlobalTypes,
+		clock:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// defaultValidator default validator used by the package level functions, see SetDefault
+var defaultValidator atomic.Pointer[Validator]
+
+func init() {
+	defaultValidator.Store(New())
+}
+
+// std default validator used by the package level functions
+func std() *Validator {
+	return defaultValidator.Load()
+}
+
+// Default get the validator used by the package level functions
+func Default() *Validator {
+	return std()
+}
+
+// SetDefault replace the validator used by the package level functions, e.g. with hot-reloaded options.
+// Validations in flight keep the validator they started with. nil restores a validator without options.
+func SetDefault(v *Validator) {
+	if v == nil {
+		v = New()
+	}
+	defaultValidator.Store(v)
+}
+
+// WithResolver resolve rule extensions and Any payload types from resolver instead of protoregistry.GlobalTypes
+func WithResolver(resolver Resolver) Option {
+	return func(v *Validator) {
+		v.resolver = resolver
+	}
+}
+
+// WithBatchWorkers validate batches with n goroutines, n <= 1 validates sequentially
+func WithBatchWorkers(n int) Option {
+	return func(v *Validator) {
+		v.batchWorkers = n
+	}
+}
+
+// WithDefaultMaxStringBytes limit the byte length of string fields without an explicit length_lt or length_eq rule,
+// as a safety net against unbounded client input
+func WithDefaultMaxStringBytes(n int64) Option {
+	return func(v *Validator) {
+		v.defaultMaxStringBytes = n
+	}
+}
+
+// WithDefaultMaxRepeated limit the element count of repeated and map fields without an explicit repeated_count_max rule,
+// as a safety net against unbounded client input
+func WithDefaultMaxRepeated(n int64) Option {
+	return func(v *Validator) {
+		v.defaultMaxRepeated = n
+	}
+}
+
+// RuleFilter decide whether a rule of a field is enabled, ruleKind is the rule name used in ValidError (e.g. "Regex").
+// It allows dark-launching or emergency-disabling a rule through a feature-flag system.
+type RuleFilter func(field protoreflect.FieldDescriptor, ruleKind string) bool
+
+// WithRuleFilter evaluate filter per failed rule, a disabled rule never fails the validation
+func WithRuleFilter(filter RuleFilter) Option {
+	return func(v *Validator) {
+		v.ruleFilter = filter
+	}
+}
+
+// WithOnViolation call hook on every violation, including the ones of shadow rules (see ValidError.Shadow),
+// e.g. to feed logs or metrics
+func WithOnViolation(hook func(err *ValidError)) Option {
+	return func(v *Validator) {
+		v.onViolation = hook
+	}
+}
+
+// WithStrictTyping report rules attached to fields of a kind they don't apply to (e.g. int_gt on a string field)
+// as configuration problems, returned by Register and reported as faults on first use, instead of ignoring them
+func WithStrictTyping() Option {
+	return func(v *Validator) {
+		v.strictTyping = true
+	}
+}
+
+// WithClock read the current time from clock in time-based rules instead of time.Now,
+// e.g. to freeze time in tests
+func WithClock(clock func() time.Time) Option {
+	return func(v *Validator) {
+		v.clock = clock
+	}
+}
+
+// now current time of time-based rules
+func (v *Validator) now() time.Time {
+	return v.clock()
+}
+
+// WithRuleDecodeViolations report a field whose rule extension can't be decoded (e.g. corrupt options,
+// or the extension unknown to the resolver) as a "RuleDecode" violation instead of validating it without rules,
+// so broken descriptor plumbing is detected instead of silently disabling validation.
+// The decode error is reported as a configuration fault either way.
+func WithRuleDecodeViolations() Option {
+	return func(v *Validator) {
+		v.decodeViolations = true
+	}
+}
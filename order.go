@@ -0,0 +1,198 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sort"
+)
+
+// RuleOrder order in which the fields of a message are validated, fields of higher priority always come first
+type RuleOrder int
+
+const (
+	// OrderDeclaration validate fields in declaration order (default)
+	OrderDeclaration RuleOrder = iota
+	// OrderCost validate fields with cheap rules (constant checks) before fields with expensive ones
+	// (IP parsing, regex, Go functions, sub-messages), and the cheap rules of a field before its expensive ones,
+	// to fail fast on hot paths when violations are not aggregated
+	OrderCost
+)
+
+// String implement fmt.Stringer
+func (o RuleOrder) String() string {
+	switch o {
+	case OrderDeclaration:
+		return "declaration"
+	case OrderCost:
+		return "cost"
+	}
+	return fmt.Sprintf("RuleOrder(%d)", int(o))
+}
+
+// WithRuleOrder validate the fields of a message in order, applied when the programs are compiled
+func WithRuleOrder(order RuleOrder) Option {
+	return func(v *Validator) {
+		v.ruleOrder = order
+	}
+}
+
+// sortFields sort the field programs by priority, then by cost if ordered by cost, keeping the declaration order otherwise
+func (v *Validator) sortFields(fields []*fieldProgram) {
+	sort.SliceStable(fields, func(i, j int) bool {
+		pi, pj := fields[i].rule.GetPriority(), fields[j].rule.GetPriority()
+		if pi != pj {
+			return pi > pj
+		}
+		return v.ruleOrder == OrderCost && fieldCost(fields[i]) < fieldCost(fields[j])
+	})
+}
+
+// ruleCosts estimated relative cost of the checks of each rule, by field name of FieldValidator.
+// Options that don't check a value by themselves (e.g. error_message, groups) cost 0.
+var ruleCosts = map[protoreflect.Name]int{
+	"regex":                     10,
+	"int_gt":                    1,
+	"int_lt":                    1,
+	"human_error":               0,
+	"float_gt":                  1,
+	"float_lt":                  1,
+	"float_epsilon":             0,
+	"float_gte":                 1,
+	"float_lte":                 1,
+	"string_not_empty":          1,
+	"repeated_count_min":        1,
+	"repeated_count_max":        1,
+	"length_gt":                 1,
+	"length_lt":                 1,
+	"length_eq":                 1,
+	"is_in_enum":                1,
+	"ip":                        5,
+	"ipv4":                      5,
+	"ipv6":                      5,
+	"ip_private":                5,
+	"ip_public":                 5,
+	"int_port":                  1,
+	"int_port_allow_zero":       1,
+	"since_version":             0,
+	"until_version":             0,
+	"versioned":                 0,
+	"shadow":                    0,
+	"field_mask":                5,
+	"field_mask_target":         5,
+	"enum_in":                   1,
+	"enum_not_in":               1,
+	"go_func":                   20,
+	"length_unit":               0,
+	"repeated_unique_by":        5,
+	"pre_validated":             0,
+	"groups":                    0,
+	"priority":                  0,
+	"hash_format":               8,
+	"string_time_of_day":        3,
+	"string_day_of_week":        2,
+	"numeric_string":            3,
+	"string_checksum":           3,
+	"repeated_monotonic":        2,
+	"string_postal_code":        10,
+	"postal_code_country_field": 0,
+	"map_key_regex":             10,
+	"map_key_in":                1,
+	"nested":                    10,
+	"msg_max_bytes":             8,
+	"string_not_similar":        15,
+	"error_message":             0,
+	"uint_gt":                   1,
+	"uint_lt":                   1,
+	"uint_gte":                  1,
+	"uint_lte":                  1,
+	"int_gte":                   1,
+	"int_lte":                   1,
+	"money":                     4,
+	"lat_lng":                   2,
+	"date":                      4,
+	"time_of_day":               3,
+	"string_prefix":             1,
+	"string_suffix":             1,
+	"string_contains":           2,
+	"string_not_contains":       2,
+}
+
+// ruleCost estimated relative cost of the checks of rules
+func ruleCost(rules ...protoreflect.Name) int {
+	cost := 0
+	for _, name := range rules {
+		cost += ruleCosts[name]
+	}
+	return cost
+}
+
+// ruleCheck check of a value of type T by some rules of its field, with the estimated cost of the rules
+type ruleCheck[T any] struct {
+	cost  int
+	check func(v *validator, field protoreflect.FieldDescriptor, value T, rule *FieldValidator) error
+}
+
+// stringChecks checks of a string in declaration order
+var stringChecks = []ruleCheck[string]{
+	{ruleCost("string_not_empty"), (*validator).checkStringNotEmpty},
+	{ruleCost("length_gt", "length_lt", "length_eq"), (*validator).checkStringLength},
+	{ruleCost("regex"), (*validator).checkStringRegex},
+	{ruleCost("string_prefix", "string_suffix", "string_contains", "string_not_contains"), (*validator).checkStringAffix},
+	{ruleCost("ip", "ipv4", "ipv6", "ip_private", "ip_public"), (*validator).checkIP},
+	{ruleCost("string_time_of_day", "string_day_of_week"), (*validator).checkSchedule},
+	{ruleCost("numeric_string"), (*validator).checkNumericString},
+	{ruleCost("string_checksum"), (*validator).checkChecksum},
+	{ruleCost("string_postal_code"), (*validator).checkPostalCode},
+	{ruleCost("string_not_similar"), (*validator).checkSimilar},
+	{ruleCost("money"), (*validator).checkDecimal},
+	{ruleCost("hash_format"), (*validator).checkHash},
+}
+
+// messageChecks checks of a sub-message with the rules of its field in declaration order
+var messageChecks = []ruleCheck[protoreflect.Message]{
+	{ruleCost("field_mask", "field_mask_target"), (*validator).checkFieldMask},
+	//estimated as a regex, the extracted values are checked with the rules of the extractor
+	{ruleCost("regex"), (*validator).checkExtracted},
+	{ruleCost("money"), (*validator).checkMoney},
+	{ruleCost("lat_lng"), (*validator).checkLatLng},
+	{ruleCost("date"), (*validator).checkDate},
+	{ruleCost("time_of_day"), (*validator).checkTimeOfDay},
+	{ruleCost("msg_max_bytes"), (*validator).checkMsgSize},
+}
+
+// stringChecksByCost stringChecks by cost, for OrderCost
+var stringChecksByCost = sortChecks(stringChecks)
+
+// messageChecksByCost messageChecks by cost, for OrderCost
+var messageChecksByCost = sortChecks(messageChecks)
+
+// sortChecks copy of checks sorted by cost, keeping the declaration order of checks of the same cost
+func sortChecks[T any](checks []ruleCheck[T]) []ruleCheck[T] {
+	sorted := append([]ruleCheck[T](nil), checks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].cost < sorted[j].cost
+	})
+	return sorted
+}
+
+// fieldCost estimated relative cost of validating a field
+func fieldCost(fp *fieldProgram) int {
+	cost := 1
+	if rule := fp.rule; rule != nil {
+		rule.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			cost += ruleCosts[field.Name()]
+			return true
+		})
+		if rule.GetLengthUnit() == LengthUnit_LENGTH_UNIT_GRAPHEMES {
+			cost += 10
+		}
+	}
+	if hasMessage(fp.field) {
+		//the sub-message is walked
+		cost += 50
+	}
+	if fp.field.IsList() || fp.field.IsMap() {
+		cost *= 4
+	}
+	return cost
+}
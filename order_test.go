@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestRuleCosts(t *testing.T) {
+	fields := (&FieldValidator{}).ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if _, ok := ruleCosts[fields.Get(i).Name()]; !ok {
+			t.Errorf("rule %s has no cost", fields.Get(i).Name())
+		}
+	}
+	if len(ruleCosts) != fields.Len() {
+		t.Errorf("%d costs for %d rules", len(ruleCosts), fields.Len())
+	}
+}
+
+func TestRuleOrder(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package order; import "validator.proto";
+message Fields {
+  string slow = 1 [(validator.field) = {regex: "^a"}];
+  string fast = 2 [(validator.field) = {length_gt: 3}];
+}
+message Rules {
+  string code = 1 [(validator.field) = {regex: "^a", string_prefix: "b"}];
+}`)
+	for _, c := range []struct {
+		name, msg, json string
+		order           RuleOrder
+		path, rule      string
+	}{
+		{"fields by declaration", "Fields", `{"slow":"x","fast":"x"}`, OrderDeclaration, "slow", "Regex"},
+		{"fields by cost", "Fields", `{"slow":"x","fast":"x"}`, OrderCost, "fast", "LengthGt"},
+		{"rules by declaration", "Rules", `{"code":"x"}`, OrderDeclaration, "code", "Regex"},
+		{"rules by cost", "Rules", `{"code":"x"}`, OrderCost, "code", "StringPrefix"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New(WithRuleOrder(c.order)).Validate(newMsg(t, fd, c.msg, c.json))
+			if !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
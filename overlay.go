@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// overlay rule overlay with its compiled programs
+type overlay struct {
+	rules *RuleSet
+	progs progCache
+}
+
+// ErrOverlayNotFound the rule overlay selected by the context of a validation is not registered
+var ErrOverlayNotFound = errors.New("[proto valid]overlay not found")
+
+// overlayKey context key of the selected overlay name
+type overlayKey struct{}
+
+// WithOverlay register a rule overlay, selected per call by its name with ContextWithOverlay
+func WithOverlay(rules *RuleSet) Option {
+	return func(v *Validator) {
+		v.SetOverlay(rules)
+	}
+}
+
+// SetOverlay add or replace the rule overlay of the same name
+func (v *Validator) SetOverlay(rules *RuleSet) {
+	v.overlays.Store(rules.GetName(), &overlay{
+		rules: rules,
+	})
+}
+
+// RemoveOverlay remove the rule overlay named name
+func (v *Validator) RemoveOverlay(name string) {
+	v.overlays.Delete(name)
+}
+
+// getOverlay get the rule overlay named name, nil if not registered
+func (v *Validator) getOverlay(name string) *overlay {
+	if x, ok := v.overlays.Load(name); ok {
+		return x.(*overlay)
+	}
+	return nil
+}
+
+// ContextWithOverlay select the rule overlay named name for the validations using ctx
+func ContextWithOverlay(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, overlayKey{}, name)
+}
+
+// OverlayFromContext get the rule overlay name selected by ctx
+func OverlayFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(overlayKey{}).(string)
+	return name, ok
+}
+
+// ValidateContext verify whether a proto message is legal with the default validator,
+// applying the rule overlay selected by ctx
+func ValidateContext(ctx context.Context, msg proto.Message) error {
+	return std().ValidateContext(ctx, msg)
+}
+
+// ValidateContext verify whether a proto message is legal, applying the rule overlay selected by ctx,
+// or else the rule set of the rule source if configured.
+// An overlay which is not registered fails the validation with an *InternalValidationError wrapping ErrOverlayNotFound,
+// instead of validating with the annotated rules only, since the overlay may tighten them.
+func (v *Validator) ValidateContext(ctx context.Context, msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	w := &validator{
+		Validator: v,
+		msg:       msg.ProtoReflect(),
+		ctx:       ctx,
+	}
+	if name, ok := OverlayFromContext(ctx); ok {
+		if w.overlay = v.getOverlay(name); w.overlay == nil {
+			if isNilMessage(w.msg) {
+				return nil
+			}
+			v.warnf("[pb valid]overlay[%s] not found", name)
+			fault := &Fault{Kind: FaultConfig, Message: messageName(w.msg), Err: fmt.Errorf("%w: %s", ErrOverlayNotFound, name)}
+			v.fault(fault)
+			return &InternalValidationError{Fault: fault}
+		}
+	} else if v.source != nil {
+		w.overlay = v.sourceOverlay(ctx, string(w.msg.Descriptor().FullName()))
+	}
+	return v.run(w)
+}
+
+// program get the compiled program of a message, with the rules of the selected overlay
+func (v *validator) program(md protoreflect.MessageDescriptor) *program {
+	if v.overlay != nil {
+		return v.overlay.progs.Get(md, v.Validator, v.overlay.rules, v.groups)
+	}
+	return v.progs.Get(md, v.Validator, nil, v.groups)
+}
+
+// overlayRule merge an overlaid rule onto the annotated rule, fields set in the overlay win.
+// Repeated fields set in the overlay (e.g. enum_in) replace the annotated ones instead of being appended to them,
+// so an overlay can tighten a set of allowed values.
+func overlayRule(rule, overlaid *FieldValidator) *FieldValidator {
+	if overlaid == nil {
+		return rule
+	}
+	if rule == nil {
+		return overlaid
+	}
+	merged := proto.Clone(rule).(*FieldValidator)
+	clearOverlaid(merged.ProtoReflect(), overlaid.ProtoReflect())
+	proto.Merge(merged, overlaid)
+	return merged
+}
+
+// clearOverlaid clear the repeated and map fields of dst set in src, including in the sub-messages set in both
+func clearOverlaid(dst, src protoreflect.Message) {
+	src.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsList() || field.IsMap():
+			dst.Clear(field)
+		case field.Message() != nil && dst.Has(field):
+			clearOverlaid(dst.Mutable(field).Message(), value.Message())
+		}
+		return true
+	})
+}
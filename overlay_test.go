@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestOverlay(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package overlay; import "validator.proto"; import "google/type/types.proto";
+enum Plan { PLAN_UNSPECIFIED = 0; PLAN_FREE = 1; PLAN_PRO = 2; PLAN_ENTERPRISE = 3; }
+message Account {
+  Plan plan = 1 [(validator.field) = {enum_in: [1, 2, 3]}];
+  string name = 2 [(validator.field) = {length_gt: 1, length_lt: 10}];
+  google.type.Money price = 3 [(validator.field) = {money: {currency_in: ["EUR", "USD"], max: "100"}}];
+}`)
+	lt := int64(20)
+	v := New(
+		WithOverlay(&RuleSet{
+			Name: proto.String("free"),
+			Messages: map[string]*MessageRules{"overlay.Account": {Fields: map[string]*FieldValidator{
+				"plan":  {EnumIn: []int32{1}},
+				"price": {Money: &MoneyRule{CurrencyIn: []string{"EUR"}}},
+			}}},
+		}),
+		WithOverlay(&RuleSet{
+			Name: proto.String("relaxed"),
+			Messages: map[string]*MessageRules{"overlay.Account": {Fields: map[string]*FieldValidator{
+				"name": {LengthLt: &lt},
+			}}},
+		}),
+	)
+	for _, c := range []struct {
+		name, overlay, json, path, rule string
+	}{
+		{"annotated", "", `{"plan":"PLAN_PRO","name":"ab","price":{"currencyCode":"USD","units":"1"}}`, "", ""},
+		{"tightened enum_in", "free", `{"plan":"PLAN_PRO","name":"ab"}`, "plan", "EnumIn"},
+		{"tightened enum_in legal", "free", `{"plan":"PLAN_FREE","name":"ab"}`, "", ""},
+		{"tightened nested list", "free", `{"plan":"PLAN_FREE","name":"ab","price":{"currencyCode":"USD","units":"1"}}`, "price", "MoneyCurrency"},
+		{"annotated field of the nested rule kept", "free", `{"plan":"PLAN_FREE","name":"ab","price":{"currencyCode":"EUR","units":"101"}}`, "price", "MoneyMax"},
+		{"annotated rule of the field kept", "free", `{"plan":"PLAN_FREE","name":"a"}`, "name", "LengthGt"},
+		{"relaxed", "relaxed", `{"plan":"PLAN_PRO","name":"longer name"}`, "", ""},
+		{"relaxed bound", "relaxed", `{"plan":"PLAN_PRO","name":"much longer name than 20"}`, "name", "LengthLt"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.overlay != "" {
+				ctx = ContextWithOverlay(ctx, c.overlay)
+			}
+			err := v.ValidateContext(ctx, newMsg(t, fd, "Account", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	//a missing overlay fails closed instead of validating with the annotated rules only
+	var faults []*Fault
+	v = New(WithFaultReporter(func(fault *Fault) { faults = append(faults, fault) }))
+	err := v.ValidateContext(ContextWithOverlay(context.Background(), "free"), newMsg(t, fd, "Account", `{"plan":"PLAN_PRO","name":"ab"}`))
+	var ierr *InternalValidationError
+	if !errors.As(err, &ierr) || !errors.Is(err, ErrOverlayNotFound) {
+		t.Fatalf("missing overlay: %v", err)
+	}
+	if len(faults) != 1 || faults[0].Kind != FaultConfig {
+		t.Fatalf("faults: %v", faults)
+	}
+}
+
+func TestOverlayRule(t *testing.T) {
+	gt, lt := int64(1), int64(5)
+	rule := &FieldValidator{LengthGt: &gt, EnumIn: []int32{1, 2}, Groups: []string{"create"}}
+	merged := overlayRule(rule, &FieldValidator{LengthLt: &lt, EnumIn: []int32{1}})
+	want := &FieldValidator{LengthGt: &gt, LengthLt: &lt, EnumIn: []int32{1}, Groups: []string{"create"}}
+	if !proto.Equal(merged, want) {
+		t.Fatalf("got %v, want %v", merged, want)
+	}
+	if len(rule.EnumIn) != 2 || rule.LengthLt != nil {
+		t.Fatalf("annotated rule modified: %v", rule)
+	}
+}
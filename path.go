@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strconv"
+	"strings"
+)
+
+// PathFormat format of violation paths
+type PathFormat int
+
+const (
+	// PathDotted proto field names with indexes and map keys, e.g. a.b[2].c or a.m["k"]
+	PathDotted PathFormat = iota
+	// PathJSONPointer RFC 6901 JSON Pointer with JSON field names, e.g. /a/b/2/c
+	PathJSONPointer
+	// PathFieldMask google.protobuf.FieldMask style proto field names without indexes and map keys, e.g. a.b.c
+	PathFieldMask
+)
+
+// String implement fmt.Stringer
+func (f PathFormat) String() string {
+	switch f {
+	case PathDotted:
+		return "dotted"
+	case PathJSONPointer:
+		return "json pointer"
+	case PathFieldMask:
+		return "field mask"
+	}
+	return fmt.Sprintf("PathFormat(%d)", int(f))
+}
+
+// WithPathFormat format violation paths in ValidError.Error and its JSON output with format
+func WithPathFormat(format PathFormat) Option {
+	return func(v *Validator) {
+		v.pathFormat = format
+	}
+}
+
+// PathElement step of a violation path from the validated message
+type PathElement struct {
+	// Field field of the step, the map field for map entries
+	Field protoreflect.FieldDescriptor
+	// Index element index of a repeated field, -1 if none
+	Index int
+	// Key key of a map entry, invalid if none
+	Key protoreflect.MapKey
+}
+
+// formatPath format a path
+func formatPath(path []PathElement, format PathFormat) string {
+	var b strings.Builder
+	for i, elem := range path {
+		switch format {
+		case PathJSONPointer:
+			b.WriteByte('/')
+			b.WriteString(escapePointer(elem.Field.JSONName()))
+			if elem.Index >= 0 {
+				b.WriteByte('/')
+				b.WriteString(strconv.Itoa(elem.Index))
+			} else if elem.Key.IsValid() {
+				b.WriteByte('/')
+				b.WriteString(escapePointer(elem.Key.String()))
+			}
+		default:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(string(elem.Field.Name()))
+			if format == PathFieldMask {
+				continue
+			}
+			if elem.Index >= 0 {
+				fmt.Fprintf(&b, "[%d]", elem.Index)
+			} else if elem.Key.IsValid() {
+				if s, ok := elem.Key.Interface().(string); ok {
+					fmt.Fprintf(&b, "[%q]", s)
+				} else {
+					fmt.Fprintf(&b, "[%v]", elem.Key.Interface())
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// escapePointer escape a JSON Pointer reference token
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// step path element of a field of the walked message, the current element if checking one
+func (v *validator) step(field protoreflect.FieldDescriptor) PathElement {
+	if v.elem.Field != nil {
+		return v.elem
+	}
+	return PathElement{Field: field, Index: -1}
+}
+
+// appendPath append an element to a copy of path
+func appendPath(path []PathElement, elem PathElement) []PathElement {
+	out := make([]PathElement, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, elem)
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPathFormat(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package path; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 1}]; }
+message Order {
+  string order_id = 1 [(validator.field) = {length_gt: 1}];
+  Item main_item = 2;
+  repeated Item line_items = 3;
+  map<string, Item> by_key = 4;
+  map<int32, Item> by_id = 5;
+  map<bool, Item> by_flag = 6;
+  map<string, int32> counts = 7 [(validator.field) = {regex: "^[a-z]$"}];
+}`)
+	for _, c := range []struct {
+		name, json                 string
+		dotted, pointer, fieldMask string
+	}{
+		{"field", `{"orderId":"a"}`, "order_id", "/orderId", "order_id"},
+		{"sub-message", `{"orderId":"ab","mainItem":{"sku":"a"}}`, "main_item.sku", "/mainItem/sku", "main_item.sku"},
+		{"first element", `{"orderId":"ab","lineItems":[{"sku":"a"}]}`, "line_items[0].sku", "/lineItems/0/sku", "line_items.sku"},
+		{"later element", `{"orderId":"ab","lineItems":[{"sku":"ab"},{"sku":"ab"},{"sku":"a"}]}`, "line_items[2].sku", "/lineItems/2/sku", "line_items.sku"},
+		{"string key", `{"orderId":"ab","byKey":{"k":{"sku":"a"}}}`, `by_key["k"].sku`, "/byKey/k/sku", "by_key.sku"},
+		{"escaped string key", `{"orderId":"ab","byKey":{"a/b~\"c":{"sku":"a"}}}`, `by_key["a/b~\"c"].sku`, `/byKey/a~1b~0"c/sku`, "by_key.sku"},
+		{"empty string key", `{"orderId":"ab","byKey":{"":{"sku":"a"}}}`, `by_key[""].sku`, "/byKey//sku", "by_key.sku"},
+		{"integer key", `{"orderId":"ab","byId":{"-7":{"sku":"a"}}}`, "by_id[-7].sku", "/byId/-7/sku", "by_id.sku"},
+		{"bool key", `{"orderId":"ab","byFlag":{"true":{"sku":"a"}}}`, "by_flag[true].sku", "/byFlag/true/sku", "by_flag.sku"},
+		{"map key rule", `{"orderId":"ab","counts":{"AB":1}}`, `counts["AB"]`, "/counts/AB", "counts"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			msg := newMsg(t, fd, "Order", c.json)
+			for format, path := range map[PathFormat]string{PathDotted: c.dotted, PathJSONPointer: c.pointer, PathFieldMask: c.fieldMask} {
+				err := New(WithPathFormat(format)).Validate(msg)
+				var violation *ValidError
+				if !errors.As(err, &violation) || violation.Path() != path || violation.FormatPath(PathDotted) != c.dotted {
+					t.Fatalf("%s: %v", format, err)
+				}
+				if !strings.Contains(violation.Error(), "field["+path+" ") {
+					t.Fatalf("%s: %v", format, err)
+				}
+				data, jsonErr := json.Marshal(violation)
+				var out struct{ Path string }
+				if jsonErr != nil || json.Unmarshal(data, &out) != nil || out.Path != path {
+					t.Fatalf("%s: %s", format, data)
+				}
+				//the call option overrides the format of the validator
+				err = New(WithPathFormat(PathFieldMask)).ValidateContext(ContextWithCallOptions(context.Background(), CallPathFormat(format)), msg)
+				if !errors.As(err, &violation) || violation.Path() != path {
+					t.Fatalf("%s call: %v", format, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPathFormatString(t *testing.T) {
+	for format, s := range map[PathFormat]string{PathDotted: "dotted", PathJSONPointer: "json pointer", PathFieldMask: "field mask", 7: "PathFormat(7)"} {
+		if format.String() != s {
+			t.Errorf("%d: %s", int(format), format)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"regexp"
+	"strings"
+)
+
+// postalCodes postal code formats by ISO 3166-1 alpha-2 country code, letters are matched in any case
+var postalCodes = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^\d{4}$`),
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+	"CA": regexp.MustCompile(`(?i)^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d$`),
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"CN": regexp.MustCompile(`^\d{6}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"ES": regexp.MustCompile(`^(0[1-9]|[1-4]\d|5[0-2])\d{3}$`),
+	"FI": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"GB": regexp.MustCompile(`(?i)^(GIR ?0AA|[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2})$`),
+	"IE": regexp.MustCompile(`(?i)^([AC-FHKNPRTV-Y]\d{2}|D6W) ?[0-9AC-FHKNPRTV-Y]{4}$`),
+	"IN": regexp.MustCompile(`^[1-9]\d{2} ?\d{3}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"KR": regexp.MustCompile(`^\d{5}$`),
+	"MX": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`(?i)^[1-9]\d{3} ?[A-Z]{2}$`),
+	"NO": regexp.MustCompile(`^\d{4}$`),
+	"NZ": regexp.MustCompile(`^\d{4}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"RU": regexp.MustCompile(`^\d{6}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"SG": regexp.MustCompile(`^\d{6}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+}
+
+// checkPostalCode check a postal code against the format of its country
+func (v *validator) checkPostalCode(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.StringPostalCode == nil && rule.PostalCodeCountryField == nil {
+		return nil
+	}
+	country := v.postalCodeCountry(field, rule)
+	exp := postalCodes[strings.ToUpper(country)]
+	if exp == nil {
+		return nil
+	}
+	if !exp.MatchString(value) {
+		return v.fail(field, "StringPostalCode", country, value)
+	}
+	return nil
+}
+
+// postalCodeCountry country of a postal code, read from the postal_code_country_field sibling if set
+func (v *validator) postalCodeCountry(field protoreflect.FieldDescriptor, rule *FieldValidator) string {
+	if rule.PostalCodeCountryField != nil && v.msg != nil {
+		if sibling := postalCodeCountryField(field, *rule.PostalCodeCountryField); sibling != nil && v.msg.Has(sibling) {
+			return v.msg.Get(sibling).String()
+		}
+	}
+	return rule.GetStringPostalCode()
+}
+
+// postalCodeCountryChanged whether the postal_code_country_field sibling of field changed since v.old
+func (v *validator) postalCodeCountryChanged(field protoreflect.FieldDescriptor, rule *FieldValidator) bool {
+	if rule.GetPostalCodeCountryField() == "" {
+		return false
+	}
+	sibling := postalCodeCountryField(field, rule.GetPostalCodeCountryField())
+	return sibling != nil && v.old.Get(sibling).String() != v.msg.Get(sibling).String()
+}
+
+// postalCodeCountryField the singular string field named name of the message of field, nil if none
+func postalCodeCountryField(field protoreflect.FieldDescriptor, name string) protoreflect.FieldDescriptor {
+	sibling := field.ContainingMessage().Fields().ByName(protoreflect.Name(name))
+	if sibling == nil || sibling.IsList() || sibling.IsMap() || sibling.Kind() != protoreflect.StringKind {
+		return nil
+	}
+	return sibling
+}
+
+// checkPostalCodeRule check that postal_code_country_field names a singular string field of the message
+func checkPostalCodeRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	if rule.PostalCodeCountryField == nil || field.ContainingMessage() == nil {
+		return nil
+	}
+	if postalCodeCountryField(field, *rule.PostalCodeCountryField) == nil {
+		return fmt.Errorf("[proto valid]field[%s] postal_code_country_field[%s] is not a singular string field of %s",
+			field.FullName(), *rule.PostalCodeCountryField, field.ContainingMessage().FullName())
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sync"
+)
+
+// anyFullName full name of google.protobuf.Any
+const anyFullName protoreflect.FullName = "google.protobuf.Any"
+
+// program compiled verification rules of a message
+type program struct {
+	desc protoreflect.MessageDescriptor
+	//groups validation groups the program is compiled for
+	groups groupSet
+	fields []*fieldProgram
+	//message message-level rules, nil if none
+	message *msgProgram
+	//nested compiled nested rules of the fields, including the nested rules of nested rules
+	nested map[*NestedRule]*nestedProgram
+}
+
+// fieldProgram compiled verification rules of a field
+type fieldProgram struct {
+	field protoreflect.FieldDescriptor
+	rule  *FieldValidator
+	//shadow rule evaluated in shadow mode, may be nil
+	shadow *FieldValidator
+	//decodeErr error decoding the rule, reported as a violation, see WithRuleDecodeViolations
+	decodeErr error
+}
+
+// compile extract the verification rules of a message.
+// Fields without rules are kept only if they may hold sub-messages.
+// Rules of inactive validation groups are dropped.
+// The returned program is usable even if configuration problems are reported.
+func compile(md protoreflect.MessageDescriptor, v *Validator, rules *RuleSet, groups groupSet) (*program, error) {
+	prog := &program{
+		desc:   md,
+		groups: groups,
+	}
+	overlaid := rules.GetMessages()[string(md.FullName())].GetFields()
+	errs := []error{v.checkDrift(md, rules)}
+	var err error
+	if prog.message, err = compileMsgRule(md); err != nil {
+		errs = append(errs, err)
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		annotated, decodeErr := loadRule(field, v.resolver)
+		if decodeErr != nil {
+			errs = append(errs, decodeErr)
+		}
+		rule := v.scopeRule(overlayRule(annotated, overlaid[string(field.Name())]))
+		var shadow *FieldValidator
+		if rules.GetShadow() && overlaid[string(field.Name())] != nil {
+			//enforce the annotated rule, shadow the overlaid one
+			rule, shadow = v.scopeRule(annotated), rule
+		}
+		if rule.GetShadow() {
+			rule, shadow = nil, rule
+		}
+		rule, shadow = groups.scope(rule), groups.scope(shadow)
+		if !v.decodeViolations {
+			decodeErr = nil
+		}
+		if rule == nil && shadow == nil && decodeErr == nil && !hasMessage(field) && !v.hasDefaults(field) && !v.hasOpenEnumPolicy(field) {
+			continue
+		}
+		if err := v.checkRule(field, rule); err != nil {
+			errs = append(errs, err)
+		}
+		if err := v.checkRule(field, shadow); err != nil {
+			errs = append(errs, err)
+		}
+		if v.strictTyping {
+			errs = append(errs, checkRuleTypes(field, rule), checkRuleTypes(field, shadow))
+		}
+		prog.compileNested(v, field, rule)
+		prog.fields = append(prog.fields, &fieldProgram{
+			field:     field,
+			rule:      rule,
+			shadow:    shadow,
+			decodeErr: decodeErr,
+		})
+	}
+	v.sortFields(prog.fields)
+	return prog, errors.Join(errs...)
+}
+
+// checkRule check the configuration of a rule, including the names it references in the validator (e.g. go_func)
+func (v *Validator) checkRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	if rule == nil {
+		return nil
+	}
+	if rule.Regex != nil {
+		if _, err := r.Get(*rule.Regex); err != nil {
+			return fmt.Errorf("[proto valid]field[%s] invalid regex[%s]: %w", field.FullName(), *rule.Regex, err)
+		}
+	}
+	if _, ok := stringLength("", rule.GetLengthUnit()); !ok {
+		return fmt.Errorf("[proto valid]field[%s] length_unit[%s] not supported in this build", field.FullName(), rule.GetLengthUnit())
+	}
+	if rule.GoFunc != nil && v.getFunc(*rule.GoFunc) == nil {
+		return fmt.Errorf("[proto valid]field[%s] go_func[%s] not registered", field.FullName(), *rule.GoFunc)
+	}
+	if rule.StringChecksum != nil && v.getChecksum(*rule.StringChecksum) == nil {
+		return fmt.Errorf("[proto valid]field[%s] string_checksum[%s] not registered", field.FullName(), *rule.StringChecksum)
+	}
+	if rule.StringNotSimilar != nil && v.denylists == nil {
+		return fmt.Errorf("[proto valid]field[%s] string_not_similar without denylist source", field.FullName())
+	}
+	if err := checkUniqueByRule(field, rule); err != nil {
+		return err
+	}
+	if err := checkPostalCodeRule(field, rule); err != nil {
+		return err
+	}
+	if err := checkMapKeyRule(field, rule); err != nil {
+		return err
+	}
+	if err := checkMoneyRule(field, rule); err != nil {
+		return err
+	}
+	if err := checkDateRule(field, rule); err != nil {
+		return err
+	}
+	if err := checkTimeOfDayRule(field, rule); err != nil {
+		return err
+	}
+	return v.checkNestedRule(field, rule)
+}
+
+// checkRuleTypes check that every rule applies to the kind of the field
+func checkRuleTypes(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	if rule == nil {
+		return nil
+	}
+	var errs []error
+	for _, issue := range typeIssues(field, rule) {
+		errs = append(errs, fmt.Errorf("[proto valid]field[%s] rule[%s] %s", field.FullName(), issue.Rule, issue.Message))
+	}
+	return errors.Join(errs...)
+}
+
+// hasMessage whether the field (or its map value) is a message
+func hasMessage(field protoreflect.FieldDescriptor) bool {
+	if field.IsMap() {
+		field = field.MapValue()
+	}
+	return field.Kind() == protoreflect.MessageKind
+}
+
+// getRule get verification rules, from the index if the message of the field is indexed.
+// A rule that can't be decoded is treated as no rule.
+func getRule(field protoreflect.FieldDescriptor, resolver Resolver) *FieldValidator {
+	rule, _ := loadRule(field, resolver)
+	return rule
+}
+
+// loadRule get verification rules, from the index if the message of the field is indexed,
+// with the error of a rule that can't be decoded
+func loadRule(field protoreflect.FieldDescriptor, resolver Resolver) (*FieldValidator, error) {
+	if idx := index.Load(); idx != nil {
+		if rule, err, ok := idx.lookup(field); ok {
+			return rule, err
+		}
+	}
+	return decodeRule(field, resolver)
+}
+
+// decodeRule decode the rule of a field from its options
+func decodeRule(field protoreflect.FieldDescriptor, resolver Resolver) (*FieldValidator, error) {
+	opt := field.Options()
+	if opt == nil || !opt.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	if rule, err := findRule(opt.ProtoReflect()); rule != nil || err != nil {
+		return rule, wrapDecodeErr(field, err)
+	}
+
+	//the extension is kept as unknown fields when the descriptor was built without knowing it
+	unknown := opt.ProtoReflect().GetUnknown()
+	if len(unknown) == 0 {
+		return nil, nil
+	}
+	resolved := opt.ProtoReflect().Type().New()
+	if err := (proto.UnmarshalOptions{Resolver: resolver}).Unmarshal(unknown, resolved.Interface()); err != nil {
+		return nil, wrapDecodeErr(field, err)
+	}
+	rule, err := findRule(resolved)
+	if rule == nil && err == nil && hasUnknownField(resolved.GetUnknown(), E_Field.TypeDescriptor().Number()) {
+		err = fmt.Errorf("extension[%s] not registered in the resolver", E_Field.TypeDescriptor().FullName())
+	}
+	return rule, wrapDecodeErr(field, err)
+}
+
+// wrapDecodeErr wrap an error decoding the rule of a field
+func wrapDecodeErr(field protoreflect.FieldDescriptor, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("[proto valid]field[%s] decode rule: %w", field.FullName(), err)
+}
+
+// hasUnknownField whether unknown fields hold the field number
+func hasUnknownField(unknown protoreflect.RawFields, number protoreflect.FieldNumber) bool {
+	for len(unknown) > 0 {
+		num, _, n := protowire.ConsumeField(unknown)
+		if n < 0 {
+			return false
+		}
+		if num == number {
+			return true
+		}
+		unknown = unknown[n:]
+	}
+	return false
+}
+
+// findRule find the rule extension in field options
+func findRule(opt protoreflect.Message) (rule *FieldValidator, err error) {
+	opt.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if !fd.IsExtension() || fd.FullName() != E_Field.TypeDescriptor().FullName() {
+			return true
+		}
+		switch ext := value.Message().Interface().(type) {
+		case *FieldValidator:
+			rule = ext
+		default:
+			//the extension was resolved as another type (e.g. dynamicpb), convert it
+			var data []byte
+			if data, err = proto.Marshal(ext); err != nil {
+				return false
+			}
+			rule = &FieldValidator{}
+			if err = proto.Unmarshal(data, rule); err != nil {
+				rule = nil
+			}
+		}
+		return false
+	})
+	return rule, err
+}
+
+// progKey key of a compiled program variant
+type progKey struct {
+	desc   protoreflect.MessageDescriptor
+	groups groupSet
+}
+
+// progCache compiled program cache, keyed by message descriptor and active validation groups
+type progCache struct {
+	swapMap
+	//pinned registered programs, kept on reset
+	pinned sync.Map
+}
+
+// Get get the compiled program of a message for the active validation groups, compiling it on first use
+func (c *progCache) Get(md protoreflect.MessageDescriptor, v *Validator, rules *RuleSet, groups groupSet) *program {
+	key := progKey{desc: md, groups: groups}
+	if x, ok := c.pinned.Load(key); ok {
+		return x.(*program)
+	}
+	if x, ok := c.load().Load(key); ok {
+		return x.(*program)
+	}
+	prog, err := compile(md, v, rules, groups)
+	if err != nil {
+		v.warnf("[pb valid]compile msg[%s] err: %s", md.FullName(), err)
+		v.fault(&Fault{Kind: FaultConfig, Message: string(md.FullName()), Err: err})
+	}
+	x, _ := c.load().LoadOrStore(key, prog)
+	return x.(*program)
+}
+
+// pin pin a program in the cache
+func (c *progCache) pin(prog *program) {
+	c.pinned.Store(progKey{desc: prog.desc, groups: prog.groups}, prog)
+}
+
+// ResetProgramCache reset compiled program cache of the default validator
+func ResetProgramCache() {
+	std().progs.reset()
+}
+
+// ResetProgramCache reset compiled program cache
+func (v *Validator) ResetProgramCache() {
+	v.progs.reset()
+}
+
+// Register precompile the rules of a message with the default validator
+func Register(md protoreflect.MessageDescriptor) error {
+	return std().Register(md)
+}
+
+// MustRegister precompile the rules of messages with the default validator, panic on configuration problems
+func MustRegister(mds ...protoreflect.MessageDescriptor) {
+	std().MustRegister(mds...)
+}
+
+// Register precompile the rules of md and of every message it references, and pin them in the cache.
+// The programs are compiled for the validation groups of WithGroups.
+// Configuration problems (e.g. an invalid regex) are returned here instead of being logged on first use.
+func (v *Validator) Register(md protoreflect.MessageDescriptor) error {
+	var errs []error
+	seen := make(map[protoreflect.MessageDescriptor]bool)
+	var register func(md protoreflect.MessageDescriptor)
+	register = func(md protoreflect.MessageDescriptor) {
+		if seen[md] {
+			return
+		}
+		seen[md] = true
+
+		prog, err := compile(md, v, nil, v.groups)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		v.progs.pin(prog)
+		for _, fp := range prog.fields {
+			field := fp.field
+			if field.IsMap() {
+				field = field.MapValue()
+			}
+			if field.Kind() == protoreflect.MessageKind {
+				register(field.Message())
+			}
+		}
+	}
+	register(md)
+	return errors.Join(errs...)
+}
+
+// MustRegister precompile the rules of messages, panic on configuration problems
+func (v *Validator) MustRegister(mds ...protoreflect.MessageDescriptor) {
+	for _, md := range mds {
+		if err := v.Register(md); err != nil {
+			panic(err)
+		}
+	}
+}
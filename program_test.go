@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"strings"
+	"testing"
+)
+
+// undecodableProto file of the message decode.Item, whose field sku holds the encoded rule as an unknown extension
+func undecodableProto(t *testing.T, rule []byte) protoreflect.FileDescriptor {
+	t.Helper()
+	fd := compileProto(t, `syntax = "proto3"; package decode; import "validator.proto";
+message Item { string sku = 1; string name = 2 [(validator.field) = {length_gt: 1}]; }`)
+	fdp := protodesc.ToFileDescriptorProto(fd)
+	opts := &descriptorpb.FieldOptions{}
+	opts.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, E_Field.TypeDescriptor().Number(), protowire.BytesType), rule))
+	fdp.MessageType[0].Field[0].Options = opts
+	files := new(protoregistry.Files)
+	if err := files.RegisterFile(fd.Imports().Get(0).FileDescriptor); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+func TestRuleDecodeViolations(t *testing.T) {
+	rule, err := proto.Marshal(&FieldValidator{LengthGt: proto.Int64(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the tag of length_gt again, without value
+	corrupt := undecodableProto(t, append(rule, rule[0]))
+	unresolved := undecodableProto(t, rule)
+	for _, c := range []struct {
+		name string
+		fd   protoreflect.FileDescriptor
+		opts []Option
+		keys []string
+	}{
+		{"corrupt ignored", corrupt, nil, nil},
+		{"corrupt reported", corrupt, []Option{WithRuleDecodeViolations()}, []string{"sku:RuleDecode"}},
+		{"unresolved ignored", unresolved, []Option{WithResolver(new(protoregistry.Types))}, nil},
+		{"unresolved reported", unresolved, []Option{WithResolver(new(protoregistry.Types)), WithRuleDecodeViolations()}, []string{"sku:RuleDecode"}},
+		{"decoded", unresolved, []Option{WithRuleDecodeViolations()}, []string{"sku:LengthGt"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(append([]Option{WithAggregation(AggregateAll), WithLogger(&testLogger{})}, c.opts...)...)
+			md := c.fd.Messages().ByName("Item")
+			if err := v.Register(md); (err != nil) != (c.name != "decoded") || err != nil && !strings.Contains(err.Error(), "decode.Item.sku] decode rule") {
+				t.Fatalf("Register: %v", err)
+			}
+			err := v.Validate(newMsg(t, c.fd, "Item", `{"sku":"a","name":"ab"}`))
+			if keys := violationKeys(err); strings.Join(keys, ",") != strings.Join(c.keys, ",") {
+				t.Fatalf("got %v, want %v", keys, c.keys)
+			}
+		})
+	}
+}
+
+func TestMustRegister(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package register; import "validator.proto";
+message Tag { string name = 1 [(validator.field) = {length_gt: 1}]; }
+message Line { string sku = 1 [(validator.field) = {length_gt: 1}]; map<string, Tag> tags = 2; }
+message Order { repeated Line lines = 1; Order parent = 2; string note = 3; }
+message BrokenTag { string name = 1 [(validator.field) = {regex: "("}]; }
+message BrokenLine { map<string, BrokenTag> tags = 1; }
+message BrokenOrder { repeated BrokenLine lines = 1; }`)
+	messages := fd.Messages()
+	mustRegister := func(v *Validator, names ...string) (p interface{}) {
+		defer func() {
+			p = recover()
+		}()
+		mds := make([]protoreflect.MessageDescriptor, len(names))
+		for i, name := range names {
+			mds[i] = messages.ByName(protoreflect.Name(name))
+		}
+		v.MustRegister(mds...)
+		return nil
+	}
+
+	v := New()
+	if p := mustRegister(v, "Order", "Tag"); p != nil {
+		t.Fatal(p)
+	}
+	//the referenced messages are pinned too, through lists, map values and cycles
+	v.ResetProgramCache()
+	for _, name := range []string{"Order", "Line", "Tag"} {
+		if _, ok := v.progs.pinned.Load(progKey{desc: messages.ByName(protoreflect.Name(name)), groups: v.groups}); !ok {
+			t.Fatalf("%s not registered", name)
+		}
+	}
+	if _, ok := v.progs.pinned.Load(progKey{desc: messages.ByName("BrokenTag"), groups: v.groups}); ok {
+		t.Fatal("unreferenced message registered")
+	}
+
+	//a bad rule of a referenced message panics, the programs compiled despite it are kept
+	p := mustRegister(v, "Line", "BrokenOrder", "BrokenTag")
+	err, ok := p.(error)
+	if !ok || !strings.Contains(err.Error(), "register.BrokenTag.name] invalid regex") {
+		t.Fatal(p)
+	}
+	if _, ok := v.progs.pinned.Load(progKey{desc: messages.ByName("BrokenLine"), groups: v.groups}); !ok {
+		t.Fatal("BrokenLine not registered")
+	}
+	if p := mustRegister(New(), "BrokenTag"); p == nil {
+		t.Fatal("no panic")
+	}
+}
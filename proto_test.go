@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// testImports sources of the imports of test.proto which are not registered, e.g. the google.type messages
+var testImports = map[string]string{
+	"google/type/types.proto": `syntax = "proto3"; package google.type;
+message Money { string currency_code = 1; int64 units = 2; int32 nanos = 3; }
+message Decimal { string value = 1; }
+message LatLng { double latitude = 1; double longitude = 2; }
+message Date { int32 year = 1; int32 month = 2; int32 day = 3; }
+message TimeOfDay { int32 hours = 1; int32 minutes = 2; int32 seconds = 3; int32 nanos = 4; }`,
+}
+
+// testResolver resolve the files of testImports, then the registered files
+type testResolver struct {
+	*protoregistry.Files
+}
+
+// FindFileByPath implement protodesc.Resolver
+func (r testResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.Files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+// FindDescriptorByName implement protodesc.Resolver
+func (r testResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.Files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// compileProto compile the source of test.proto, which may import validator.proto and testImports
+func compileProto(t testing.TB, src string) protoreflect.FileDescriptor {
+	t.Helper()
+	parser := protoparse.Parser{
+		Accessor: func(filename string) (io.ReadCloser, error) {
+			if filename == "test.proto" {
+				return io.NopCloser(strings.NewReader(src)), nil
+			}
+			if imported, ok := testImports[filename]; ok {
+				return io.NopCloser(strings.NewReader(imported)), nil
+			}
+			return os.Open(filename)
+		},
+	}
+	fds, err := parser.ParseFiles("test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := testResolver{Files: new(protoregistry.Files)}
+	for _, dep := range fds[0].GetDependencies() {
+		if _, ok := testImports[dep.GetName()]; !ok {
+			continue
+		}
+		imported, err := protodesc.NewFile(protodesc.ToFileDescriptorProto(dep.UnwrapFile()), protoregistry.GlobalFiles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := resolver.RegisterFile(imported); err != nil {
+			t.Fatal(err)
+		}
+	}
+	//round trip through the wire format so the rule extensions are decoded as FieldValidator
+	data, err := proto.Marshal(protodesc.ToFileDescriptorProto(fds[0].UnwrapFile()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fdp := &descriptorpb.FileDescriptorProto{}
+	if err := (proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(data, fdp); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, resolver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+// testTypes resolver of the validator extensions and of the messages of fd, e.g. for WithResolver
+func testTypes(t testing.TB, fd protoreflect.FileDescriptor) *protoregistry.Types {
+	t.Helper()
+	types := new(protoregistry.Types)
+	for _, xt := range []protoreflect.ExtensionType{E_Field, E_Method, E_Message} {
+		if err := types.RegisterExtension(xt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var register func(mds protoreflect.MessageDescriptors)
+	register = func(mds protoreflect.MessageDescriptors) {
+		for i := 0; i < mds.Len(); i++ {
+			if err := types.RegisterMessage(dynamicpb.NewMessageType(mds.Get(i))); err != nil {
+				t.Fatal(err)
+			}
+			register(mds.Get(i).Messages())
+		}
+	}
+	register(fd.Messages())
+	return types
+}
+
+// newMsg message name of fd decoded from JSON
+func newMsg(t testing.TB, fd protoreflect.FileDescriptor, name, json string) *dynamicpb.Message {
+	t.Helper()
+	msg := dynamicpb.NewMessage(fd.Messages().ByName(protoreflect.Name(name)))
+	if err := protojson.Unmarshal([]byte(json), msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// testLogger logger recording the warnings
+type testLogger struct {
+	warnings []string
+}
+
+// Debugf implement Logger
+func (l *testLogger) Debugf(string, ...interface{}) {}
+
+// Warnf implement Logger
+func (l *testLogger) Warnf(format string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sync"
+)
+
+// RuleLayer configuration layer a rule comes from
+type RuleLayer string
+
+const (
+	// LayerAnnotation rule annotated in the proto options of the field
+	LayerAnnotation RuleLayer = "annotation"
+	// LayerOverlay rule of an overlay, or of the rule set fetched from the rule source
+	LayerOverlay RuleLayer = "overlay"
+	// LayerDefault default policy of the validator, e.g. WithDefaultMaxStringBytes or WithOpenEnumPolicy
+	LayerDefault RuleLayer = "default"
+)
+
+// Provenance where a violated rule comes from, so operators can tell which layer rejected a request
+type Provenance struct {
+	Layer RuleLayer `json:"layer"`
+	// Name name of the rule set of the overlay layer
+	Name string `json:"name,omitempty"`
+}
+
+// String implement fmt.Stringer, e.g. "overlay(lax)"
+func (p Provenance) String() string {
+	if p.Name == "" {
+		return string(p.Layer)
+	}
+	return string(p.Layer) + "(" + p.Name + ")"
+}
+
+// Provenance where the violated rule comes from
+func (e *ValidError) Provenance() Provenance {
+	if e.provenance.Layer == "" {
+		return Provenance{Layer: LayerAnnotation}
+	}
+	return e.provenance
+}
+
+// provenance where the rule of a field named ruleKind comes from
+func (v *validator) provenance(field protoreflect.FieldDescriptor, ruleKind string) Provenance {
+	switch ruleKind {
+	case "DefaultMaxStringBytes", "DefaultMaxRepeated", "OpenEnum":
+		return Provenance{Layer: LayerDefault}
+	}
+	//in shadow overlays the enforced rules are the annotated ones
+	if v.overlay == nil || v.overlay.rules.GetShadow() && !v.shadow || field.ContainingMessage() == nil {
+		return Provenance{Layer: LayerAnnotation}
+	}
+	overlaid := v.overlay.rules.GetMessages()[string(field.ContainingMessage().FullName())].GetFields()[string(field.Name())]
+	if overlaid == nil {
+		return Provenance{Layer: LayerAnnotation}
+	}
+	if fd := ruleKindField(ruleKind); fd != nil && !overlaid.ProtoReflect().Has(fd) {
+		return Provenance{Layer: LayerAnnotation}
+	}
+	return Provenance{Layer: LayerOverlay, Name: v.overlay.rules.GetName()}
+}
+
+var (
+	ruleKindFieldsOnce sync.Once
+	ruleKindFields     map[string]protoreflect.FieldDescriptor
+)
+
+// ruleKindField field of FieldValidator of a rule kind, e.g. "IntGt" for int_gt, nil if the kind isn't a field
+func ruleKindField(ruleKind string) protoreflect.FieldDescriptor {
+	ruleKindFieldsOnce.Do(func() {
+		ruleKindFields = make(map[string]protoreflect.FieldDescriptor)
+		fields := (&FieldValidator{}).ProtoReflect().Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			ruleKindFields[goName(fields.Get(i))] = fields.Get(i)
+		}
+	})
+	return ruleKindFields[ruleKind]
+}
@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestProvenance(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package provenance; import "validator.proto";
+enum Plan { PLAN_UNSPECIFIED = 0; PLAN_FREE = 1; }
+message Account {
+  string name = 1 [(validator.field) = {length_gt: 1, length_lt: 10}];
+  string note = 2;
+  Plan plan = 3;
+  repeated string tags = 4;
+}`)
+	lt, gt := int64(5), int64(2)
+	overlays := []Option{
+		WithOverlay(&RuleSet{
+			Name:     proto.String("strict"),
+			Messages: map[string]*MessageRules{"provenance.Account": {Fields: map[string]*FieldValidator{"name": {LengthLt: &lt}}}},
+		}),
+		WithOverlay(&RuleSet{
+			Name:     proto.String("trial"),
+			Shadow:   proto.Bool(true),
+			Messages: map[string]*MessageRules{"provenance.Account": {Fields: map[string]*FieldValidator{"note": {LengthGt: &gt}}}},
+		}),
+	}
+	for _, c := range []struct {
+		name, overlay, json, path, rule string
+		opts                            []Option
+		provenance                      Provenance
+	}{
+		{"annotated", "", `{"name":"a"}`, "name", "LengthGt", nil, Provenance{Layer: LayerAnnotation}},
+		{"overlaid rule", "strict", `{"name":"abcdef"}`, "name", "LengthLt", nil, Provenance{Layer: LayerOverlay, Name: "strict"}},
+		{"annotated rule of an overlaid field", "strict", `{"name":"a"}`, "name", "LengthGt", nil, Provenance{Layer: LayerAnnotation}},
+		{"annotated rule without overlay", "", `{"name":"abcdefghijk"}`, "name", "LengthLt", nil, Provenance{Layer: LayerAnnotation}},
+		{"enforced rule of a shadow overlay", "trial", `{"name":"a","note":"ab"}`, "name", "LengthGt", nil, Provenance{Layer: LayerAnnotation}},
+		{"default string bytes", "", `{"name":"ab","note":"abcdef"}`, "note", "DefaultMaxStringBytes",
+			[]Option{WithDefaultMaxStringBytes(5)}, Provenance{Layer: LayerDefault}},
+		{"default repeated", "strict", `{"name":"ab","tags":["a","b"]}`, "tags", "DefaultMaxRepeated",
+			[]Option{WithDefaultMaxRepeated(1)}, Provenance{Layer: LayerDefault}},
+		{"open enum", "", `{"name":"ab","plan":7}`, "plan", "OpenEnum", []Option{WithOpenEnumPolicy(EnumReject)}, Provenance{Layer: LayerDefault}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.overlay != "" {
+				ctx = ContextWithOverlay(ctx, c.overlay)
+			}
+			err := New(append(overlays, c.opts...)...).ValidateContext(ctx, newMsg(t, fd, "Account", c.json))
+			e := FindViolation(err, c.path, c.rule)
+			if e == nil {
+				t.Fatal(err)
+			}
+			if e.Provenance() != c.provenance {
+				t.Fatalf("got %s, want %s", e.Provenance(), c.provenance)
+			}
+		})
+	}
+
+	//violations of a shadow overlay come from the overlay
+	var shadowed []*ValidError
+	v := New(append(overlays, WithOnViolation(func(e *ValidError) {
+		if e.Shadow() {
+			shadowed = append(shadowed, e)
+		}
+	}))...)
+	if err := v.ValidateContext(ContextWithOverlay(context.Background(), "trial"), newMsg(t, fd, "Account", `{"name":"ab","note":"a"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(shadowed) != 1 || shadowed[0].Provenance() != (Provenance{Layer: LayerOverlay, Name: "trial"}) {
+		t.Fatal(shadowed)
+	}
+}
+
+func TestProvenanceString(t *testing.T) {
+	for p, s := range map[Provenance]string{
+		{Layer: LayerAnnotation}:              "annotation",
+		{Layer: LayerOverlay, Name: "strict"}: "overlay(strict)",
+		{Layer: LayerOverlay}:                 "overlay",
+		{Layer: LayerDefault}:                 "default",
+	} {
+		if p.String() != s {
+			t.Errorf("got %s, want %s", p, s)
+		}
+	}
+	//the zero provenance of a violation is the annotation
+	if p := (&ValidError{}).Provenance(); p != (Provenance{Layer: LayerAnnotation}) {
+		t.Fatal(p)
+	}
+}
@@ -0,0 +1,208 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// proxyMaxBodyBytes max request body read by the proxy, same as the default max receive size of grpc
+const proxyMaxBodyBytes = 4 << 20
+
+// grpcInvalidArgument grpc status code of invalid argument
+const grpcInvalidArgument = 3
+
+// grpcResourceExhausted grpc status code of resource exhausted
+const grpcResourceExhausted = 8
+
+// errBodyTooLarge the request body, or a decompressed grpc-web frame, exceeds proxyMaxBodyBytes
+var errBodyTooLarge = fmt.Errorf("[proto valid]body exceeds %d bytes", proxyMaxBodyBytes)
+
+// Route map a request path to the message type of its body
+type Route struct {
+	// Path exact url path, e.g. "/package.Service/Method" for gRPC-Web or "/v1/orders" for JSON
+	Path string
+	// Message type of the request body
+	Message protoreflect.MessageType
+}
+
+// Proxy http handler validating request bodies per route before forwarding them to a backend.
+// Requests of unknown routes are forwarded untouched.
+type Proxy struct {
+	validator *Validator
+	backend   http.Handler
+	routes    map[string]protoreflect.MessageType
+}
+
+// NewProxy create a validating proxy in front of backend, e.g. httputil.NewSingleHostReverseProxy(target)
+func NewProxy(v *Validator, backend http.Handler, routes ...Route) *Proxy {
+	if v == nil {
+		v = std()
+	}
+	p := &Proxy{
+		validator: v,
+		backend:   backend,
+		routes:    make(map[string]protoreflect.MessageType, len(routes)),
+	}
+	for _, route := range routes {
+		p.routes[route.Path] = route.Message
+	}
+	return p
+}
+
+// ServeHTTP implement http.Handler
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mt, ok := p.routes[r.URL.Path]
+	if !ok || r.Body == nil {
+		p.backend.ServeHTTP(w, r)
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	grpcWeb := strings.HasPrefix(contentType, "application/grpc-web")
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, proxyMaxBodyBytes))
+	_ = r.Body.Close()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			err = errBodyTooLarge
+		}
+		p.reject(w, grpcWeb, fmt.Errorf("[proto valid]read body: %w", err))
+		return
+	}
+
+	if grpcWeb {
+		err = p.validGrpcWeb(r.Context(), mt, contentType, r.Header.Get("Grpc-Encoding"), body)
+	} else {
+		err = p.validBody(r.Context(), mt, contentType, body)
+	}
+	if err != nil {
+		p.reject(w, grpcWeb, err)
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	p.backend.ServeHTTP(w, r)
+}
+
+// validBody decode and verify a JSON or binary body with the context of the request
+func (p *Proxy) validBody(ctx context.Context, mt protoreflect.MessageType, contentType string, body []byte) error {
+	msg := mt.New().Interface()
+	var err error
+	switch contentType {
+	case "application/x-protobuf", "application/protobuf", "application/octet-stream":
+		err = proto.UnmarshalOptions{Resolver: p.validator.resolver}.Unmarshal(body, msg)
+	default:
+		err = protojson.UnmarshalOptions{Resolver: p.validator.resolver, DiscardUnknown: true}.Unmarshal(body, msg)
+	}
+	if err != nil {
+		return fmt.Errorf("[proto valid]decode body as %s: %w", mt.Descriptor().FullName(), err)
+	}
+	return p.validator.ValidateContext(ctx, msg)
+}
+
+// validGrpcWeb decode and verify every data frame of a gRPC-Web body with the context of the request
+func (p *Proxy) validGrpcWeb(ctx context.Context, mt protoreflect.MessageType, contentType, encoding string, body []byte) error {
+	if strings.HasPrefix(contentType, "application/grpc-web-text") {
+		decoded, err := base64.StdEncoding.DecodeString(string(body))
+		if err != nil {
+			return fmt.Errorf("[proto valid]decode grpc-web-text body: %w", err)
+		}
+		body = decoded
+	}
+
+	for len(body) > 0 {
+		if len(body) < 5 {
+			return errors.New("[proto valid]truncated grpc-web frame header")
+		}
+		flag, size := body[0], binary.BigEndian.Uint32(body[1:5])
+		if uint64(len(body)-5) < uint64(size) {
+			return errors.New("[proto valid]truncated grpc-web frame")
+		}
+		frame := body[5 : 5+size]
+		body = body[5+size:]
+
+		if flag&0x80 != 0 {
+			//trailer frame
+			continue
+		}
+		if flag&0x01 != 0 {
+			data, err := decompress(encoding, frame)
+			if err != nil {
+				return err
+			}
+			frame = data
+		}
+
+		msg := mt.New().Interface()
+		if err := (proto.UnmarshalOptions{Resolver: p.validator.resolver}).Unmarshal(frame, msg); err != nil {
+			return fmt.Errorf("[proto valid]decode grpc-web frame as %s: %w", mt.Descriptor().FullName(), err)
+		}
+		if err := p.validator.ValidateContext(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decompress decompress a compressed grpc message, failing with errBodyTooLarge beyond proxyMaxBodyBytes
+func decompress(encoding string, data []byte) ([]byte, error) {
+	if encoding != "gzip" {
+		return nil, fmt.Errorf("[proto valid]unsupported grpc-encoding[%s]", encoding)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("[proto valid]decompress grpc-web frame: %w", err)
+	}
+	defer zr.Close()
+	data, err = io.ReadAll(io.LimitReader(zr, proxyMaxBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("[proto valid]decompress grpc-web frame: %w", err)
+	}
+	if len(data) > proxyMaxBodyBytes {
+		return nil, errBodyTooLarge
+	}
+	return data, nil
+}
+
+// reject write the rejection response.
+// gRPC-Web clients get a trailers-only response with status INVALID_ARGUMENT, others a 400 JSON body.
+// A body exceeding proxyMaxBodyBytes is rejected with RESOURCE_EXHAUSTED, or 413.
+func (p *Proxy) reject(w http.ResponseWriter, grpcWeb bool, err error) {
+	code, httpStatus := grpcInvalidArgument, http.StatusBadRequest
+	if errors.Is(err, errBodyTooLarge) {
+		code, httpStatus = grpcResourceExhausted, http.StatusRequestEntityTooLarge
+	}
+	if grpcWeb {
+		w.Header().Set("Content-Type", "application/grpc-web+proto")
+		w.Header().Set("Grpc-Status", strconv.Itoa(code))
+		w.Header().Set("Grpc-Message", url.PathEscape(err.Error()))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    code,
+		"message": err.Error(),
+	})
+}
@@ -0,0 +1,105 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// grpcWebFrame gRPC-Web data frame of data, compressed with gzip if compressed
+func grpcWebFrame(t *testing.T, data []byte, compressed bool) []byte {
+	t.Helper()
+	var flag byte
+	if compressed {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		data, flag = buf.Bytes(), 0x01
+	}
+	frame := make([]byte, 5, 5+len(data))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
+
+func TestProxy(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package proxy; import "validator.proto";
+message Order { string sku = 1 [(validator.field) = {length_gt: 1}]; string note = 2; }`)
+	mt := dynamicpb.NewMessageType(fd.Messages().ByName("Order"))
+	lt := int64(4)
+	v := New(WithOverlay(&RuleSet{
+		Name: proto.String("strict"),
+		Messages: map[string]*MessageRules{
+			"proxy.Order": {Fields: map[string]*FieldValidator{"note": {LengthLt: &lt}}},
+		},
+	}))
+	var forwarded []byte
+	backend := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	proxy := NewProxy(v, backend, Route{Path: "/v1/orders", Message: mt}, Route{Path: "/proxy.Orders/Create", Message: mt})
+
+	wire := func(json string) []byte {
+		data, err := proto.Marshal(newMsg(t, fd, "Order", json))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	for _, c := range []struct {
+		name, path, contentType string
+		body                    []byte
+		ctx                     context.Context
+		status                  int
+		grpcStatus              string
+	}{
+		{"unknown route", "/v1/other", "application/json", []byte(`not json`), nil, http.StatusNoContent, ""},
+		{"legal json", "/v1/orders", "application/json", []byte(`{"sku":"ab"}`), nil, http.StatusNoContent, ""},
+		{"invalid json", "/v1/orders", "application/json", []byte(`{"sku":"a"}`), nil, http.StatusBadRequest, ""},
+		{"undecodable json", "/v1/orders", "application/json", []byte(`{"sku":1}`), nil, http.StatusBadRequest, ""},
+		{"legal binary", "/v1/orders", "application/x-protobuf", wire(`{"sku":"ab"}`), nil, http.StatusNoContent, ""},
+		{"overlay of the request context", "/v1/orders", "application/json", []byte(`{"sku":"ab","note":"long note"}`),
+			ContextWithOverlay(context.Background(), "strict"), http.StatusBadRequest, ""},
+		{"json too large", "/v1/orders", "application/json", bytes.Repeat([]byte(" "), proxyMaxBodyBytes+1), nil, http.StatusRequestEntityTooLarge, ""},
+		{"legal grpc-web", "/proxy.Orders/Create", "application/grpc-web+proto", grpcWebFrame(t, wire(`{"sku":"ab"}`), false), nil, http.StatusNoContent, ""},
+		{"invalid grpc-web", "/proxy.Orders/Create", "application/grpc-web+proto", grpcWebFrame(t, wire(`{"sku":"a"}`), false), nil, http.StatusOK, "3"},
+		{"compressed grpc-web", "/proxy.Orders/Create", "application/grpc-web+proto", grpcWebFrame(t, wire(`{"sku":"a"}`), true), nil, http.StatusOK, "3"},
+		{"truncated grpc-web", "/proxy.Orders/Create", "application/grpc-web+proto", grpcWebFrame(t, wire(`{"sku":"ab"}`), false)[:4], nil, http.StatusOK, "3"},
+		{"decompressed grpc-web too large", "/proxy.Orders/Create", "application/grpc-web+proto",
+			grpcWebFrame(t, wire(`{"note":"`+strings.Repeat("x", proxyMaxBodyBytes)+`"}`), true), nil, http.StatusOK, "8"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			forwarded = nil
+			r := httptest.NewRequest(http.MethodPost, c.path, bytes.NewReader(c.body))
+			if c.ctx != nil {
+				r = r.WithContext(c.ctx)
+			}
+			r.Header.Set("Content-Type", c.contentType)
+			r.Header.Set("Grpc-Encoding", "gzip")
+			w := httptest.NewRecorder()
+			proxy.ServeHTTP(w, r)
+			if w.Code != c.status || w.Header().Get("Grpc-Status") != c.grpcStatus {
+				t.Fatalf("got %d grpc-status[%s]: %s", w.Code, w.Header().Get("Grpc-Status"), w.Body)
+			}
+			if (c.status == http.StatusNoContent) != bytes.Equal(forwarded, c.body) {
+				t.Fatalf("forwarded %d bytes of %d", len(forwarded), len(c.body))
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// pureReflectDenied standard packages which must stay out of the purereflect build
+var pureReflectDenied = []string{"net/http", "database/sql", "html/template", "text/template", "os/exec"}
+
+// goTool path of the go command, the test is skipped without it
+func goTool(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds the package")
+	}
+	path, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	return path
+}
+
+func TestPureReflectDeps(t *testing.T) {
+	out, err := exec.Command(goTool(t), "list", "-tags", "purereflect", "-deps", "-f", "{{.ImportPath}} {{.Standard}}", ".").CombinedOutput()
+	if err != nil {
+		t.Fatalf("go list: %s: %s", err, out)
+	}
+	module := "github.com/bafflingbug/go-proto-reflect-validators"
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		pkg, standard, _ := strings.Cut(line, " ")
+		if standard == "true" {
+			for _, denied := range pureReflectDenied {
+				if pkg == denied || strings.HasPrefix(pkg, denied+"/") {
+					t.Errorf("purereflect build depends on %s", pkg)
+				}
+			}
+			continue
+		}
+		if pkg != module && !strings.HasPrefix(pkg, "google.golang.org/protobuf/") {
+			t.Errorf("purereflect build depends on %s", pkg)
+		}
+	}
+}
+
+func TestPureReflectWasm(t *testing.T) {
+	cmd := exec.Command(goTool(t), "build", "-tags", "purereflect", "-o", os.DevNull, ".")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %s: %s", err, out)
+	}
+}
@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestRepeatedElements(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package repeated; import "validator.proto";
+message Blobs {
+  repeated bytes sized = 1 [(validator.field) = {length_gt: 1, length_lt: 4}];
+  repeated bytes prefixed = 2 [(validator.field) = {string_prefix: "ab", string_suffix: "c"}];
+  repeated bytes matched = 3 [(validator.field) = {regex: "^[a-z]+$"}];
+  repeated string names = 4 [(validator.field) = {length_eq: 3, regex: "^[a-z]+$", string_prefix: "a"}];
+}`)
+	//bytes in JSON are base64: YWJj "abc", YWJjZA== "abcd", eA== "x", eHl6 "xyz", MTI= "12"
+	for _, c := range []struct {
+		name, json string
+		want       [][2]string
+	}{
+		{"legal", `{"sized":["YWJj"],"prefixed":["YWJj"],"matched":["eHl6"],"names":["abc"]}`, nil},
+		{"bytes length", `{"sized":["YWJj","eA==","YWJjZA=="]}`, [][2]string{{"sized[1]", "LengthGt"}, {"sized[2]", "LengthLt"}}},
+		{"bytes prefix", `{"prefixed":["YWJj","eHl6"]}`, [][2]string{{"prefixed[1]", "StringPrefix"}, {"prefixed[1]", "StringSuffix"}}},
+		{"bytes regex", `{"matched":["eHl6","MTI="]}`, [][2]string{{"matched[1]", "Regex"}}},
+		{"string elements", `{"names":["abc","ab","xyz"]}`, [][2]string{{"names[1]", "LengthEq"}, {"names[2]", "StringPrefix"}}},
+		{"string regex", `{"names":["a12"]}`, [][2]string{{"names[0]", "Regex"}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Blobs", c.json))
+			if got := len(ValidErrors(err)); got != len(c.want) {
+				t.Fatalf("want %d violations, got %v", len(c.want), err)
+			}
+			for _, want := range c.want {
+				if !MatchViolation(err, want[0], want[1]) {
+					t.Errorf("want %s at %s, got %v", want[1], want[0], err)
+				}
+			}
+		})
+	}
+}
+
+func TestRepeatedCount(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package repeated; import "validator.proto";
+message Item { string sku = 1; }
+message Order {
+  repeated string tags = 1 [(validator.field) = {repeated_count_min: 1, repeated_count_max: 2}];
+  map<string, string> labels = 2 [(validator.field) = {repeated_count_min: 1, repeated_count_max: 2}];
+  map<string, Item> items = 3 [(validator.field) = {repeated_count_max: 3}];
+  map<string, Item> others = 4;
+}`)
+	legal := `"tags":["a"],"labels":{"a":"x"}`
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"legal", `{` + legal + `}`, "", ""},
+		{"list under the min", `{"labels":{"a":"x"}}`, "tags", "RepeatedCountMin"},
+		{"list over the max", `{"tags":["a","b","c"],"labels":{"a":"x"}}`, "tags", "RepeatedCountMax"},
+		{"map under the min", `{"tags":["a"]}`, "labels", "RepeatedCountMin"},
+		{"map at the max", `{"tags":["a"],"labels":{"a":"x","b":"y"}}`, "", ""},
+		{"map over the max", `{"tags":["a"],"labels":{"a":"x","b":"y","c":"z"}}`, "labels", "RepeatedCountMax"},
+		//the explicit max replaces the default limit of 2
+		{"message map at the max", `{` + legal + `,"items":{"a":{},"b":{},"c":{}}}`, "", ""},
+		{"message map over the max", `{` + legal + `,"items":{"a":{},"b":{},"c":{},"d":{}}}`, "items", "RepeatedCountMax"},
+		{"default limit", `{` + legal + `,"others":{"a":{},"b":{},"c":{}}}`, "others", "DefaultMaxRepeated"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New(WithDefaultMaxRepeated(2)).Validate(newMsg(t, fd, "Order", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// reportTemplate page of the rules of a package
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Package}} validation rules</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+code { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>{{.Package}}</h1>
+<ul>
+{{- range .Messages}}
+<li><a href="#{{.Name}}">{{.Name}}</a></li>
+{{- end}}
+</ul>
+{{- range .Messages}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+<p>{{.File}}</p>
+<table>
+<tr><th>#</th><th>Field</th><th>Type</th><th>Rules</th></tr>
+{{- range .Fields}}
+<tr><td>{{.Number}}</td><td>{{.Name}}</td><td>{{if .Link}}<a href="{{.Link}}">{{.Type}}</a>{{else}}{{.Type}}{{end}}</td><td><code>{{.Rules}}</code></td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// reportPage data of a package page
+type reportPage struct {
+	Package  string
+	Messages []*reportMessage
+}
+
+// reportMessage data of a message
+type reportMessage struct {
+	Name   string
+	File   string
+	Fields []*reportField
+}
+
+// reportField data of a field
+type reportField struct {
+	Number int
+	Name   string
+	Type   string
+	Link   string
+	Rules  string
+}
+
+// WriteHTMLReport write a browsable HTML page listing every message of the package pkg in files,
+// with the type and the rules of every field and an anchor per message (the message full name)
+func WriteHTMLReport(w io.Writer, pkg protoreflect.FullName, files ...protoreflect.FileDescriptor) error {
+	page := &reportPage{Package: string(pkg)}
+	packages := make(map[protoreflect.FullName]bool)
+	for _, fd := range files {
+		packages[fd.Package()] = true
+	}
+	for _, fd := range files {
+		if fd.Package() == pkg {
+			page.Messages = reportMessages(fd, fd.Messages(), packages, page.Messages)
+		}
+	}
+	sort.Slice(page.Messages, func(i, j int) bool {
+		return page.Messages[i].Name < page.Messages[j].Name
+	})
+	return reportTemplate.Execute(w, page)
+}
+
+// WriteHTMLReports write a report page per package of files into dir, named after the package (e.g. "example.v1.html")
+func WriteHTMLReports(dir string, files ...protoreflect.FileDescriptor) error {
+	packages := make(map[protoreflect.FullName]bool)
+	for _, fd := range files {
+		packages[fd.Package()] = true
+	}
+	for pkg := range packages {
+		f, err := os.Create(filepath.Join(dir, reportFile(pkg)))
+		if err != nil {
+			return err
+		}
+		err = WriteHTMLReport(f, pkg, files...)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("[proto valid]write report of package[%s]: %w", pkg, err)
+		}
+	}
+	return nil
+}
+
+// reportFile file name of the report page of a package
+func reportFile(pkg protoreflect.FullName) string {
+	if pkg == "" {
+		return "_.html"
+	}
+	return string(pkg) + ".html"
+}
+
+// reportMessages collect messages and their nested messages, map entries excluded.
+// Message types are linked if their package is reported.
+func reportMessages(fd protoreflect.FileDescriptor, messages protoreflect.MessageDescriptors, packages map[protoreflect.FullName]bool, out []*reportMessage) []*reportMessage {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		msg := &reportMessage{
+			Name: string(md.FullName()),
+			File: fd.Path(),
+		}
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			rf := &reportField{
+				Number: int(field.Number()),
+				Name:   string(field.Name()),
+				Type:   reportType(field),
+			}
+			if target := reportTarget(field); target != nil && packages[target.ParentFile().Package()] {
+				rf.Link = "#" + string(target.FullName())
+				if target.ParentFile().Package() != fd.Package() {
+					rf.Link = reportFile(target.ParentFile().Package()) + rf.Link
+				}
+			}
+			if rule := getRule(field, protoregistry.GlobalTypes); rule != nil {
+				rf.Rules = formatRule(rule.ProtoReflect(), "")
+			}
+			msg.Fields = append(msg.Fields, rf)
+		}
+		out = append(out, msg)
+		out = reportMessages(fd, md.Messages(), packages, out)
+	}
+	return out
+}
+
+// reportType type of a field, e.g. "repeated string" or "map<string, example.Item>"
+func reportType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", reportKind(field.MapKey()), reportKind(field.MapValue()))
+	}
+	if field.IsList() {
+		return "repeated " + reportKind(field)
+	}
+	return reportKind(field)
+}
+
+// reportKind kind of a field, the full name of messages and enums
+func reportKind(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(field.Enum().FullName())
+	}
+	return field.Kind().String()
+}
+
+// reportTarget message linked from a field, nil if none
+func reportTarget(field protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if field.IsMap() {
+		field = field.MapValue()
+	}
+	if field.Kind() != protoreflect.MessageKind || field.Message().IsMapEntry() {
+		return nil
+	}
+	return field.Message()
+}
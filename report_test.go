@@ -0,0 +1,120 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"bytes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testReportProto messages of the package report, referencing the package google.type
+const testReportProto = `syntax = "proto3"; package report; import "validator.proto"; import "google/type/types.proto";
+message Order {
+  string id = 1 [(validator.field) = {regex: "^<[a-z]+>$"}];
+  repeated Item items = 2 [(validator.field) = {repeated_count_max: 3}];
+  map<string, Item> by_key = 3;
+  google.type.Money price = 4;
+  Status status = 5;
+  message Item { int64 count = 1; }
+}
+enum Status { STATUS_UNSPECIFIED = 0; }
+message Audit { bytes data = 1; }`
+
+// testReportFiles files of the package report and of its google.type import
+func testReportFiles(t *testing.T) (report, types protoreflect.FileDescriptor) {
+	t.Helper()
+	fd := compileProto(t, testReportProto)
+	for i := 0; i < fd.Imports().Len(); i++ {
+		if imported := fd.Imports().Get(i); imported.Package() == "google.type" {
+			return fd, imported.FileDescriptor
+		}
+	}
+	t.Fatal("google.type not imported")
+	return nil, nil
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	fd, types := testReportFiles(t)
+	for _, c := range []struct {
+		name     string
+		files    []protoreflect.FileDescriptor
+		contains []string
+		excludes []string
+	}{
+		{"package", []protoreflect.FileDescriptor{fd}, []string{
+			`<title>report validation rules</title>`,
+			`<li><a href="#report.Audit">report.Audit</a></li>
+<li><a href="#report.Order">report.Order</a></li>
+<li><a href="#report.Order.Item">report.Order.Item</a></li>`,
+			`<tr><td>1</td><td>id</td><td>string</td><td><code>regex: &#34;^&lt;[a-z]&#43;&gt;$&#34;`,
+			`<td><a href="#report.Order.Item">repeated report.Order.Item</a></td><td><code>repeated_count_max: 3`,
+			`<td><a href="#report.Order.Item">map&lt;string, report.Order.Item&gt;</a></td>`,
+			`<td>google.type.Money</td>`,
+			`<td>report.Status</td>`,
+			`<td>bytes</td><td><code></code></td>`,
+		}, []string{"ByKeyEntry", "google.type.Money</a>"}},
+		{"linked package", []protoreflect.FileDescriptor{fd, types}, []string{
+			`<td><a href="google.type.html#google.type.Money">google.type.Money</a></td>`,
+		}, []string{`id="google.type.Money"`}},
+		{"no file of the package", []protoreflect.FileDescriptor{types}, []string{`<h1>report</h1>`}, []string{"<h2"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteHTMLReport(&buf, "report", c.files...); err != nil {
+				t.Fatal(err)
+			}
+			page := buf.String()
+			for _, s := range c.contains {
+				if !strings.Contains(page, s) {
+					t.Errorf("missing %s", s)
+				}
+			}
+			for _, s := range c.excludes {
+				if strings.Contains(page, s) {
+					t.Errorf("unexpected %s", s)
+				}
+			}
+			if t.Failed() {
+				t.Log(page)
+			}
+		})
+	}
+}
+
+func TestWriteHTMLReports(t *testing.T) {
+	fd, types := testReportFiles(t)
+	dir := t.TempDir()
+	if err := WriteHTMLReports(dir, fd, types); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != "google.type.html,report.html" {
+		t.Fatal(names)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "google.type.html"))
+	if err != nil || !bytes.Contains(page, []byte(`<h2 id="google.type.Money">google.type.Money</h2>`)) {
+		t.Fatal(err)
+	}
+	if err := WriteHTMLReports(filepath.Join(dir, "missing"), fd); err == nil {
+		t.Fatal("want an error writing into a missing directory")
+	}
+}
+
+func TestReportFile(t *testing.T) {
+	for pkg, file := range map[protoreflect.FullName]string{"": "_.html", "a": "a.html", "a.b.v1": "a.b.v1.html"} {
+		if got := reportFile(pkg); got != file {
+			t.Errorf("%s: %s", pkg, got)
+		}
+	}
+}
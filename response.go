@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"fmt"
+)
+
+// ResponseMode handling of the responses by the middlewares validating requests (e.g. kit and grpcvalid).
+// Every middleware defaults to ResponseSkip, the zero value: responses are only validated when a mode is selected.
+type ResponseMode int
+
+const (
+	// ResponseSkip responses are not validated, the default
+	ResponseSkip ResponseMode = iota
+	// ResponseFailClosed an invalid response is replaced by an error
+	ResponseFailClosed
+	// ResponseLogOnly an invalid response is logged and returned, e.g. while rolling out response rules
+	ResponseLogOnly
+)
+
+// String implement fmt.Stringer
+func (m ResponseMode) String() string {
+	switch m {
+	case ResponseFailClosed:
+		return "fail_closed"
+	case ResponseLogOnly:
+		return "log_only"
+	case ResponseSkip:
+		return "skip"
+	}
+	return fmt.Sprintf("ResponseMode(%d)", int(m))
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+)
+
+// Result verify a proto message with the default validator and describe the result as a message
+func Result(msg proto.Message) (*ValidationResult, error) {
+	return std().Result(msg)
+}
+
+// Result verify a proto message and describe every violation as a ValidationResult message,
+// e.g. to persist it or to send it to another service. Violations of shadow rules are included as warnings.
+// The error reports a failure other than a violation.
+func (v *Validator) Result(msg proto.Message) (*ValidationResult, error) {
+	result := &ValidationResult{}
+	if msg == nil {
+		return result, nil
+	}
+	result.Message = proto.String(string(msg.ProtoReflect().Descriptor().FullName()))
+	var errs, warnings []error
+	if err := v.run(&validator{
+		Validator: v,
+		msg:       msg.ProtoReflect(),
+		errs:      &errs,
+		warnings:  &warnings,
+	}); err != nil {
+		errs = append(errs, err)
+	}
+
+	var other []error
+	for _, err := range append(errs, warnings...) {
+		var e *ValidError
+		switch {
+		case errors.As(err, &e):
+			result.Violations = append(result.Violations, e.ResultViolation())
+		case errors.Is(err, ErrViolationsTruncated):
+			result.Truncated = proto.Bool(true)
+		default:
+			other = append(other, err)
+		}
+	}
+	return result, errors.Join(other...)
+}
+
+// ResultViolation describe the violation as a message of a ValidationResult
+func (e *ValidError) ResultViolation() *ValidationResult_Violation {
+	severity := Severity_SEVERITY_ERROR
+	if e.shadow {
+		severity = Severity_SEVERITY_WARNING
+	}
+	return &ValidationResult_Violation{
+		Path:       proto.String(e.Path()),
+		Rule:       proto.String(e.validKey),
+		Constraint: proto.String(fmt.Sprint(e.validValue)),
+		Actual:     proto.String(fmt.Sprint(e.fieldValue)),
+		Message:    proto.String(e.Message()),
+		Severity:   severity.Enum(),
+		Count:      proto.Int32(int32(e.Count())),
+		Provenance: proto.String(e.Provenance().String()),
+	}
+}
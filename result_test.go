@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package result; import "validator.proto";
+message User {
+  string name = 1 [(validator.field) = {length_gt: 1}];
+  string nick = 2 [(validator.field) = {length_lt: 4, shadow: true}];
+}`)
+	result, err := New().Result(newMsg(t, fd, "User", `{"name":"a","nick":"toolong"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GetMessage() != "result.User" || result.GetTruncated() {
+		t.Fatalf("unexpected result %v", result)
+	}
+	want := map[string]Severity{"name": Severity_SEVERITY_ERROR, "nick": Severity_SEVERITY_WARNING}
+	if len(result.GetViolations()) != len(want) {
+		t.Fatalf("want %d violations, got %v", len(want), result.GetViolations())
+	}
+	for _, violation := range result.GetViolations() {
+		if severity, ok := want[violation.GetPath()]; !ok || violation.GetSeverity() != severity {
+			t.Errorf("unexpected violation %v", violation)
+		}
+	}
+
+	// the result survives a round trip over the wire
+	b, err := proto.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &ValidationResult{}
+	if err := proto.Unmarshal(b, decoded); err != nil || !proto.Equal(result, decoded) {
+		t.Fatalf("round trip: %v, %v", decoded, err)
+	}
+
+	result, err = New().Result(newMsg(t, fd, "User", `{"name":"ab"}`))
+	if err != nil || len(result.GetViolations()) != 0 {
+		t.Fatalf("legal message: %v, %v", result, err)
+	}
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Sampler decide whether a violation is passed to the OnViolation hook and the audit sink,
+// so a flood of invalid traffic doesn't overwhelm logging systems. The validation result is never sampled.
+type Sampler interface {
+	Sample(err *ValidError) bool
+}
+
+// SamplerFunc function implementing Sampler
+type SamplerFunc func(err *ValidError) bool
+
+// Sample implement Sampler
+func (f SamplerFunc) Sample(err *ValidError) bool {
+	return f(err)
+}
+
+// WithViolationSampler pass only the violations sampled by sampler to the OnViolation hook and the audit sink
+func WithViolationSampler(sampler Sampler) Option {
+	return func(v *Validator) {
+		v.sampler = sampler
+	}
+}
+
+// RateSampler sample a random fraction rate (0 to 1) of the violations
+func RateSampler(rate float64) Sampler {
+	return SamplerFunc(func(*ValidError) bool {
+		return rand.Float64() < rate
+	})
+}
+
+// RuleCapSampler sample at most n violations of every rule of every field per window,
+// e.g. the first 10 regex violations of a field per minute
+func RuleCapSampler(n int, window time.Duration) Sampler {
+	return &ruleCapSampler{
+		n:       n,
+		window:  window,
+		clock:   time.Now,
+		windows: make(map[ruleCapKey]*ruleCapWindow),
+	}
+}
+
+// ruleCapKey rule of a field
+type ruleCapKey struct {
+	field string
+	rule  string
+}
+
+// ruleCapWindow sampled violations of a rule in the current window
+type ruleCapWindow struct {
+	start time.Time
+	count int
+}
+
+// ruleCapSampler sampler capping the violations per rule and window
+type ruleCapSampler struct {
+	n       int
+	window  time.Duration
+	clock   func() time.Time
+	mu      sync.Mutex
+	windows map[ruleCapKey]*ruleCapWindow
+}
+
+// Sample implement Sampler
+func (s *ruleCapSampler) Sample(err *ValidError) bool {
+	key := ruleCapKey{field: string(err.field.FullName()), rule: err.validKey}
+	now := s.clock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.windows[key]
+	if w == nil || now.Sub(w.start) >= s.window {
+		w = &ruleCapWindow{start: now}
+		s.windows[key] = w
+	}
+	if w.count >= s.n {
+		return false
+	}
+	w.count++
+	return true
+}
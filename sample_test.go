@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestViolationSampler(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package sample; import "validator.proto";
+message Item {
+  string sku = 1 [(validator.field) = {length_gt: 1}];
+  string name = 2 [(validator.field) = {length_gt: 1, regex: "^[a-z]+$"}];
+}`)
+	type step struct {
+		after time.Duration
+		json  string
+	}
+	illegal := `{"sku":"a","name":"B"}`
+	for _, c := range []struct {
+		name    string
+		sampler func(clock func() time.Time) Sampler
+		steps   []step
+		sampled int
+	}{
+		{"no sampler", nil, []step{{0, illegal}, {0, illegal}}, 6},
+		{"rate 0", func(func() time.Time) Sampler { return RateSampler(0) }, []step{{0, illegal}, {0, illegal}}, 0},
+		{"rate 1", func(func() time.Time) Sampler { return RateSampler(1) }, []step{{0, illegal}, {0, illegal}}, 6},
+		{"cap 0", capSampler(0, time.Minute), []step{{0, illegal}}, 0},
+		//each rule of each field is capped on its own
+		{"cap 1", capSampler(1, time.Minute), []step{{0, illegal}, {time.Second, illegal}}, 3},
+		{"cap 2", capSampler(2, time.Minute), []step{{0, illegal}, {0, illegal}, {0, illegal}}, 6},
+		{"cap per rule", capSampler(1, time.Minute), []step{{0, `{"sku":"a","name":"ab"}`}, {0, illegal}}, 3},
+		{"just before the window ends", capSampler(1, time.Minute), []step{{0, illegal}, {time.Minute - time.Nanosecond, illegal}}, 3},
+		{"new window", capSampler(1, time.Minute), []step{{0, illegal}, {time.Minute, illegal}, {0, illegal}}, 6},
+		{"legal messages", capSampler(1, time.Minute), []step{{0, `{"sku":"ab","name":"ab"}`}}, 0},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			sampled := 0
+			opts := []Option{WithOnViolation(func(*ValidError) { sampled++ })}
+			if c.sampler != nil {
+				opts = append(opts, WithViolationSampler(c.sampler(func() time.Time { return now })))
+			}
+			v := New(opts...)
+			for _, s := range c.steps {
+				now = now.Add(s.after)
+				//the validation result is never sampled
+				err := v.ValidateAll(newMsg(t, fd, "Item", s.json))
+				if want := s.json != `{"sku":"ab","name":"ab"}`; (err != nil) != want {
+					t.Fatal(err)
+				}
+			}
+			if sampled != c.sampled {
+				t.Errorf("got %d sampled violations, want %d", sampled, c.sampled)
+			}
+		})
+	}
+}
+
+// capSampler RuleCapSampler reading the time from clock
+func capSampler(n int, window time.Duration) func(clock func() time.Time) Sampler {
+	return func(clock func() time.Time) Sampler {
+		s := RuleCapSampler(n, window).(*ruleCapSampler)
+		s.clock = clock
+		return s
+	}
+}
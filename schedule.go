@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+	"time"
+)
+
+// checkSchedule check time of day and day of week strings
+func (v *validator) checkSchedule(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.GetStringTimeOfDay() && !validTimeOfDay(value) {
+		if err := v.fail(field, "StringTimeOfDay", *rule.StringTimeOfDay, value); err != nil {
+			return err
+		}
+	}
+	if rule.GetStringDayOfWeek() && !validDayOfWeek(value) {
+		if err := v.fail(field, "StringDayOfWeek", *rule.StringDayOfWeek, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validTimeOfDay whether a string is HH:MM or HH:MM:SS on a 24-hour clock
+func validTimeOfDay(value string) bool {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return false
+	}
+	for i, part := range parts {
+		max := 59
+		if i == 0 {
+			max = 23
+		}
+		if len(part) != 2 || part[0] < '0' || part[0] > '9' || part[1] < '0' || part[1] > '9' {
+			return false
+		}
+		if n := int(part[0]-'0')*10 + int(part[1]-'0'); n > max {
+			return false
+		}
+	}
+	return true
+}
+
+// validDayOfWeek whether a string is an English day of the week, full or abbreviated to 3 letters, case-insensitive
+func validDayOfWeek(value string) bool {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := day.String()
+		if strings.EqualFold(value, name) || strings.EqualFold(value, name[:3]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package validator
+
+import "testing"
+
+func TestSchedule(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package schedule; import "validator.proto";
+message Opening {
+  string at = 1 [(validator.field) = {string_time_of_day: true}];
+  string day = 2 [(validator.field) = {string_day_of_week: true}];
+  string any = 3 [(validator.field) = {string_time_of_day: false, string_day_of_week: false}];
+  repeated string days = 4 [(validator.field) = {string_day_of_week: true}];
+}`)
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"legal", `{"at":"09:30","day":"Monday"}`, "", ""},
+		{"midnight", `{"at":"00:00","day":"mon"}`, "", ""},
+		{"last second", `{"at":"23:59:59","day":"SUNDAY"}`, "", ""},
+		{"hour 24", `{"at":"24:00","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"minute 60", `{"at":"12:60","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"second 60", `{"at":"12:00:60","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"25:61", `{"at":"25:61","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"single digit hour", `{"at":"9:30","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"hour only", `{"at":"09","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"fraction", `{"at":"09:30:00:00","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"12-hour clock", `{"at":"09:30 PM","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"sign", `{"at":"+9:30","day":"mon"}`, "at", "StringTimeOfDay"},
+		{"unset time", `{"day":"mon"}`, "at", "StringTimeOfDay"},
+		{"abbreviation in other case", `{"at":"09:30","day":"tHu"}`, "", ""},
+		{"two letters", `{"at":"09:30","day":"mo"}`, "day", "StringDayOfWeek"},
+		{"four letters", `{"at":"09:30","day":"tues"}`, "day", "StringDayOfWeek"},
+		{"other language", `{"at":"09:30","day":"lundi"}`, "day", "StringDayOfWeek"},
+		{"padded", `{"at":"09:30","day":" monday"}`, "day", "StringDayOfWeek"},
+		{"unset day", `{"at":"09:30"}`, "day", "StringDayOfWeek"},
+		{"disabled rules", `{"at":"09:30","day":"mon","any":"x"}`, "", ""},
+		{"days", `{"at":"09:30","day":"mon","days":["sat","Sunday"]}`, "", ""},
+		{"illegal element", `{"at":"09:30","day":"mon","days":["sat","sun."]}`, "days[1]", "StringDayOfWeek"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().Validate(newMsg(t, fd, "Opening", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
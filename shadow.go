@@ -0,0 +1,13 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// validShadow evaluate the shadow rule of a field, violations are only reported to the OnViolation hook
+func (v *validator) validShadow(field protoreflect.FieldDescriptor, value protoreflect.Value, rule *FieldValidator) {
+	sv := *v
+	sv.shadow = true
+	sv.errorMessage, sv.humanError = rule.GetErrorMessage(), rule.GetHumanError()
+	_ = sv.validValue(field, value, rule)
+}
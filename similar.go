@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// DenylistSource source of the denylists of the string_not_similar rule, e.g. a moderation service.
+// Fetch is called per checked value, cache the lists in the source if fetching them is costly.
+type DenylistSource interface {
+	// Fetch fetch the entries of the denylist named name
+	Fetch(ctx context.Context, name string) ([]string, error)
+}
+
+// DenylistSourceFunc adapter to use a function as a DenylistSource
+type DenylistSourceFunc func(ctx context.Context, name string) ([]string, error)
+
+// Fetch implement DenylistSource
+func (f DenylistSourceFunc) Fetch(ctx context.Context, name string) ([]string, error) {
+	return f(ctx, name)
+}
+
+// StaticDenylists fixed denylists keyed by name
+type StaticDenylists map[string][]string
+
+// Fetch implement DenylistSource
+func (s StaticDenylists) Fetch(_ context.Context, name string) ([]string, error) {
+	entries, ok := s[name]
+	if !ok {
+		return nil, fmt.Errorf("denylist[%s] not found", name)
+	}
+	return entries, nil
+}
+
+// WithDenylistSource provide the denylists of the string_not_similar rule
+func WithDenylistSource(source DenylistSource) Option {
+	return func(v *Validator) {
+		v.denylists = source
+	}
+}
+
+// checkSimilar check that a string is not too similar to an entry of the denylist,
+// a missing denylist source or a denylist that can't be fetched rejects every value
+func (v *validator) checkSimilar(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	similar := rule.StringNotSimilar
+	if similar == nil {
+		return nil
+	}
+	if v.denylists == nil {
+		v.warnf("[pb valid]field[%s] denylist[%s] without denylist source", field.FullName(), similar.GetDenylist())
+		v.fault(&Fault{Kind: FaultConfig, Err: fmt.Errorf("field[%s] denylist[%s] without denylist source", field.FullName(), similar.GetDenylist())})
+		//reported by Register, fail closed instead of skipping the check
+		return v.fail(field, "StringNotSimilar", similar.GetDenylist(), "denylist source not set")
+	}
+	ctx := v.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	entries, err := v.denylists.Fetch(ctx, similar.GetDenylist())
+	if err != nil {
+		v.warnf("[pb valid]fetch denylist[%s] err: %s", similar.GetDenylist(), err)
+		v.fault(&Fault{Kind: FaultSource, Err: fmt.Errorf("fetch denylist[%s]: %w", similar.GetDenylist(), err)})
+		return v.fail(field, "StringNotSimilar", similar.GetDenylist(), "denylist not fetched")
+	}
+	if similar.GetIgnoreCase() {
+		value = strings.ToLower(value)
+	}
+	limit := int(similar.GetMaxDistance())
+	for _, entry := range entries {
+		if similar.GetIgnoreCase() {
+			entry = strings.ToLower(entry)
+		}
+		if levenshtein([]rune(value), []rune(entry), limit) <= limit {
+			return v.fail(field, "StringNotSimilar", similar.GetDenylist(), value)
+		}
+	}
+	return nil
+}
+
+// levenshtein edit distance of a and b, any distance above limit is returned as limit+1
+func levenshtein(a, b []rune, limit int) int {
+	if d := len(a) - len(b); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if cur[j] < best {
+				best = cur[j]
+			}
+		}
+		if best > limit {
+			//every later row is at least as far
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	if prev[len(b)] > limit {
+		return limit + 1
+	}
+	return prev[len(b)]
+}
+
+// minInt smallest of values
+func minInt(values ...int) int {
+	m := values[0]
+	for _, x := range values[1:] {
+		if x < m {
+			m = x
+		}
+	}
+	return m
+}
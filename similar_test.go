@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSimilar(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package similar; import "validator.proto";
+message User { string name = 1 [(validator.field) = {string_not_similar: {denylist: "staff", max_distance: 1, ignore_case: true}}]; }`)
+	staff := WithDenylistSource(StaticDenylists{"staff": {"admin"}})
+	broken := WithDenylistSource(DenylistSourceFunc(func(context.Context, string) ([]string, error) {
+		return nil, errors.New("unavailable")
+	}))
+	for _, c := range []struct {
+		name, json string
+		opts       []Option
+		compileErr bool
+		illegal    bool
+	}{
+		{"legal", `{"name":"alice"}`, []Option{staff}, false, false},
+		{"similar", `{"name":"Admln"}`, []Option{staff}, false, true},
+		{"fetch error", `{"name":"alice"}`, []Option{broken}, false, true},
+		{"no source", `{"name":"alice"}`, nil, true, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(c.opts...)
+			if err := v.Register(fd.Messages().ByName("User")); (err != nil) != c.compileErr {
+				t.Fatalf("Register: %v", err)
+			}
+			err := v.Validate(newMsg(t, fd, "User", c.json))
+			if !c.illegal && err != nil || c.illegal && !MatchViolation(err, "name", "StringNotSimilar") {
+				t.Fatal(err)
+			}
+		})
+	}
+}
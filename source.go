@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RuleSource source of rule sets, e.g. a central config service
+type RuleSource interface {
+	// Fetch fetch the rule set applied to the message named messageName (full name),
+	// nil if the message has no overlaid rules
+	Fetch(ctx context.Context, messageName string) (*RuleSet, error)
+}
+
+// RuleSourceFunc adapter to use a function as a RuleSource
+type RuleSourceFunc func(ctx context.Context, messageName string) (*RuleSet, error)
+
+// Fetch implement RuleSource
+func (f RuleSourceFunc) Fetch(ctx context.Context, messageName string) (*RuleSet, error) {
+	return f(ctx, messageName)
+}
+
+// ruleEntry cached rule set of a message
+type ruleEntry struct {
+	rules   *RuleSet
+	expires time.Time
+}
+
+// CachedRuleSource cache the rule sets fetched from a source for a ttl.
+// If a refresh fails the stale rule set is served for another ttl.
+type CachedRuleSource struct {
+	source  RuleSource
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*ruleEntry
+}
+
+// NewCachedRuleSource create a caching wrapper of source
+func NewCachedRuleSource(source RuleSource, ttl time.Duration) *CachedRuleSource {
+	return &CachedRuleSource{
+		source:  source,
+		ttl:     ttl,
+		entries: make(map[string]*ruleEntry),
+	}
+}
+
+// Fetch implement RuleSource
+func (c *CachedRuleSource) Fetch(ctx context.Context, messageName string) (*RuleSet, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[messageName]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.rules, nil
+	}
+
+	rules, err := c.source.Fetch(ctx, messageName)
+	if err != nil {
+		if !ok {
+			return nil, err
+		}
+		warnf("[pb valid]refresh rule set of msg[%s] err: %s, keep the stale one", messageName, err)
+		//retry after another ttl instead of on every call
+		rules = entry.rules
+	}
+
+	c.mu.Lock()
+	c.entries[messageName] = &ruleEntry{
+		rules:   rules,
+		expires: now.Add(c.ttl),
+	}
+	c.mu.Unlock()
+	return rules, nil
+}
+
+// Invalidate drop the cached rule set of a message, or of every message if messageName is empty
+func (c *CachedRuleSource) Invalidate(messageName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if messageName == "" {
+		c.entries = make(map[string]*ruleEntry)
+		return
+	}
+	delete(c.entries, messageName)
+}
+
+// WithRuleSource overlay the rule set fetched from source for the validated message,
+// for the calls of ValidateContext without an overlay selected by the context.
+// Wrap the source with NewCachedRuleSource to avoid a fetch per call.
+func WithRuleSource(source RuleSource) Option {
+	return func(v *Validator) {
+		v.source = source
+	}
+}
+
+// sourceOverlay get the overlay of the rule set fetched from the rule source, nil if none.
+// Compiled programs are kept as long as the source returns the same rule set.
+func (v *Validator) sourceOverlay(ctx context.Context, messageName string) *overlay {
+	rules, err := v.source.Fetch(ctx, messageName)
+	if err != nil {
+		v.warnf("[pb valid]fetch rule set of msg[%s] err: %s", messageName, err)
+		v.fault(&Fault{Kind: FaultSource, Message: messageName, Err: err})
+		return nil
+	}
+	if rules == nil {
+		return nil
+	}
+	if x, ok := v.sourced.Load(messageName); ok && x.(*overlay).rules == rules {
+		return x.(*overlay)
+	}
+	o := &overlay{
+		rules: rules,
+	}
+	v.sourced.Store(messageName, o)
+	return o
+}
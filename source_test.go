@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+// expire expire the cached rule set of a message
+func (c *CachedRuleSource) expire(messageName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[messageName].expires = time.Now().Add(-time.Second)
+}
+
+func TestCachedRuleSource(t *testing.T) {
+	logger := &testLogger{}
+	SetLogger(logger)
+	defer SetLogger(nil)
+	ctx := context.Background()
+	var (
+		calls int
+		rules = &RuleSet{Name: proto.String("v1")}
+		err   error
+	)
+	cached := NewCachedRuleSource(RuleSourceFunc(func(context.Context, string) (*RuleSet, error) {
+		calls++
+		return rules, err
+	}), time.Hour)
+	fetch := func(wantCalls int, want *RuleSet) {
+		t.Helper()
+		got, err := cached.Fetch(ctx, "pkg.Order")
+		if err != nil || got != want || calls != wantCalls {
+			t.Fatalf("got %v %v after %d calls, want %v after %d", got, err, calls, want, wantCalls)
+		}
+	}
+	v1 := rules
+	fetch(1, v1)
+	//hit
+	fetch(1, v1)
+	//refresh after the ttl
+	v2 := &RuleSet{Name: proto.String("v2")}
+	rules = v2
+	fetch(1, v1)
+	cached.expire("pkg.Order")
+	fetch(2, v2)
+	//a failed refresh keeps the last good rules for another ttl
+	rules, err = nil, errors.New("unavailable")
+	cached.expire("pkg.Order")
+	fetch(3, v2)
+	fetch(3, v2)
+	if len(logger.warnings) != 1 {
+		t.Fatal(logger.warnings)
+	}
+	//without good rules the error is returned, and not cached
+	for i := 0; i < 2; i++ {
+		if got, err := cached.Fetch(ctx, "pkg.Item"); got != nil || err == nil {
+			t.Fatal(got, err)
+		}
+	}
+	if calls != 5 {
+		t.Fatal(calls)
+	}
+	//invalidated rules are fetched again
+	rules, err = v1, nil
+	cached.Invalidate("pkg.Order")
+	fetch(6, v1)
+	rules = v2
+	cached.Invalidate("")
+	fetch(7, v2)
+}
+
+func TestCachedRuleSourceValidation(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package source;
+message Order { string sku = 1; }`)
+	gt := int64(2)
+	var err error
+	cached := NewCachedRuleSource(RuleSourceFunc(func(context.Context, string) (*RuleSet, error) {
+		if err != nil {
+			return nil, err
+		}
+		return &RuleSet{Messages: map[string]*MessageRules{"source.Order": {Fields: map[string]*FieldValidator{"sku": {LengthGt: &gt}}}}}, nil
+	}), time.Hour)
+	var faults []*Fault
+	v := New(WithRuleSource(cached), WithFaultReporter(func(fault *Fault) { faults = append(faults, fault) }))
+	msg := newMsg(t, fd, "Order", `{"sku":"ab"}`)
+	if err := v.ValidateContext(context.Background(), msg); !MatchViolation(err, "sku", "LengthGt") {
+		t.Fatal(err)
+	}
+	//the source fails, the cached rules are still enforced without fault
+	err = errors.New("unavailable")
+	cached.expire("source.Order")
+	if err := v.ValidateContext(context.Background(), msg); !MatchViolation(err, "sku", "LengthGt") {
+		t.Fatal(err)
+	}
+	if len(faults) != 0 {
+		t.Fatal(faults)
+	}
+	//without cached rules the failure is a fault, and the annotated rules only are enforced
+	cached.Invalidate("")
+	if err := v.ValidateContext(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(faults) != 1 || faults[0].Kind != FaultSource || faults[0].Message != "source.Order" {
+		t.Fatal(faults)
+	}
+}
@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// statsSamples number of recent durations kept per message type for the percentiles
+const statsSamples = 1024
+
+// statsTopRules number of top failing rules reported per message type
+const statsTopRules = 5
+
+// MessageStats validation statistics of a message type
+type MessageStats struct {
+	// Message full name of the message type
+	Message string `json:"message"`
+	// Validations number of validations
+	Validations int64 `json:"validations"`
+	// Failures number of failed validations
+	Failures int64 `json:"failures"`
+	// TopRules most violated rules, most violated first
+	TopRules []RuleCount `json:"top_rules,omitempty"`
+	// P99 99th percentile of the duration of the recent validations
+	P99 time.Duration `json:"p99"`
+}
+
+// RuleCount number of violations of a rule
+type RuleCount struct {
+	// Rule field full name and rule name, e.g. "example.Order.sku:Regex"
+	Rule  string `json:"rule"`
+	Count int64  `json:"count"`
+}
+
+// messageStats accumulated statistics of a message type
+type messageStats struct {
+	mu          sync.Mutex
+	validations int64
+	failures    int64
+	rules       map[string]int64
+	durations   [statsSamples]time.Duration
+}
+
+// WithStats accumulate per message type validation statistics, see Stats
+func WithStats() Option {
+	return func(v *Validator) {
+		v.statsEnabled = true
+	}
+}
+
+// record record a validation of the message of w
+func (v *Validator) record(w *validator, start time.Time, err error) {
+	elapsed := time.Since(start)
+	if err == nil && w.errs != nil {
+		//violations collected by ValidateAll
+		err = errors.Join(*w.errs...)
+	}
+	name := messageName(w.msg)
+	x, ok := v.stats.Load(name)
+	if !ok {
+		x, _ = v.stats.LoadOrStore(name, &messageStats{rules: make(map[string]int64)})
+	}
+	s := x.(*messageStats)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.durations[s.validations%statsSamples] = elapsed
+	s.validations++
+	if err == nil {
+		return
+	}
+	s.failures++
+	walkErrors(err, func(err error) bool {
+		if e, ok := err.(*ValidError); ok {
+			s.rules[string(e.field.FullName())+":"+e.validKey]++
+		}
+		return true
+	})
+}
+
+// Stats validation statistics per message type accumulated since WithStats or ResetStats, sorted by message name
+func (v *Validator) Stats() []MessageStats {
+	var out []MessageStats
+	v.stats.Range(func(key, value interface{}) bool {
+		s := value.(*messageStats)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		stats := MessageStats{
+			Message:     key.(string),
+			Validations: s.validations,
+			Failures:    s.failures,
+		}
+		for rule, count := range s.rules {
+			stats.TopRules = append(stats.TopRules, RuleCount{Rule: rule, Count: count})
+		}
+		sort.Slice(stats.TopRules, func(i, j int) bool {
+			if stats.TopRules[i].Count != stats.TopRules[j].Count {
+				return stats.TopRules[i].Count > stats.TopRules[j].Count
+			}
+			return stats.TopRules[i].Rule < stats.TopRules[j].Rule
+		})
+		if len(stats.TopRules) > statsTopRules {
+			stats.TopRules = stats.TopRules[:statsTopRules]
+		}
+
+		n := s.validations
+		if n > statsSamples {
+			n = statsSamples
+		}
+		durations := append([]time.Duration(nil), s.durations[:n]...)
+		sort.Slice(durations, func(i, j int) bool {
+			return durations[i] < durations[j]
+		})
+		if n > 0 {
+			stats.P99 = durations[(n*99+99)/100-1]
+		}
+		out = append(out, stats)
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Message < out[j].Message
+	})
+	return out
+}
+
+// ResetStats drop the accumulated validation statistics
+func (v *Validator) ResetStats() {
+	v.stats.Range(func(key, _ interface{}) bool {
+		v.stats.Delete(key)
+		return true
+	})
+}
@@ -0,0 +1,16 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// StatsHandler admin endpoint serving Stats as JSON
+func (v *Validator) StatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v.Stats())
+	})
+}
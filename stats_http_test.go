@@ -0,0 +1,32 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatsHandler(t *testing.T) {
+	fd := compileProto(t, testStatsProto)
+	v := New(WithStats())
+	_ = v.Validate(newMsg(t, fd, "Item", `{}`))
+
+	rec := httptest.NewRecorder()
+	v.StatsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/stats", nil))
+	if rec.Code != 200 || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatal(rec.Code, rec.Header())
+	}
+	var stats []map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0]["message"] != "stats.Item" || stats[0]["validations"] != 1.0 || stats[0]["failures"] != 1.0 {
+		t.Fatal(rec.Body.String())
+	}
+	rules, _ := stats[0]["top_rules"].([]interface{})
+	if len(rules) != 1 || rules[0].(map[string]interface{})["rule"] != "stats.Item.a:LengthGt" {
+		t.Fatal(rec.Body.String())
+	}
+}
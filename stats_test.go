@@ -0,0 +1,95 @@
+package validator
+
+import "testing"
+
+// testStatsProto message with seven rules of distinct fields
+const testStatsProto = `syntax = "proto3"; package stats; import "validator.proto";
+message Item {
+  string a = 1 [(validator.field) = {length_gt: 1}];
+  string b = 2 [(validator.field) = {length_gt: 1}];
+  string c = 3 [(validator.field) = {length_gt: 1}];
+  string d = 4 [(validator.field) = {length_gt: 1}];
+  string e = 5 [(validator.field) = {length_gt: 1}];
+  string f = 6 [(validator.field) = {length_gt: 1}];
+  string g = 7 [(validator.field) = {length_gt: 1, regex: "^[a-z]*$"}];
+}
+message Other { string a = 1; }`
+
+func TestStats(t *testing.T) {
+	fd := compileProto(t, testStatsProto)
+	legal := `{"a":"ab","b":"ab","c":"ab","d":"ab","e":"ab","f":"ab","g":"ab"}`
+	for _, c := range []struct {
+		name        string
+		all         bool
+		msgs        []string
+		validations int64
+		failures    int64
+		rules       []RuleCount
+	}{
+		{"legal", false, []string{legal, legal}, 2, 0, nil},
+		{"first violation", false, []string{`{"g":"AB"}`, legal}, 2, 1, []RuleCount{{"stats.Item.a:LengthGt", 1}}},
+		{"most violated first", true, []string{`{"g":"ab"}`, `{"a":"ab","g":"ab"}`, `{"a":"ab","b":"ab","g":"ab"}`}, 3, 3, []RuleCount{
+			{"stats.Item.c:LengthGt", 3}, {"stats.Item.d:LengthGt", 3}, {"stats.Item.e:LengthGt", 3}, {"stats.Item.f:LengthGt", 3}, {"stats.Item.b:LengthGt", 2}}},
+		{"top five", true, []string{`{"g":"A"}`}, 1, 1, []RuleCount{
+			{"stats.Item.a:LengthGt", 1}, {"stats.Item.b:LengthGt", 1}, {"stats.Item.c:LengthGt", 1}, {"stats.Item.d:LengthGt", 1}, {"stats.Item.e:LengthGt", 1}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(WithStats())
+			for _, msg := range c.msgs {
+				if c.all {
+					_ = v.ValidateAll(newMsg(t, fd, "Item", msg))
+				} else {
+					_ = v.Validate(newMsg(t, fd, "Item", msg))
+				}
+			}
+			stats := v.Stats()
+			if len(stats) != 1 {
+				t.Fatal(stats)
+			}
+			s := stats[0]
+			if s.Message != "stats.Item" || s.Validations != c.validations || s.Failures != c.failures || s.P99 < 0 {
+				t.Fatalf("%+v", s)
+			}
+			if len(s.TopRules) != len(c.rules) {
+				t.Fatalf("got %v, want %v", s.TopRules, c.rules)
+			}
+			for i := range c.rules {
+				if s.TopRules[i] != c.rules[i] {
+					t.Fatalf("got %v, want %v", s.TopRules, c.rules)
+				}
+			}
+		})
+	}
+}
+
+func TestStatsMessages(t *testing.T) {
+	fd := compileProto(t, testStatsProto)
+	//disabled by default
+	v := New()
+	_ = v.Validate(newMsg(t, fd, "Other", `{}`))
+	if stats := v.Stats(); len(stats) != 0 {
+		t.Fatal(stats)
+	}
+
+	v = New(WithStats())
+	if stats := v.Stats(); len(stats) != 0 {
+		t.Fatal(stats)
+	}
+	//more validations than duration samples
+	for i := 0; i < statsSamples+1; i++ {
+		_ = v.Validate(newMsg(t, fd, "Other", `{}`))
+	}
+	_ = v.Validate(newMsg(t, fd, "Item", `{}`))
+	stats := v.Stats()
+	if len(stats) != 2 || stats[0].Message != "stats.Item" || stats[1].Message != "stats.Other" || stats[1].Validations != statsSamples+1 {
+		t.Fatal(stats)
+	}
+	v.ResetStats()
+	if stats := v.Stats(); len(stats) != 0 {
+		t.Fatal(stats)
+	}
+	_ = v.Validate(newMsg(t, fd, "Other", `{}`))
+	if stats := v.Stats(); len(stats) != 1 || stats[0].Validations != 1 {
+		t.Fatal(stats)
+	}
+}
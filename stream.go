@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+)
+
+// StreamResult validation result of a message received from a stream
+type StreamResult struct {
+	// Index position of the message in the stream
+	Index int
+	// Msg the validated message
+	Msg proto.Message
+	// Err validation error, nil if the message is legal
+	Err error
+}
+
+// ValidateStream verify every message received from in with ctx, see ValidateContext, and emit its result in order.
+// The returned channel is unbuffered so a slow consumer applies backpressure to the producer,
+// it is closed when in is closed or ctx is done.
+func (v *Validator) ValidateStream(ctx context.Context, in <-chan proto.Message) <-chan StreamResult {
+	out := make(chan StreamResult)
+	go func() {
+		defer close(out)
+		for i := 0; ; i++ {
+			var msg proto.Message
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			result := StreamResult{
+				Index: i,
+				Msg:   msg,
+				Err:   v.ValidateContext(ctx, msg),
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- result:
+			}
+		}
+	}()
+	return out
+}
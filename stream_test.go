@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestValidateStream(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package stream; import "validator.proto";
+message Item { string name = 1 [(validator.field) = {length_gt: 1}]; }`)
+	in := make(chan proto.Message, 3)
+	for _, json := range []string{`{"name":"ok"}`, `{"name":"x"}`, `{"name":"ok"}`} {
+		in <- newMsg(t, fd, "Item", json)
+	}
+	close(in)
+	//the call options of ctx apply to every message
+	ctx := ContextWithCallOptions(context.Background(), CallPathFormat(PathJSONPointer))
+	var results []StreamResult
+	for result := range New().ValidateStream(ctx, in) {
+		results = append(results, result)
+	}
+	if len(results) != 3 {
+		t.Fatalf("want 3 results, got %d", len(results))
+	}
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("result %d has index %d", i, result.Index)
+		}
+		if legal := i != 1; legal != (result.Err == nil) {
+			t.Errorf("result %d: %v", i, result.Err)
+		}
+	}
+	if !MatchViolation(results[1].Err, "/name", "LengthGt") {
+		t.Errorf("want LengthGt at /name, got %v", results[1].Err)
+	}
+}
+
+func TestValidateStreamCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := New().ValidateStream(ctx, make(chan proto.Message))
+	cancel()
+	if _, ok := <-out; ok {
+		t.Fatal("want the results closed")
+	}
+}
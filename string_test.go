@@ -0,0 +1,56 @@
+package validator
+
+import "testing"
+
+func TestStringAffixes(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package affix; import "validator.proto";
+message Sku {
+  string code = 1 [(validator.field) = {string_prefix: "SKU-", string_suffix: "-EU", string_contains: "00", string_not_contains: "XX"}];
+  string note = 2 [(validator.field) = {string_not_contains: "XX"}];
+  string any = 3 [(validator.field) = {string_prefix: "", string_suffix: "", string_contains: ""}];
+  repeated string tags = 4 [(validator.field) = {string_prefix: "t:"}];
+}`)
+	for _, c := range []struct {
+		name, json, path, rule string
+	}{
+		{"legal", `{"code":"SKU-1001-EU"}`, "", ""},
+		{"affixes only", `{"code":"SKU-00-EU"}`, "", ""},
+		{"overlapping affixes", `{"code":"SKU-EU"}`, "code", "StringContains"},
+		{"prefix", `{"code":"ABC-1001-EU"}`, "code", "StringPrefix"},
+		{"prefix case", `{"code":"sku-1001-EU"}`, "code", "StringPrefix"},
+		{"suffix", `{"code":"SKU-1001-US"}`, "code", "StringSuffix"},
+		{"contains", `{"code":"SKU-1234-EU"}`, "code", "StringContains"},
+		{"not contains", `{"code":"SKU-XX00-EU"}`, "code", "StringNotContains"},
+		{"unset", `{}`, "code", "StringPrefix"},
+		{"empty affixes", `{"code":"SKU-1001-EU","any":"anything"}`, "", ""},
+		{"element", `{"code":"SKU-1001-EU","tags":["t:a","b"]}`, "tags[1]", "StringPrefix"},
+		{"not contains elsewhere", `{"code":"SKU-1001-EU","note":"aXXb"}`, "note", "StringNotContains"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Sku", c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestStringAffixesRuleConfig(t *testing.T) {
+	for _, c := range []struct {
+		name, field string
+		invalid     bool
+	}{
+		{"string", "string", false},
+		{"repeated string", "repeated string", false},
+		{"bytes", "bytes", true},
+		{"integer", "int64", true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fd := compileProto(t, `syntax = "proto3"; package affix; import "validator.proto";
+message Sku { `+c.field+` code = 1 [(validator.field) = {string_prefix: "SKU-", string_not_contains: "XX"}]; }`)
+			if err := New(WithStrictTyping()).Register(fd.Messages().ByName("Sku")); (err != nil) != c.invalid {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+const trustProto = `syntax = "proto3"; package trust; import "validator.proto";
+message Blob { string x = 1 [(validator.field) = {string_not_empty: true}]; }
+message Req {
+  Blob blob = 1 [(validator.field) = {pre_validated: true}];
+  int32 n = 2 [(validator.field) = {int_gt: 0}];
+}`
+
+func TestTrustToken(t *testing.T) {
+	fd := compileProto(t, trustProto)
+	now := time.Unix(1000, 0)
+	key := []byte("k")
+	const method = "/trust.Service/Create"
+	v := New(WithTrustKey(key), WithClock(func() time.Time { return now }))
+	msg := newMsg(t, fd, "Req", `{"blob":{},"n":1}`)
+	sign := func(key []byte, method string, msg proto.Message, expires time.Time) string {
+		token, err := SignTrustToken(key, method, msg, expires)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	for _, c := range []struct {
+		name    string
+		ctx     context.Context
+		trusted bool
+	}{
+		{"token", ContextWithTrustToken(context.Background(), method, sign(key, method, msg, now.Add(time.Minute))), true},
+		{"no token", context.Background(), false},
+		{"other key", ContextWithTrustToken(context.Background(), method, sign([]byte("other"), method, msg, now.Add(time.Minute))), false},
+		{"expired", ContextWithTrustToken(context.Background(), method, sign(key, method, msg, now.Add(-time.Minute))), false},
+		{"other method", ContextWithTrustToken(context.Background(), "/trust.Service/Delete", sign(key, method, msg, now.Add(time.Minute))), false},
+		{"other message", ContextWithTrustToken(context.Background(), method, sign(key, method, newMsg(t, fd, "Req", `{"blob":{},"n":2}`), now.Add(time.Minute))), false},
+		{"garbage", ContextWithTrustToken(context.Background(), method, "garbage"), false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := v.ValidateContext(c.ctx, msg)
+			if c.trusted && err != nil || !c.trusted && !MatchViolation(err, "blob.x", "StringNotEmpty") {
+				t.Fatal(err)
+			}
+		})
+	}
+}
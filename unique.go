@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkUniqueBy check that the repeated_unique_by field of the elements of a repeated message field is unique,
+// the violation is reported on the field of the duplicate element
+func (v *validator) checkUniqueBy(field protoreflect.FieldDescriptor, list protoreflect.List, rule *FieldValidator) error {
+	if rule.RepeatedUniqueBy == nil || field.Kind() != protoreflect.MessageKind || list.Len() == 0 {
+		return nil
+	}
+	key := uniqueByField(field, *rule.RepeatedUniqueBy)
+	if key == nil {
+		//reported by Register, fail closed instead of skipping the check
+		err := fmt.Errorf("repeated_unique_by[%s] is not a singular scalar field of %s", *rule.RepeatedUniqueBy, field.Message().FullName())
+		v.warnf("[pb valid]field[%s] %s", field.FullName(), err)
+		v.fault(&Fault{Kind: FaultConfig, Message: string(field.ContainingMessage().FullName()), Err: err})
+		return v.fail(field, "RepeatedUniqueBy", *rule.RepeatedUniqueBy, "unique key not found")
+	}
+
+	seen := make(map[interface{}]bool, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		elem := list.Get(i).Message()
+		if !elem.Has(key) {
+			continue
+		}
+		value := elem.Get(key)
+		k := value.Interface()
+		if key.Kind() == protoreflect.BytesKind {
+			k = string(value.Bytes())
+		}
+		if !seen[k] {
+			seen[k] = true
+			continue
+		}
+		sub := *v
+		sub.path, sub.elem = appendPath(v.path, PathElement{Field: field, Index: i}), PathElement{}
+		if err := sub.fail(key, "RepeatedUniqueBy", *rule.RepeatedUniqueBy, value.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniqueByField the singular scalar field named name of the elements of a repeated message field, nil if none
+func uniqueByField(field protoreflect.FieldDescriptor, name string) protoreflect.FieldDescriptor {
+	key := field.Message().Fields().ByName(protoreflect.Name(name))
+	if key == nil || key.IsList() || key.IsMap() || key.Kind() == protoreflect.MessageKind || key.Kind() == protoreflect.GroupKind {
+		return nil
+	}
+	return key
+}
+
+// checkUniqueByRule check that repeated_unique_by names a singular scalar field of the elements
+func checkUniqueByRule(field protoreflect.FieldDescriptor, rule *FieldValidator) error {
+	if rule.RepeatedUniqueBy == nil || !field.IsList() || field.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+	if uniqueByField(field, *rule.RepeatedUniqueBy) == nil {
+		return fmt.Errorf("[proto valid]field[%s] repeated_unique_by[%s] is not a singular scalar field of %s",
+			field.FullName(), *rule.RepeatedUniqueBy, field.Message().FullName())
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+)
+
+func TestRepeatedUniqueBy(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package unique; import "validator.proto";
+message Item { string sku = 1; int32 id = 2; bytes hash = 3; optional string code = 4; Item parent = 5; }
+message Order {
+  repeated Item items = 1 [(validator.field) = {repeated_unique_by: "sku"}];
+  repeated Item by_id = 2 [(validator.field) = {repeated_unique_by: "id"}];
+  repeated Item by_hash = 3 [(validator.field) = {repeated_unique_by: "hash"}];
+  repeated Item by_code = 4 [(validator.field) = {repeated_unique_by: "code"}];
+}`)
+	for _, c := range []struct {
+		name, json, path string
+	}{
+		{"empty", `{}`, ""},
+		{"unique", `{"items":[{"sku":"a"},{"sku":"b"}]}`, ""},
+		{"duplicate", `{"items":[{"sku":"a"},{"sku":"b"},{"sku":"a"}]}`, "items[2].sku"},
+		{"case differs", `{"items":[{"sku":"a"},{"sku":"A"}]}`, ""},
+		//unset proto3 scalars are not present
+		{"unset keys", `{"items":[{"id":1},{"id":2}]}`, ""},
+		{"unset and empty", `{"byCode":[{},{"code":""}]}`, ""},
+		{"empty keys", `{"byCode":[{"code":""},{"code":""}]}`, "by_code[1].code"},
+		{"duplicate int", `{"byId":[{"id":1},{"id":2},{"id":1}]}`, "by_id[2].id"},
+		{"duplicate bytes", `{"byHash":[{"hash":"AQI="},{"hash":"AQI="}]}`, "by_hash[1].hash"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().Validate(newMsg(t, fd, "Order", c.json))
+			if c.path == "" && err != nil || c.path != "" && !MatchViolation(err, c.path, "RepeatedUniqueBy") {
+				t.Fatal(err)
+			}
+		})
+	}
+	//every duplicate is reported
+	if errs := ValidErrors(New().ValidateAll(newMsg(t, fd, "Order", `{"items":[{"sku":"a"},{"sku":"a"},{"sku":"a"}]}`))); len(errs) != 2 {
+		t.Fatal(errs)
+	}
+}
+
+func TestRepeatedUniqueByRuleConfig(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package unique; import "validator.proto";
+message Item { string sku = 1; Item parent = 2; repeated string tags = 3; }
+message Missing { repeated Item items = 1 [(validator.field) = {repeated_unique_by: "name"}]; }
+message Nested { repeated Item items = 1 [(validator.field) = {repeated_unique_by: "parent"}]; }
+message Repeated { repeated Item items = 1 [(validator.field) = {repeated_unique_by: "tags"}]; }`)
+	for _, name := range []string{"Missing", "Nested", "Repeated"} {
+		t.Run(name, func(t *testing.T) {
+			if err := New().Register(fd.Messages().ByName(protoreflect.Name(name))); err == nil {
+				t.Fatal("want a repeated_unique_by error")
+			}
+			//a bad field name fails closed
+			var faults []*Fault
+			v := New(WithFaultReporter(func(fault *Fault) { faults = append(faults, fault) }))
+			err := v.Validate(newMsg(t, fd, name, `{"items":[{"sku":"a"}]}`))
+			if !MatchViolation(err, "items", "RepeatedUniqueBy") || len(faults) == 0 || faults[len(faults)-1].Kind != FaultConfig {
+				t.Fatal(err, faults)
+			}
+			//an empty list has nothing to check
+			if err := New().Validate(newMsg(t, fd, name, `{}`)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -1,29 +1,53 @@
 package validator
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/golang/protobuf/proto"
-	"github.com/jhump/protoreflect/desc"
-	"github.com/jhump/protoreflect/dynamic"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
-	"log"
+	"math"
+	"net/netip"
+	"reflect"
 	"regexp"
+	"runtime/debug"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 )
 
-// regCache regexp cache
-type regCache struct {
-	sync.Map
+// swapMap sync.Map swapped atomically on reset, so a reset never races with concurrent lookups
+type swapMap struct {
+	m atomic.Pointer[sync.Map]
+}
+
+// load current map
+func (s *swapMap) load() *sync.Map {
+	if m := s.m.Load(); m != nil {
+		return m
+	}
+	s.m.CompareAndSwap(nil, new(sync.Map))
+	return s.m.Load()
+}
+
+// reset swap in an empty map, in-flight lookups keep the previous one
+func (s *swapMap) reset() {
+	s.m.Store(new(sync.Map))
 }
 
-// reset cache
-func (r *regCache) reset() {
-	r.Map = sync.Map{}
+// regCache regexp cache
+type regCache struct {
+	swapMap
 }
 
 // Get get regexp instance
 func (r *regCache) Get(expr string) (*regexp.Regexp, error) {
-	if x, ok := r.Map.Load(expr); ok {
+	if x, ok := r.load().Load(expr); ok {
 		if exp, ok := x.(*regexp.Regexp); ok {
 			return exp, nil
 		}
@@ -32,7 +56,7 @@ func (r *regCache) Get(expr string) (*regexp.Regexp, error) {
 	if err != nil {
 		return nil, err
 	}
-	r.Map.Store(expr, exp)
+	r.load().Store(expr, exp)
 	return exp, nil
 }
 
@@ -45,225 +69,567 @@ func ResetRegCache() {
 
 // validator proto validator
 type validator struct {
-	msg *dynamic.Message
+	*Validator
+	msg protoreflect.Message
+	//old previous version of msg, unchanged fields are skipped if set
+	old protoreflect.Message
+	//overlay rule overlay selected for the call, may be nil
+	overlay *overlay
+	//shadow evaluating shadow rules, violations are reported only
+	shadow bool
+	//errs collected violations, nil if the validation stops at the first violation
+	errs *[]error
+	//perField stop after the first field with violations in errs
+	perField bool
+	//path path of msg from the validated message
+	path []PathElement
+	//elem element of a repeated or map field being checked, Field is nil outside of them
+	elem PathElement
+	//ctx context of the call, may be nil
+	ctx context.Context
+	//pathFormat path format of the call, see CallPathFormat
+	pathFormat PathFormat
+	//trusted the call carries a valid trust token, pre_validated fields are skipped
+	trusted bool
+	//groups validation groups active for the call, see CallGroups
+	groups groupSet
+	//nested evaluating nested rules, sub-messages are validated by the walk of their message
+	nested bool
+	//nestedProg program holding the compiled nested rules being evaluated, nil if not nested
+	nestedProg *program
+	//maxViolations cap of the collected violations, see WithMaxViolations
+	maxViolations int
+	//truncated whether violations were dropped, nil without cap
+	truncated *bool
+	//errorMessage error_message of the rule of the field being checked, see FieldValidator.ErrorMessage
+	errorMessage string
+	//humanError human_error of the rule of the field being checked, see FieldValidator.HumanError
+	humanError string
+	//warnings collected violations of shadow rules, nil if they are only reported to the hooks
+	warnings *[]error
+	//messages messages of violations in the locale of the call, see WithLocale
+	messages map[string]string
+	//deadline deadline of the call, nil without budget, see WithBudget
+	deadline *budgetState
+}
+
+// Validate verify whether a generated proto message is legal.
+// The compiled program of T's descriptor is cached on first use.
+func Validate[T proto.Message](msg T) error {
+	if any(msg) == nil {
+		return nil
+	}
+	return std().valid(msg.ProtoReflect())
+}
+
+// Validate verify whether a proto message is legal
+func (v *Validator) Validate(msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	return v.valid(msg.ProtoReflect())
+}
+
+// ValidProto verify whether a proto message is legal with the default validator.
+// Generated messages are walked through protoreflect directly, without converting them to *dynamic.Message as ValidMsg requires.
+func ValidProto(msg proto.Message) error {
+	return std().Validate(msg)
+}
+
+// ValidProto verify whether a proto message is legal, see Validate
+func (v *Validator) ValidProto(msg proto.Message) error {
+	return v.Validate(msg)
+}
+
+// valid verify a protoreflect message
+func (v *Validator) valid(m protoreflect.Message) error {
+	return v.run(&validator{
+		Validator: v,
+		msg:       m,
+	})
 }
 
-// ValidMsg verify whether a proto message is legal
-func ValidMsg(msg *dynamic.Message) (err error) {
+// run run a validator, a panic is logged, reported as a fault and returned as an *InternalValidationError
+// (or treated as legal with WithLenientPanics)
+func (v *Validator) run(w *validator) (err error) {
+	if isNilMessage(w.msg) {
+		return nil
+	}
+	if v.statsEnabled {
+		start := time.Now()
+		defer func() {
+			v.record(w, start, err)
+		}()
+	}
+	aggregation, locale, budget := v.aggregation, v.locale, v.budget
+	w.pathFormat, w.trusted, w.groups, w.maxViolations = v.pathFormat, v.trusted(w.ctx, w.msg), v.groups, v.maxViolations
+	if calls := callOptionsFrom(w.ctx); calls != nil {
+		if calls.aggregation != nil {
+			aggregation = *calls.aggregation
+		}
+		if calls.pathFormat != nil {
+			w.pathFormat = *calls.pathFormat
+		}
+		if calls.groups != nil {
+			w.groups = *calls.groups
+		}
+		if calls.maxViolations != nil {
+			w.maxViolations = *calls.maxViolations
+		}
+		if calls.locale != nil {
+			locale = *calls.locale
+		}
+		if calls.budget != nil {
+			budget = *calls.budget
+		}
+	}
+	w.messages = v.localeMessages(locale)
+	if w.errs == nil && aggregation != AggregateFirst {
+		var errs []error
+		w.errs, w.perField = &errs, aggregation == AggregateField
+		defer func() {
+			if err == nil {
+				err = errors.Join(errs...)
+			}
+		}()
+	}
+	if w.errs != nil && w.maxViolations > 0 {
+		w.truncated = new(bool)
+		defer func() {
+			if *w.truncated {
+				*w.errs = append(*w.errs, ErrViolationsTruncated)
+			}
+		}()
+	}
+	if budget > 0 {
+		w.deadline = &budgetState{at: time.Now().Add(budget)}
+		defer func() {
+			if !w.deadline.exceeded {
+				return
+			}
+			if w.errs != nil {
+				*w.errs = append(*w.errs, ErrBudgetExceeded)
+			} else if err == nil {
+				err = ErrBudgetExceeded
+			}
+		}()
+	}
+	if w.errs != nil && v.collapseSamples > 0 {
+		defer func() {
+			*w.errs = v.collapse(*w.errs)
+		}()
+	}
 	defer func() {
 		if p := recover(); p != nil {
-			log.Printf("[pb valid]panic: %s, msg: %+v", p, msg)
-			err = nil
+			v.warnf("[pb valid]panic: %s, msg: %+v", p, w.msg)
+			fault := &Fault{
+				Kind:    FaultPanic,
+				Message: messageName(w.msg),
+				Err:     fmt.Errorf("panic: %v", p),
+				Stack:   debug.Stack(),
+			}
+			v.fault(fault)
+			err = &InternalValidationError{Fault: fault}
+			if v.lenientPanics {
+				err = nil
+			}
 		}
 	}()
-	v := validator{
-		msg: msg,
+	return w.Valid()
+}
+
+// isNilMessage whether m is nil or a typed nil, e.g. a nil *dynamicpb.Message which panics on IsValid
+func isNilMessage(m protoreflect.Message) bool {
+	if m == nil {
+		return true
 	}
-	return v.Valid()
+	rv := reflect.ValueOf(m)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
 }
 
 // Valid valid proto msg
 func (v *validator) Valid() error {
-	if v.msg == nil {
+	if v.msg == nil || !v.msg.IsValid() {
 		return nil
 	}
-	fields := v.msg.GetKnownFields()
-	for _, field := range fields {
-		if field.IsExtension() {
-			continue
+	prog := v.program(v.msg.Descriptor())
+	if err := v.checkMsgRule(prog.message); err != nil {
+		return err
+	}
+	if v.stop() {
+		return nil
+	}
+	for _, fp := range prog.fields {
+		field, rule := fp.field, fp.rule
+		v.errorMessage, v.humanError = rule.GetErrorMessage(), rule.GetHumanError()
+		if fp.decodeErr != nil {
+			if err := v.fail(field, "RuleDecode", string(E_Field.TypeDescriptor().FullName()), fp.decodeErr.Error()); err != nil {
+				return err
+			}
 		}
-
-		value, err := v.msg.TryGetField(field)
-		if err != nil {
-			log.Printf("[pb valid]get field[%+v] value err: %s", field, err)
+		if v.trusted && rule.GetPreValidated() {
 			continue
 		}
-		rule := v.getRule(field)
+		value := v.msg.Get(field)
 
-		if field.IsMap() {
-			if err2 := v.validMap(field, value, rule); err2 != nil {
-				return err2
-			}
-		} else if field.IsRepeated() {
-			if err2 := v.validRepeated(field, value, rule); err2 != nil {
-				return err2
-			}
-		} else {
-			if err2 := v.validField(field, value, rule); err2 != nil {
-				return err2
+		if v.old != nil {
+			if done, err := v.validChanged(field, rule, v.old.Get(field), value); done {
+				if err != nil {
+					return err
+				}
+				if v.stop() {
+					return nil
+				}
+				continue
 			}
 		}
 
-		//fmt.Println(field)
+		if err := v.validValue(field, value, rule); err != nil {
+			return err
+		}
+		if fp.shadow != nil && !v.shadow {
+			v.validShadow(field, value, fp.shadow)
+		}
+		if v.stop() {
+			return nil
+		}
 	}
 	return nil
 }
 
-// getRule get verification rules
-func (v *validator) getRule(field *desc.FieldDescriptor) *FieldValidator {
-	opt := field.GetFieldOptions()
-	if opt == nil {
-		return nil
+// validValue valid the value of a field
+func (v *validator) validValue(field protoreflect.FieldDescriptor, value protoreflect.Value, rule *FieldValidator) error {
+	if field.IsMap() {
+		return v.validMap(field, value.Map(), rule)
+	} else if field.IsList() {
+		return v.validRepeated(field, value.List(), rule)
 	}
-	ext, err := proto.GetExtension(opt, E_Field)
-	if err != nil {
-		return nil
-	}
-	rule, ok := ext.(*FieldValidator)
-	if !ok {
-		return nil
-	}
-	return rule
+	return v.validField(field, value, rule)
 }
 
 // validRepeated valid list
-func (v *validator) validRepeated(field *desc.FieldDescriptor, value interface{}, rule *FieldValidator) error {
-	if value == nil {
-		return nil
-	}
-	vList, ok := value.([]interface{})
-	if !ok {
-		log.Printf("[pb valid]field[%+v] value[%+v] is not array", field, value)
-		return nil
-	}
-
-	if err := v.checkRepeated(field, vList, rule); err != nil {
+func (v *validator) validRepeated(field protoreflect.FieldDescriptor, list protoreflect.List, rule *FieldValidator) error {
+	if err := v.checkRepeated(field, list, rule); err != nil {
 		return err
 	}
 
-	for _, item := range vList {
-		if err := v.validField(field, item, rule); err != nil {
+	for i := 0; i < list.Len() && !v.stop(); i++ {
+		if err := v.validElem(field, i, list.Get(i), rule); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// validMap valid map
-func (v *validator) validMap(field *desc.FieldDescriptor, value interface{}, rule *FieldValidator) error {
-	if value == nil {
-		return nil
+// validElem valid an element of a repeated field, reporting its index on failure
+func (v *validator) validElem(field protoreflect.FieldDescriptor, i int, value protoreflect.Value, rule *FieldValidator) error {
+	v.elem = PathElement{Field: field, Index: i}
+	err := v.validField(field, value, rule)
+	v.elem = PathElement{}
+	return err
+}
+
+// validEntry valid the key and the value of a map entry, reporting the key on failure
+func (v *validator) validEntry(field protoreflect.FieldDescriptor, key protoreflect.MapKey, item protoreflect.Value, rule *FieldValidator) error {
+	v.elem = PathElement{Field: field, Index: -1, Key: key}
+	defer func() {
+		v.elem = PathElement{}
+	}()
+	if err := v.checkMapKey(field, key, rule); err != nil {
+		return err
 	}
-	vList, ok := value.(map[interface{}]interface{})
-	if !ok {
-		log.Printf("[pb valid]field[%+v] value[%+v] is not map", field, value)
-		return nil
+	if err := v.validField(field.MapKey(), key.Value(), rule); err != nil {
+		return err
 	}
+	return v.validField(field.MapValue(), item, nil)
+}
 
-	for key, item := range vList {
-		if err := v.validField(field.GetMapKeyType(), key, rule); err != nil {
-			return err
-		}
-
-		if err := v.validField(field.GetMapValueType(), item, nil); err != nil {
-			return err
-		}
+// validMap valid map
+func (v *validator) validMap(field protoreflect.FieldDescriptor, m protoreflect.Map, rule *FieldValidator) (err error) {
+	if err = v.checkCount(field, int64(m.Len()), rule); err != nil {
+		return err
 	}
-	return nil
+
+	m.Range(func(key protoreflect.MapKey, item protoreflect.Value) bool {
+		err = v.validEntry(field, key, item, rule)
+		return err == nil && !v.stop()
+	})
+	return err
 }
 
 // validField valid a field
-func (v *validator) validField(field *desc.FieldDescriptor, value interface{}, rule *FieldValidator) error {
-	if value == nil {
+func (v *validator) validField(field protoreflect.FieldDescriptor, value protoreflect.Value, rule *FieldValidator) error {
+	if !value.IsValid() {
 		return nil
 	}
+	if err := v.checkKind(field, value, rule); err != nil {
+		return err
+	}
+	return v.checkFunc(field, value, rule)
+}
 
-	switch field.GetType() {
-	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+// checkKind check a value with the rules of the kind of its field
+func (v *validator) checkKind(field protoreflect.FieldDescriptor, value protoreflect.Value, rule *FieldValidator) error {
+	switch field.Kind() {
+	case protoreflect.MessageKind:
 		//message
 		return v.checkMessage(field, value, rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
-		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+	case protoreflect.Int32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind:
 		//int32
-		return v.checkInt(field, int64(value.(int32)), rule)
+		return v.checkInt(field, value.Int(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
-		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
-		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+	case protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind:
 		//int64
-		return v.checkInt(field, value.(int64), rule)
+		return v.checkInt(field, value.Int(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+	case protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind:
 		//uint32
-		return v.checkInt(field, int64(value.(uint32)), rule)
+		return v.checkUint(field, value.Uint(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
-		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+	case protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
 		//uint64
-		return v.checkInt(field, int64(value.(uint64)), rule)
+		return v.checkUint(field, value.Uint(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+	case protoreflect.FloatKind:
 		//float32
-		return v.checkFloat(field, float64(value.(float32)), rule)
+		return v.checkFloat(field, value.Float(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+	case protoreflect.DoubleKind:
 		//float64
-		return v.checkFloat(field, value.(float64), rule)
+		return v.checkFloat(field, value.Float(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+	case protoreflect.StringKind:
 		//string
-		return v.checkString(field, value.(string), rule)
+		return v.checkString(field, value.String(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+	case protoreflect.BytesKind:
 		//[]bytes
-		return v.checkBytes(field, value.([]byte), rule)
+		return v.checkBytes(field, value.Bytes(), rule)
 
-	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+	case protoreflect.EnumKind:
 		//enum
-		return v.checkEnum(field, value.(int32), rule)
+		return v.checkEnum(field, int32(value.Enum()), rule)
 	}
 	return nil
 }
 
-// checkRepeated check list
-func (v *validator) checkRepeated(field *desc.FieldDescriptor, values []interface{}, rule *FieldValidator) error {
+// checkCount check the number of elements of a list or of entries of a map
+func (v *validator) checkCount(field protoreflect.FieldDescriptor, _len int64, rule *FieldValidator) error {
+	if err := v.checkDefaultRepeated(field, _len, rule); err != nil {
+		return err
+	}
 	if rule == nil {
 		return nil
 	}
 
-	_len := int64(len(values))
 	if rule.RepeatedCountMin != nil && !(_len >= *rule.RepeatedCountMin) {
-		return ValidFail(field, "RepeatedCountMin", *rule.RepeatedCountMin, _len)
+		if err := v.fail(field, "RepeatedCountMin", *rule.RepeatedCountMin, _len); err != nil {
+			return err
+		}
 	}
 	if rule.RepeatedCountMax != nil && !(_len <= *rule.RepeatedCountMax) {
-		return ValidFail(field, "RepeatedCountMax", *rule.RepeatedCountMax, _len)
+		if err := v.fail(field, "RepeatedCountMax", *rule.RepeatedCountMax, _len); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// checkRepeated check list
+func (v *validator) checkRepeated(field protoreflect.FieldDescriptor, list protoreflect.List, rule *FieldValidator) error {
+	if err := v.checkCount(field, int64(list.Len()), rule); err != nil {
+		return err
+	}
+	if rule == nil {
+		return nil
+	}
+	if err := v.checkMonotonic(field, list, rule); err != nil {
+		return err
+	}
+	return v.checkUniqueBy(field, list, rule)
+}
+
 // checkMessage 检查消息
-func (v *validator) checkMessage(field *desc.FieldDescriptor, value interface{}, rule *FieldValidator) error {
-	subMsg, ok := value.(*dynamic.Message)
+func (v *validator) checkMessage(field protoreflect.FieldDescriptor, value protoreflect.Value, rule *FieldValidator) error {
+	subMsg, ok := value.Interface().(protoreflect.Message)
 	if !ok {
-		log.Printf("[pb valid]field[%+v] value[%+v] is not *dynamic.Message", field, value)
+		v.warnf("[pb valid]field[%s] value[%+v] is not protoreflect.Message", field.FullName(), value.Interface())
+		v.fault(&Fault{
+			Kind:    FaultTypeMismatch,
+			Message: string(field.ContainingMessage().FullName()),
+			Err:     fmt.Errorf("field[%s] value of type %T is not protoreflect.Message", field.FullName(), value.Interface()),
+		})
 		return nil
 	}
-	if err := ValidMsg(subMsg); err != nil {
+	if !subMsg.IsValid() {
+		return nil
+	}
+	if err := v.checkMessageRules(field, subMsg, rule); err != nil {
 		return err
 	}
+	if v.shadow {
+		//sub-messages are validated by the enforcing validator
+		return nil
+	}
+	if subMsg.Descriptor().FullName() == anyFullName {
+		if subMsg, ok = v.unpackAny(field, subMsg); !ok {
+			return nil
+		}
+	}
+	sub := *v
+	sub.msg, sub.old = subMsg, nil
+	sub.path, sub.elem = appendPath(v.path, v.step(field)), PathElement{}
+	if !v.nested {
+		if err := sub.Valid(); err != nil {
+			return err
+		}
+	}
+	return sub.checkNested(field, rule)
+}
+
+// checkMessageRules check a sub-message with the rules of its field, its own fields are left to the walk of the sub-message.
+// Shared by checkMessage and the changed sub-messages of ValidateChanged.
+func (v *validator) checkMessageRules(field protoreflect.FieldDescriptor, subMsg protoreflect.Message, rule *FieldValidator) error {
+	checks := messageChecks
+	if v.ruleOrder == OrderCost {
+		checks = messageChecksByCost
+	}
+	for _, c := range checks {
+		if err := c.check(v, field, subMsg, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMsgSize check the encoded size of a sub-message
+func (v *validator) checkMsgSize(field protoreflect.FieldDescriptor, subMsg protoreflect.Message, rule *FieldValidator) error {
+	if rule == nil || rule.MsgMaxBytes == nil {
+		return nil
+	}
+	if size := int64(proto.Size(subMsg.Interface())); size > *rule.MsgMaxBytes {
+		return v.fail(field, "MsgMaxBytes", *rule.MsgMaxBytes, size)
+	}
 	return nil
 }
 
+// unpackAny resolve the payload of a google.protobuf.Any
+func (v *validator) unpackAny(field protoreflect.FieldDescriptor, anyMsg protoreflect.Message) (protoreflect.Message, bool) {
+	fields := anyMsg.Descriptor().Fields()
+	typeURL := anyMsg.Get(fields.ByName("type_url")).String()
+	if typeURL == "" {
+		return nil, false
+	}
+	mt, err := v.resolver.FindMessageByURL(typeURL)
+	if err != nil {
+		v.warnf("[pb valid]field[%s] resolve any type[%s] err: %s", field.FullName(), typeURL, err)
+		return nil, false
+	}
+	payload := mt.New()
+	opts := proto.UnmarshalOptions{Resolver: v.resolver}
+	if err := opts.Unmarshal(anyMsg.Get(fields.ByName("value")).Bytes(), payload.Interface()); err != nil {
+		v.warnf("[pb valid]field[%s] unmarshal any type[%s] err: %s", field.FullName(), typeURL, err)
+		return nil, false
+	}
+	return payload, true
+}
+
 // checkInt check int
-func (v *validator) checkInt(field *desc.FieldDescriptor, value int64, rule *FieldValidator) error {
+func (v *validator) checkInt(field protoreflect.FieldDescriptor, value int64, rule *FieldValidator) error {
 	if rule == nil {
 		return nil
 	}
 
 	if rule.IntGt != nil && !(value > *rule.IntGt) {
-		return ValidFail(field, "IntGt", *rule.IntGt, value)
+		if err := v.fail(field, "IntGt", *rule.IntGt, value); err != nil {
+			return err
+		}
 	}
 	if rule.IntLt != nil && !(value < *rule.IntLt) {
-		return ValidFail(field, "IntLt", *rule.IntLt, value)
+		if err := v.fail(field, "IntLt", *rule.IntLt, value); err != nil {
+			return err
+		}
+	}
+	if rule.IntGte != nil && !(value >= *rule.IntGte) {
+		if err := v.fail(field, "IntGte", *rule.IntGte, value); err != nil {
+			return err
+		}
+	}
+	if rule.IntLte != nil && !(value <= *rule.IntLte) {
+		if err := v.fail(field, "IntLte", *rule.IntLte, value); err != nil {
+			return err
+		}
+	}
+	if rule.IntPort != nil && *rule.IntPort && !(value >= 1 && value <= 65535) {
+		if !(value == 0 && rule.IntPortAllowZero != nil && *rule.IntPortAllowZero) {
+			if err := v.fail(field, "IntPort", *rule.IntPort, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkUint check unsigned int, int_* rules included without wrapping values above math.MaxInt64
+func (v *validator) checkUint(field protoreflect.FieldDescriptor, value uint64, rule *FieldValidator) error {
+	if rule == nil {
+		return nil
+	}
+
+	if value <= math.MaxInt64 {
+		if err := v.checkInt(field, int64(value), rule); err != nil {
+			return err
+		}
+	} else {
+		//greater than every int64
+		if rule.IntLt != nil {
+			if err := v.fail(field, "IntLt", *rule.IntLt, value); err != nil {
+				return err
+			}
+		}
+		if rule.IntLte != nil {
+			if err := v.fail(field, "IntLte", *rule.IntLte, value); err != nil {
+				return err
+			}
+		}
+		if rule.GetIntPort() {
+			if err := v.fail(field, "IntPort", *rule.IntPort, value); err != nil {
+				return err
+			}
+		}
+	}
+	if rule.UintGt != nil && !(value > *rule.UintGt) {
+		if err := v.fail(field, "UintGt", *rule.UintGt, value); err != nil {
+			return err
+		}
+	}
+	if rule.UintLt != nil && !(value < *rule.UintLt) {
+		if err := v.fail(field, "UintLt", *rule.UintLt, value); err != nil {
+			return err
+		}
+	}
+	if rule.UintGte != nil && !(value >= *rule.UintGte) {
+		if err := v.fail(field, "UintGte", *rule.UintGte, value); err != nil {
+			return err
+		}
+	}
+	if rule.UintLte != nil && !(value <= *rule.UintLte) {
+		if err := v.fail(field, "UintLte", *rule.UintLte, value); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // checkFloat check float
-func (v *validator) checkFloat(field *desc.FieldDescriptor, value float64, rule *FieldValidator) error {
+func (v *validator) checkFloat(field protoreflect.FieldDescriptor, value float64, rule *FieldValidator) error {
 	if rule == nil {
 		return nil
 	}
@@ -276,108 +642,465 @@ func (v *validator) checkFloat(field *desc.FieldDescriptor, value float64, rule
 	}
 
 	if rule.FloatGt != nil && !(valueMax > *rule.FloatGt) {
-		return ValidFail(field, "FloatGt", *rule.FloatGt, value)
+		if err := v.fail(field, "FloatGt", *rule.FloatGt, value); err != nil {
+			return err
+		}
 	}
 	if rule.FloatLt != nil && !(valueMin < *rule.FloatLt) {
-		return ValidFail(field, "FloatLt", *rule.FloatLt, value)
+		if err := v.fail(field, "FloatLt", *rule.FloatLt, value); err != nil {
+			return err
+		}
 	}
 
 	if rule.FloatGte != nil && !(valueMax >= *rule.FloatGte) {
-		return ValidFail(field, "FloatGte", *rule.FloatGte, value)
+		if err := v.fail(field, "FloatGte", *rule.FloatGte, value); err != nil {
+			return err
+		}
 	}
 	if rule.FloatLte != nil && !(valueMin <= *rule.FloatLte) {
-		return ValidFail(field, "FloatLte", *rule.FloatLte, value)
+		if err := v.fail(field, "FloatLte", *rule.FloatLte, value); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // checkString check string
-func (v *validator) checkString(field *desc.FieldDescriptor, value string, rule *FieldValidator) error {
+func (v *validator) checkString(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if err := v.checkDefaultStringBytes(field, value, rule); err != nil {
+		return err
+	}
 	if rule == nil {
 		return nil
 	}
 
+	checks := stringChecks
+	if v.ruleOrder == OrderCost {
+		checks = stringChecksByCost
+	}
+	for _, c := range checks {
+		if err := c.check(v, field, value, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStringNotEmpty check string_not_empty
+func (v *validator) checkStringNotEmpty(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
 	if rule.StringNotEmpty != nil && *rule.StringNotEmpty && value == "" {
-		return ValidFail(field, "StringNotEmpty", *rule.StringNotEmpty, value)
+		return v.fail(field, "StringNotEmpty", *rule.StringNotEmpty, value)
 	}
+	return nil
+}
 
-	_len := int64(len(value))
+// checkStringLength check the length of a string in its length_unit
+func (v *validator) checkStringLength(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.LengthGt == nil && rule.LengthLt == nil && rule.LengthEq == nil {
+		return nil
+	}
+	_len, ok := stringLength(value, rule.GetLengthUnit())
+	if !ok {
+		//reported by Register, fail closed instead of skipping the check
+		return v.fail(field, "LengthUnit", rule.GetLengthUnit().String(), "length unit not supported in this build")
+	}
 	if rule.LengthGt != nil && !(_len > *rule.LengthGt) {
-		return ValidFail(field, "LengthGt", *rule.LengthGt, _len)
+		if err := v.fail(field, "LengthGt", *rule.LengthGt, _len); err != nil {
+			return err
+		}
 	}
 	if rule.LengthLt != nil && !(_len < *rule.LengthLt) {
-		return ValidFail(field, "LengthLt", *rule.LengthLt, _len)
+		if err := v.fail(field, "LengthLt", *rule.LengthLt, _len); err != nil {
+			return err
+		}
 	}
 	if rule.LengthEq != nil && !(_len == *rule.LengthEq) {
-		return ValidFail(field, "LengthEq", *rule.LengthEq, _len)
+		if err := v.fail(field, "LengthEq", *rule.LengthEq, _len); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if rule.Regex != nil {
-		exp, err := r.Get(*rule.Regex)
-		if err != nil {
-			log.Printf("[pb valid]make regex[%s] err: %s", *rule.Regex, err)
-		} else if !exp.MatchString(value) {
-			return ValidFail(field, "Regex", *rule.Regex, value)
+// checkStringRegex check the regex of a string
+func (v *validator) checkStringRegex(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.Regex == nil {
+		return nil
+	}
+	exp, err := r.Get(*rule.Regex)
+	if err != nil {
+		//reported by Register, an invalid regex rejects every value instead of letting it through
+		v.debugf("[pb valid]make regex[%s] err: %s", *rule.Regex, err)
+	}
+	if err != nil || !exp.MatchString(value) {
+		return v.fail(field, "Regex", *rule.Regex, value)
+	}
+	return nil
+}
+
+// checkStringAffix check the prefix, suffix and substrings of a string
+func (v *validator) checkStringAffix(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.StringPrefix != nil && !strings.HasPrefix(value, *rule.StringPrefix) {
+		if err := v.fail(field, "StringPrefix", *rule.StringPrefix, value); err != nil {
+			return err
+		}
+	}
+	if rule.StringSuffix != nil && !strings.HasSuffix(value, *rule.StringSuffix) {
+		if err := v.fail(field, "StringSuffix", *rule.StringSuffix, value); err != nil {
+			return err
+		}
+	}
+	if rule.StringContains != nil && !strings.Contains(value, *rule.StringContains) {
+		if err := v.fail(field, "StringContains", *rule.StringContains, value); err != nil {
+			return err
+		}
+	}
+	if rule.StringNotContains != nil && strings.Contains(value, *rule.StringNotContains) {
+		if err := v.fail(field, "StringNotContains", *rule.StringNotContains, value); err != nil {
+			return err
 		}
 	}
+	return nil
+}
+
+// stringLength length of a string in unit, ok is false if the unit is not supported by the build
+func stringLength(value string, unit LengthUnit) (n int64, ok bool) {
+	switch unit {
+	case LengthUnit_LENGTH_UNIT_RUNES:
+		return int64(utf8.RuneCountInString(value)), true
+	case LengthUnit_LENGTH_UNIT_GRAPHEMES:
+		return graphemeCount(value)
+	}
+	return int64(len(value)), true
+}
 
+// checkIP check ip address string
+func (v *validator) checkIP(field protoreflect.FieldDescriptor, value string, rule *FieldValidator) error {
+	if rule.Ip == nil && rule.Ipv4 == nil && rule.Ipv6 == nil && rule.IpPrivate == nil && rule.IpPublic == nil {
+		return nil
+	}
+
+	addr, parseErr := netip.ParseAddr(value)
+	if rule.Ip != nil && *rule.Ip && parseErr != nil {
+		if err := v.fail(field, "Ip", *rule.Ip, value); err != nil {
+			return err
+		}
+	}
+	if rule.Ipv4 != nil && *rule.Ipv4 && (parseErr != nil || !addr.Is4()) {
+		if err := v.fail(field, "Ipv4", *rule.Ipv4, value); err != nil {
+			return err
+		}
+	}
+	if rule.Ipv6 != nil && *rule.Ipv6 && (parseErr != nil || !addr.Is6()) {
+		if err := v.fail(field, "Ipv6", *rule.Ipv6, value); err != nil {
+			return err
+		}
+	}
+
+	//ipv4-mapped ipv6 address is classified as ipv4
+	addr = addr.Unmap()
+	if rule.IpPrivate != nil && *rule.IpPrivate && (parseErr != nil || !addr.IsPrivate()) {
+		if err := v.fail(field, "IpPrivate", *rule.IpPrivate, value); err != nil {
+			return err
+		}
+	}
+	if rule.IpPublic != nil && *rule.IpPublic && (parseErr != nil || !addr.IsGlobalUnicast() || addr.IsPrivate()) {
+		if err := v.fail(field, "IpPublic", *rule.IpPublic, value); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // checkBytes check []byte
-func (v *validator) checkBytes(field *desc.FieldDescriptor, value []byte, rule *FieldValidator) error {
+func (v *validator) checkBytes(field protoreflect.FieldDescriptor, value []byte, rule *FieldValidator) error {
 	if rule == nil {
 		return nil
 	}
 
 	_len := int64(len(value))
 	if rule.LengthGt != nil && !(_len > *rule.LengthGt) {
-		return ValidFail(field, "LengthGt", *rule.LengthGt, _len)
+		if err := v.fail(field, "LengthGt", *rule.LengthGt, _len); err != nil {
+			return err
+		}
 	}
 	if rule.LengthLt != nil && !(_len < *rule.LengthLt) {
-		return ValidFail(field, "LengthLt", *rule.LengthLt, _len)
+		if err := v.fail(field, "LengthLt", *rule.LengthLt, _len); err != nil {
+			return err
+		}
 	}
 	if rule.LengthEq != nil && !(_len == *rule.LengthEq) {
-		return ValidFail(field, "LengthEq", *rule.LengthEq, _len)
+		if err := v.fail(field, "LengthEq", *rule.LengthEq, _len); err != nil {
+			return err
+		}
+	}
+	if rule.StringPrefix != nil && !bytes.HasPrefix(value, []byte(*rule.StringPrefix)) {
+		if err := v.fail(field, "StringPrefix", *rule.StringPrefix, value); err != nil {
+			return err
+		}
+	}
+	if rule.StringSuffix != nil && !bytes.HasSuffix(value, []byte(*rule.StringSuffix)) {
+		if err := v.fail(field, "StringSuffix", *rule.StringSuffix, value); err != nil {
+			return err
+		}
+	}
+
+	if rule.Regex != nil {
+		exp, err := r.Get(*rule.Regex)
+		if err != nil {
+			//reported by Register, an invalid regex rejects every value instead of letting it through
+			v.debugf("[pb valid]make regex[%s] err: %s", *rule.Regex, err)
+		}
+		if err != nil || !exp.Match(value) {
+			if err := v.fail(field, "Regex", *rule.Regex, value); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
 // checkEnum check enum
-func (v *validator) checkEnum(field *desc.FieldDescriptor, value int32, rule *FieldValidator) error {
-	if rule == nil || rule.IsInEnum == nil || !*rule.IsInEnum {
+func (v *validator) checkEnum(field protoreflect.FieldDescriptor, value int32, rule *FieldValidator) error {
+	if err := v.checkOpenEnum(field, value, rule); err != nil {
+		return err
+	}
+	if rule == nil {
 		return nil
 	}
 
-	for _, item := range field.GetEnumType().GetValues() {
-		if value == item.GetNumber() {
-			return nil
+	if rule.IsInEnum != nil && *rule.IsInEnum && field.Enum().Values().ByNumber(protoreflect.EnumNumber(value)) == nil {
+		if err := v.fail(field, "IsInEnum", *rule.IsInEnum, false); err != nil {
+			return err
+		}
+	}
+	if len(rule.EnumIn) > 0 && !containsInt32(rule.EnumIn, value) {
+		if err := v.fail(field, "EnumIn", rule.EnumIn, value); err != nil {
+			return err
 		}
 	}
-	return ValidFail(field, "IsInEnum", *rule.IsInEnum, false)
+	if len(rule.EnumNotIn) > 0 && containsInt32(rule.EnumNotIn, value) {
+		if err := v.fail(field, "EnumNotIn", rule.EnumNotIn, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// containsInt32 whether values contains value
+func containsInt32(values []int32, value int32) bool {
+	for _, x := range values {
+		if x == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString whether values contains value
+func containsString(values []string, value string) bool {
+	for _, x := range values {
+		if x == value {
+			return true
+		}
+	}
+	return false
+}
+
+// fail report a failed rule, a non-nil error stops the validation
+func (v *validator) fail(field protoreflect.FieldDescriptor, validKey string, validValue interface{}, fieldValue interface{}) error {
+	if v.ruleFilter != nil && !v.ruleFilter(field, validKey) {
+		return nil
+	}
+	err := &ValidError{
+		field:      field,
+		validKey:   validKey,
+		validValue: validValue,
+		fieldValue: fieldValue,
+		path:       appendPath(v.path, v.step(field)),
+		format:     v.pathFormat,
+		shadow:     v.shadow,
+		provenance: v.provenance(field, validKey),
+		human:      v.humanError,
+	}
+	if v.errorMessage != "" {
+		err.message = renderErrorMessage(v.errorMessage, validKey, fieldValue)
+	} else if tmpl, ok := v.messages[validKey]; ok {
+		err.message = renderErrorMessage(tmpl, validKey, fieldValue)
+	}
+	if (v.onViolation != nil || v.auditSink != nil) && (v.sampler == nil || v.sampler.Sample(err)) {
+		if v.onViolation != nil {
+			v.onViolation(err)
+		}
+		if v.auditSink != nil {
+			v.audit(err)
+		}
+	}
+	if v.shadow {
+		if v.warnings != nil {
+			*v.warnings = append(*v.warnings, err)
+		}
+		return nil
+	}
+	if v.errs != nil {
+		if !v.full() {
+			*v.errs = append(*v.errs, err)
+		}
+		return nil
+	}
+	return err
 }
 
 // ValidError error warp
 type ValidError struct {
-	field      *desc.FieldDescriptor
+	field      protoreflect.FieldDescriptor
 	validKey   string
 	validValue interface{}
 	fieldValue interface{}
+	//path path of the violating value from the validated message
+	path   []PathElement
+	format PathFormat
+	shadow bool
+	//count collapsed violations of the elements of the same repeated field, see WithCollapsedElements
+	count int
+	//indices sample indexes of the collapsed elements
+	indices []int
+	//provenance layer the violated rule comes from
+	provenance Provenance
+	//message custom message of the violation, see FieldValidator.ErrorMessage
+	message string
+	//human user-friendly text of the violation, see FieldValidator.HumanError
+	human string
 }
 
-// ValidFail error warp
-func ValidFail(field *desc.FieldDescriptor, validKey string, validValue interface{}, fieldValue interface{}) error {
+// validFail error warp
+func validFail(field protoreflect.FieldDescriptor, validKey string, validValue interface{}, fieldValue interface{}) error {
 	return &ValidError{
 		field:      field,
 		validKey:   validKey,
 		validValue: validValue,
 		fieldValue: fieldValue,
+		path:       []PathElement{{Field: field, Index: -1}},
 	}
 }
 
 // Error implement interface
 func (e *ValidError) Error() string {
-	return fmt.Sprintf("[proto valid]error: field[%s (type:%s)] valid[%s(rule:%+v)] find[%+v]",
-		e.field.GetName(), e.field.GetType(), e.validKey, e.validValue, e.fieldValue)
+	if e.message != "" {
+		return "[proto valid]error: " + e.message
+	}
+	if e.human != "" {
+		return "[proto valid]error: " + e.human
+	}
+	if e.field == nil {
+		//target of RuleError, without field nor value
+		return fmt.Sprintf("[proto valid]error: valid[%s]", e.validKey)
+	}
+	msg := fmt.Sprintf("[proto valid]error: field[%s (type:%s)] valid[%s(rule:%+v)] find[%+v]",
+		e.Path(), descriptorpb.FieldDescriptorProto_Type(e.field.Kind()), e.validKey, e.validValue, e.fieldValue)
+	if e.count > 1 {
+		msg += fmt.Sprintf(" repeated[%d elements, indices %v]", e.count, e.indices)
+	}
+	return msg
+}
+
+// Message human readable description of the violation, the error_message or the human_error of the field if set
+func (e *ValidError) Message() string {
+	if e.message != "" {
+		return e.message
+	}
+	if e.human != "" {
+		return e.human
+	}
+	return fmt.Sprintf("%s violates %s(%v), got %v", e.Path(), e.validKey, e.validValue, e.fieldValue)
+}
+
+// HumanMessage user-friendly text of the violation, the human_error of the field if set, Message otherwise
+func (e *ValidError) HumanMessage() string {
+	if e.human != "" {
+		return e.human
+	}
+	return e.Message()
+}
+
+// renderErrorMessage replace the placeholders of an error_message
+func renderErrorMessage(tmpl, ruleKind string, fieldValue interface{}) string {
+	return strings.NewReplacer("{{value}}", fmt.Sprint(fieldValue), "{{rule}}", ruleKind).Replace(tmpl)
+}
+
+// Count number of elements of the repeated field failing the rule when collapsed (see WithCollapsedElements), 1 otherwise
+func (e *ValidError) Count() int {
+	if e.count == 0 {
+		return 1
+	}
+	return e.count
+}
+
+// Indices sample indexes of the elements failing the rule when collapsed (see WithCollapsedElements), nil otherwise
+func (e *ValidError) Indices() []int {
+	return e.indices
+}
+
+// Shadow whether the violation comes from a rule evaluated in shadow mode, i.e. it did not fail the validation
+func (e *ValidError) Shadow() bool {
+	return e.shadow
+}
+
+// Field descriptor of the violating field
+func (e *ValidError) Field() protoreflect.FieldDescriptor {
+	return e.field
+}
+
+// Rule key of the failed rule, e.g. "IntGt"
+func (e *ValidError) Rule() string {
+	return e.validKey
+}
+
+// Index element index of a repeated field, -1 if the violating value is not an element
+func (e *ValidError) Index() int {
+	return e.path[len(e.path)-1].Index
+}
+
+// Key map key of the violating entry, invalid (see protoreflect.MapKey.IsValid) if the violating value is not a map entry.
+// The keys of the map entries enclosing the violating value (e.g. "redis" in config["redis"].endpoints[0].host)
+// are held by the elements of PathElements and are part of Path.
+func (e *ValidError) Key() protoreflect.MapKey {
+	if len(e.path) == 0 {
+		return protoreflect.MapKey{}
+	}
+	return e.path[len(e.path)-1].Key
+}
+
+// Path path of the violating value from the validated message, in the format of the validator (see WithPathFormat)
+func (e *ValidError) Path() string {
+	return formatPath(e.path, e.format)
+}
+
+// FormatPath path of the violating value from the validated message in format
+func (e *ValidError) FormatPath(format PathFormat) string {
+	return formatPath(e.path, format)
+}
+
+// PathElements steps of the path of the violating value from the validated message
+func (e *ValidError) PathElements() []PathElement {
+	return e.path
+}
+
+// MarshalJSON encode the violation as {"path", "rule", "expected", "value", "provenance"}
+func (e *ValidError) MarshalJSON() ([]byte, error) {
+	out := map[string]interface{}{
+		"path":       e.Path(),
+		"rule":       e.validKey,
+		"expected":   e.validValue,
+		"value":      e.fieldValue,
+		"provenance": e.Provenance(),
+	}
+	if e.count > 1 {
+		out["count"], out["indices"] = e.count, e.indices
+	}
+	if e.message != "" || e.human != "" {
+		out["message"] = e.Message()
+	}
+	return json.Marshal(out)
 }
@@ -0,0 +1,366 @@
+package validator
+
+import (
+	"encoding/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"strings"
+	"testing"
+)
+
+func TestMsgMaxBytes(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package msgsize; import "validator.proto";
+message Blob { string data = 1; }
+message Upload {
+  Blob blob = 1 [(validator.field) = {msg_max_bytes: 8}];
+  repeated Blob parts = 2 [(validator.field) = {msg_max_bytes: 8}];
+  Blob empty = 3 [(validator.field) = {msg_max_bytes: 0}];
+}`)
+	for _, c := range []struct {
+		name, json, path string
+	}{
+		{"legal", `{"blob":{"data":"abc"},"parts":[{"data":"abc"}]}`, ""},
+		{"unset", `{}`, ""},
+		{"zero", `{"blob":{},"parts":[{}],"empty":{}}`, ""},
+		{"bound", `{"blob":{"data":"abcdef"}}`, ""},
+		{"above bound", `{"blob":{"data":"abcdefg"}}`, "blob"},
+		{"zero bound", `{"empty":{"data":"a"}}`, "empty"},
+		{"element", `{"parts":[{"data":"abc"},{"data":"too large"}]}`, "parts[1]"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Upload", c.json))
+			if c.path == "" && err != nil || c.path != "" && !MatchViolation(err, c.path, "MsgMaxBytes") {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestMsgMaxBytesRuleConfig(t *testing.T) {
+	for _, c := range []struct {
+		name, field string
+		invalid     bool
+	}{
+		{"message", "Blob", false},
+		{"scalar", "string", true},
+		{"bytes", "bytes", true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fd := compileProto(t, `syntax = "proto3"; package msgsize; import "validator.proto";
+message Blob { string data = 1; }
+message Upload { `+c.field+` blob = 1 [(validator.field) = {msg_max_bytes: 8}]; }`)
+			if err := New(WithStrictTyping()).Register(fd.Messages().ByName("Upload")); (err != nil) != c.invalid {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestIPPrivatePublic(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package ip; import "validator.proto";
+message Host {
+  string private = 1 [(validator.field) = {ip_private: true}];
+  string public = 2 [(validator.field) = {ip_public: true}];
+}`)
+	for _, c := range []struct {
+		value           string
+		private, public bool
+	}{
+		{"10.0.0.0", true, false},
+		{"10.255.255.255", true, false},
+		{"172.15.255.255", false, true},
+		{"172.16.0.0", true, false},
+		{"172.31.255.255", true, false},
+		{"172.32.0.0", false, true},
+		{"192.168.1.1", true, false},
+		{"8.8.8.8", false, true},
+		{"127.0.0.1", false, false},
+		{"169.254.1.1", false, false},
+		{"0.0.0.0", false, false},
+		{"255.255.255.255", false, false},
+		{"224.0.0.1", false, false},
+		{"fc00::1", true, false},
+		{"fd12:3456::1", true, false},
+		{"fe80::1", false, false},
+		{"::1", false, false},
+		{"::", false, false},
+		{"2001:4860:4860::8888", false, true},
+		{"::ffff:10.0.0.1", true, false},
+		{"::ffff:8.8.8.8", false, true},
+		{"", false, false},
+		{"10.0.0.0/8", false, false},
+		{"host.example", false, false},
+	} {
+		t.Run(c.value, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Host", `{"private":"`+c.value+`","public":"`+c.value+`"}`))
+			if MatchViolation(err, "private", "IpPrivate") == c.private || MatchViolation(err, "public", "IpPublic") == c.public {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestIntPort(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package port; import "validator.proto";
+message Listen {
+  int32 port = 1 [(validator.field) = {int_port: true}];
+  uint32 any_port = 2 [(validator.field) = {int_port: true, int_port_allow_zero: true}];
+  uint64 wide_port = 3 [(validator.field) = {int_port: true}];
+  repeated int64 ports = 4 [(validator.field) = {int_port: true}];
+}`)
+	for _, c := range []struct {
+		name, json, path string
+	}{
+		{"legal", `{"port":8080,"anyPort":0,"widePort":"443","ports":["1","65535"]}`, ""},
+		{"lower bound", `{"port":1,"widePort":"1"}`, ""},
+		{"upper bound", `{"port":65535,"anyPort":65535,"widePort":"65535"}`, ""},
+		{"unset", `{"widePort":"1"}`, "port"},
+		{"zero", `{"port":0,"widePort":"1"}`, "port"},
+		{"zero allowed", `{"port":1,"anyPort":0,"widePort":"1"}`, ""},
+		{"negative", `{"port":-1,"widePort":"1"}`, "port"},
+		{"above upper bound", `{"port":65536,"widePort":"1"}`, "port"},
+		{"above upper bound allowing zero", `{"port":1,"anyPort":65536,"widePort":"1"}`, "any_port"},
+		{"above int64", `{"port":1,"widePort":"18446744073709551615"}`, "wide_port"},
+		{"element", `{"port":1,"widePort":"1","ports":["80","70000"]}`, "ports[1]"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateAll(newMsg(t, fd, "Listen", c.json))
+			if c.path == "" && err != nil || c.path != "" && !MatchViolation(err, c.path, "IntPort") {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package errmsg; import "validator.proto";
+message Item {
+  string sku = 1 [(validator.field) = {regex: "^[A-Z]+$", error_message: "{{value}} is not a valid SKU"}];
+  int64 count = 2 [(validator.field) = {int_gt: 0, int_lt: 10, error_message: "count failed {{rule}} ({{rule}}): {{value}}"}];
+  string name = 3 [(validator.field) = {length_gt: 1}];
+  string note = 4 [(validator.field) = {length_gt: 1, human_error: "Please add a note"}];
+  string code = 5 [(validator.field) = {length_gt: 1, human_error: "Please add a code", error_message: "code {{rule}}"}];
+  repeated string tags = 6 [(validator.field) = {regex: "^[a-z]+$", error_message: "bad tag {{value}}"}];
+  string title = 7 [(validator.field) = {length_gt: 1, error_message: "static"}];
+}`)
+	legal := map[string]interface{}{"sku": "AB", "count": 1, "name": "ab", "note": "ab", "code": "ab", "title": "ab"}
+	for _, c := range []struct {
+		name, field string
+		value       interface{}
+		path, rule  string
+		opts        []Option
+		message     string
+		human       string
+	}{
+		{"value", "sku", "ab1", "sku", "Regex", nil, "ab1 is not a valid SKU", "ab1 is not a valid SKU"},
+		{"empty value", "sku", "", "sku", "Regex", nil, " is not a valid SKU", " is not a valid SKU"},
+		{"rule repeated", "count", 0, "count", "IntGt", nil, "count failed IntGt (IntGt): 0", "count failed IntGt (IntGt): 0"},
+		{"other rule of the field", "count", 10, "count", "IntLt", nil, "count failed IntLt (IntLt): 10", "count failed IntLt (IntLt): 10"},
+		{"no placeholder", "title", "a", "title", "LengthGt", nil, "static", "static"},
+		{"no message", "name", "a", "name", "LengthGt", nil, "name violates LengthGt(1), got 1", "name violates LengthGt(1), got 1"},
+		{"human error", "note", "a", "note", "LengthGt", nil, "Please add a note", "Please add a note"},
+		{"error message and human error", "code", "a", "code", "LengthGt", nil, "code LengthGt", "Please add a code"},
+		{"element", "tags", []string{"ok", "Bad"}, "tags[1]", "Regex", nil, "bad tag Bad", "bad tag Bad"},
+		{"over the locale", "sku", "ab1", "sku", "Regex",
+			[]Option{WithMessages("fr", map[string]string{"Regex": "format invalide"}), WithLocale("fr")}, "ab1 is not a valid SKU", "ab1 is not a valid SKU"},
+		{"locale without error message", "name", "a", "name", "LengthGt",
+			[]Option{WithMessages("fr", map[string]string{"LengthGt": "trop court"}), WithLocale("fr")}, "trop court", "trop court"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fields := make(map[string]interface{}, len(legal))
+			for name, value := range legal {
+				fields[name] = value
+			}
+			fields[c.field] = c.value
+			data, err := json.Marshal(fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = New(c.opts...).Validate(newMsg(t, fd, "Item", string(data)))
+			e := FindViolation(err, c.path, c.rule)
+			if e == nil {
+				t.Fatal(err)
+			}
+			if e.Message() != c.message || e.HumanMessage() != c.human {
+				t.Fatalf("got %q and %q", e.Message(), e.HumanMessage())
+			}
+			//a custom text replaces the rule details of the error string
+			if (e.message != "" || e.human != "") && e.Error() != "[proto valid]error: "+c.message {
+				t.Fatal(e.Error())
+			}
+		})
+	}
+}
+
+func TestUint64Bounds(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package uintbounds; import "validator.proto";
+message Counter {
+  uint64 gt = 1 [(validator.field) = {uint_gt: 9223372036854775807}];
+  uint64 gte = 2 [(validator.field) = {uint_gte: 9223372036854775808}];
+  uint64 lt = 3 [(validator.field) = {uint_lt: 18446744073709551615}];
+  uint64 lte = 4 [(validator.field) = {uint_lte: 9223372036854775807}];
+  uint64 int_gt = 5 [(validator.field) = {int_gt: 0}];
+  uint64 int_lt = 6 [(validator.field) = {int_lt: 100}];
+  fixed64 int_lte = 7 [(validator.field) = {int_lte: 9223372036854775807}];
+  uint32 small = 8 [(validator.field) = {uint_lte: 4294967294}];
+}`)
+	const (
+		maxInt64     = "9223372036854775807"
+		maxInt64Plus = "9223372036854775808"
+		maxUint64    = "18446744073709551615"
+	)
+	legal := map[string]interface{}{"gt": maxInt64Plus, "gte": maxInt64Plus, "lt": "0", "lte": "0", "intGt": "1", "intLt": "0", "intLte": "0", "small": 0}
+	for _, c := range []struct {
+		name, field string
+		value       interface{}
+		rule        string
+	}{
+		{"gt at MaxInt64", "gt", maxInt64, "UintGt"},
+		{"gt at MaxInt64+1", "gt", maxInt64Plus, ""},
+		{"gt at MaxUint64", "gt", maxUint64, ""},
+		{"gt at 0", "gt", "0", "UintGt"},
+		{"gte at MaxInt64", "gte", maxInt64, "UintGte"},
+		{"gte at MaxInt64+1", "gte", maxInt64Plus, ""},
+		{"gte at MaxUint64", "gte", maxUint64, ""},
+		{"lt at MaxInt64", "lt", maxInt64, ""},
+		{"lt at MaxInt64+1", "lt", maxInt64Plus, ""},
+		{"lt at MaxUint64", "lt", maxUint64, "UintLt"},
+		{"lte at MaxInt64", "lte", maxInt64, ""},
+		{"lte at MaxInt64+1", "lte", maxInt64Plus, "UintLte"},
+		{"lte at MaxUint64", "lte", maxUint64, "UintLte"},
+		//int_* rules compare without wrapping to negative values
+		{"int_gt at MaxInt64+1", "intGt", maxInt64Plus, ""},
+		{"int_gt at MaxUint64", "intGt", maxUint64, ""},
+		{"int_gt at 0", "intGt", "0", "IntGt"},
+		{"int_lt at MaxInt64", "intLt", maxInt64, "IntLt"},
+		{"int_lt at MaxUint64", "intLt", maxUint64, "IntLt"},
+		{"int_lte at MaxInt64", "intLte", maxInt64, ""},
+		{"int_lte at MaxInt64+1", "intLte", maxInt64Plus, "IntLte"},
+		{"uint32 below the bound", "small", 4294967294, ""},
+		{"uint32 max", "small", 4294967295, "UintLte"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fields := make(map[string]interface{}, len(legal))
+			for name, value := range legal {
+				fields[name] = value
+			}
+			fields[c.field] = c.value
+			data, err := json.Marshal(fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = New().Validate(newMsg(t, fd, "Counter", string(data)))
+			if c.rule == "" && err != nil || c.rule != "" && FindViolation(err, "", c.rule) == nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRegex(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package regex; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {regex: "^[A-Z]+$"}]; }
+message Broken { string sku = 1 [(validator.field) = {regex: "("}]; repeated string tags = 2 [(validator.field) = {regex: "[a-"}]; }`)
+	for _, c := range []struct {
+		name, msg, json, path string
+		compileErr            bool
+		rule                  string
+	}{
+		{"legal", "Item", `{"sku":"AB"}`, "", false, ""},
+		{"illegal", "Item", `{"sku":"ab"}`, "sku", false, "Regex"},
+		{"empty", "Item", `{}`, "sku", false, "Regex"},
+		{"invalid", "Broken", `{"sku":"("}`, "sku", true, "Regex"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := New(WithLogger(&testLogger{}))
+			if err := v.Register(fd.Messages().ByName(protoreflect.Name(c.msg))); (err != nil) != c.compileErr {
+				t.Fatalf("Register: %v", err)
+			} else if c.compileErr && !strings.Contains(err.Error(), "invalid regex") {
+				t.Fatalf("Register: %v", err)
+			}
+			err := v.Validate(newMsg(t, fd, c.msg, c.json))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+	//every invalid regex is reported
+	err := New().Register(fd.Messages().ByName("Broken"))
+	if err == nil || !strings.Contains(err.Error(), "regex.Broken.sku") || !strings.Contains(err.Error(), "regex.Broken.tags") {
+		t.Fatal(err)
+	}
+	if err := New(WithAggregation(AggregateAll)).Validate(newMsg(t, fd, "Broken", `{"tags":["a"]}`)); !MatchViolation(err, "tags[0]", "Regex") {
+		t.Fatal(err)
+	}
+}
+
+func TestRuleFilter(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package filter; import "validator.proto";
+message Item {
+  string sku = 1 [(validator.field) = {length_gt: 2, regex: "^[A-Z]+$"}];
+  string code = 2 [(validator.field) = {regex: "^[A-Z]+$"}];
+}`)
+	msg := newMsg(t, fd, "Item", `{"sku":"a","code":"a"}`)
+	for _, c := range []struct {
+		name   string
+		filter RuleFilter
+		keys   []string
+	}{
+		{"no filter", nil, []string{"sku:LengthGt", "sku:Regex", "code:Regex"}},
+		{"all enabled", func(protoreflect.FieldDescriptor, string) bool { return true }, []string{"sku:LengthGt", "sku:Regex", "code:Regex"}},
+		{"rule kind disabled", func(_ protoreflect.FieldDescriptor, kind string) bool { return kind != "Regex" }, []string{"sku:LengthGt"}},
+		{"rule kind of a field disabled", func(field protoreflect.FieldDescriptor, kind string) bool {
+			return field.Name() != "sku" || kind != "Regex"
+		}, []string{"sku:LengthGt", "code:Regex"}},
+		{"all disabled", func(protoreflect.FieldDescriptor, string) bool { return false }, nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New(WithAggregation(AggregateAll), WithRuleFilter(c.filter)).Validate(msg)
+			if keys := violationKeys(err); strings.Join(keys, ",") != strings.Join(c.keys, ",") {
+				t.Fatalf("got %v, want %v", keys, c.keys)
+			}
+		})
+	}
+	//the first violation of an enabled rule stops the validation, not the disabled ones
+	err := New(WithRuleFilter(func(field protoreflect.FieldDescriptor, _ string) bool { return field.Name() != "sku" })).Validate(msg)
+	if !MatchViolation(err, "code", "Regex") {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateGeneric(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package generic; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 1}]; }`)
+	for _, c := range []struct {
+		name, json, rule string
+	}{
+		{"legal", `{"sku":"ab"}`, ""},
+		{"illegal", `{"sku":"a"}`, "LengthGt"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			msg := newMsg(t, fd, "Item", c.json)
+			//the same outcome as the other entry points of the default validator
+			for name, err := range map[string]error{
+				"Validate[*dynamicpb.Message]": Validate(msg),
+				"Validate[proto.Message]":      Validate[proto.Message](msg),
+				"ValidProto":                   ValidProto(msg),
+				"Validator.Validate":           New().Validate(msg),
+			} {
+				if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, "sku", c.rule) {
+					t.Fatalf("%s: %v", name, err)
+				}
+			}
+		})
+	}
+	//nil messages are legal, typed or not
+	if err := Validate[*dynamicpb.Message](nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate[proto.Message](nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(&FieldValidator{}); err != nil {
+		t.Fatal(err)
+	}
+}
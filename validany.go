@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// WithMessageDescriptor decode the []byte messages passed to ValidAny as md, in the protobuf wire format
+func WithMessageDescriptor(md protoreflect.MessageDescriptor) Option {
+	return func(v *Validator) {
+		v.descriptor = md
+	}
+}
+
+// ValidAny verify whether a message of any supported representation is legal, see Validator.ValidAny.
+// The default validator is used if no option is given, otherwise a validator is created per call:
+// keep a Validator on hot paths.
+func ValidAny(x interface{}, opts ...Option) error {
+	v := std()
+	if len(opts) > 0 {
+		v = New(opts...)
+	}
+	return v.ValidAny(x)
+}
+
+// ValidAny verify whether a message is legal, dispatching on its representation:
+// proto.Message, protoreflect.Message, *dynamic.Message (see ValidMsg),
+// or []byte in the wire format of the message set by WithMessageDescriptor.
+// An unsupported type is an error.
+func (v *Validator) ValidAny(x interface{}) error {
+	if ok, err := v.validLegacy(x); ok {
+		return err
+	}
+	switch m := x.(type) {
+	case nil:
+		return nil
+	case proto.Message:
+		return v.Validate(m)
+	case protoreflect.Message:
+		return v.valid(m)
+	case []byte:
+		if v.descriptor == nil {
+			return fmt.Errorf("[proto valid]no message descriptor to decode []byte, see WithMessageDescriptor")
+		}
+		var mt protoreflect.MessageType = dynamicpb.NewMessageType(v.descriptor)
+		if resolved, err := v.resolver.FindMessageByName(v.descriptor.FullName()); err == nil {
+			mt = resolved
+		}
+		msg := mt.New().Interface()
+		if err := (proto.UnmarshalOptions{Resolver: v.resolver}).Unmarshal(m, msg); err != nil {
+			return fmt.Errorf("[proto valid]decode message[%s]: %w", v.descriptor.FullName(), err)
+		}
+		return v.Validate(msg)
+	}
+	return fmt.Errorf("[proto valid]unsupported message type %T", x)
+}
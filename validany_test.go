@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+func TestValidAny(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package any; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {length_gt: 1}]; }`)
+	md := fd.Messages().ByName("Item")
+	legal, illegal := newMsg(t, fd, "Item", `{"sku":"ab"}`), newMsg(t, fd, "Item", `{"sku":"a"}`)
+	legalWire, err := proto.Marshal(legal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	illegalWire, err := proto.Marshal(illegal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withDescriptor := []Option{WithMessageDescriptor(md)}
+	for _, c := range []struct {
+		name      string
+		x         interface{}
+		opts      []Option
+		violation bool
+		err       string
+	}{
+		{"nil", nil, nil, false, ""},
+		{"legal message", legal, nil, false, ""},
+		{"illegal message", illegal, nil, true, ""},
+		{"reflected message", illegal.ProtoReflect(), nil, true, ""},
+		{"legal wire format", legalWire, withDescriptor, false, ""},
+		{"illegal wire format", illegalWire, withDescriptor, true, ""},
+		{"empty wire format", []byte{}, withDescriptor, true, ""},
+		{"malformed wire format", []byte{0x0a, 0x05, 'a'}, withDescriptor, false, "decode message[any.Item]"},
+		{"wire format without descriptor", legalWire, nil, false, "no message descriptor"},
+		{"unsupported type", "sku: ab", nil, false, "unsupported message type string"},
+		{"unsupported struct", struct{}{}, withDescriptor, false, "unsupported message type struct {}"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New(c.opts...).ValidAny(c.x)
+			switch {
+			case c.violation:
+				if !MatchViolation(err, "sku", "LengthGt") {
+					t.Fatal(err)
+				}
+			case c.err != "":
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Fatal(err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			}
+		})
+	}
+	//the package function creates a validator with the options of the call only
+	if err := ValidAny(illegalWire, withDescriptor...); !MatchViolation(err, "sku", "LengthGt") {
+		t.Fatal(err)
+	}
+	if err := ValidAny(illegalWire); err == nil {
+		t.Fatal("want an error without message descriptor")
+	}
+}
@@ -23,17 +23,341 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Monotonic order of the elements of a repeated field
+type Monotonic int32
+
+const (
+	Monotonic_MONOTONIC_UNSPECIFIED Monotonic = 0
+	// Every element is greater than the previous one.
+	Monotonic_MONOTONIC_INCREASING Monotonic = 1
+	// Every element is greater than or equal to the previous one.
+	Monotonic_MONOTONIC_NON_DECREASING Monotonic = 2
+)
+
+// Enum value maps for Monotonic.
+var (
+	Monotonic_name = map[int32]string{
+		0: "MONOTONIC_UNSPECIFIED",
+		1: "MONOTONIC_INCREASING",
+		2: "MONOTONIC_NON_DECREASING",
+	}
+	Monotonic_value = map[string]int32{
+		"MONOTONIC_UNSPECIFIED":    0,
+		"MONOTONIC_INCREASING":     1,
+		"MONOTONIC_NON_DECREASING": 2,
+	}
+)
+
+func (x Monotonic) Enum() *Monotonic {
+	p := new(Monotonic)
+	*p = x
+	return p
+}
+
+func (x Monotonic) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Monotonic) Descriptor() protoreflect.EnumDescriptor {
+	return file_validator_proto_enumTypes[0].Descriptor()
+}
+
+func (Monotonic) Type() protoreflect.EnumType {
+	return &file_validator_proto_enumTypes[0]
+}
+
+func (x Monotonic) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Monotonic) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = Monotonic(num)
+	return nil
+}
+
+// Deprecated: Use Monotonic.Descriptor instead.
+func (Monotonic) EnumDescriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{0}
+}
+
+// NumericString number type of a numeric string
+type NumericString int32
+
+const (
+	NumericString_NUMERIC_STRING_UNSPECIFIED NumericString = 0
+	// Decimal 64-bit signed integer, e.g. "-42".
+	NumericString_NUMERIC_STRING_INT NumericString = 1
+	// Finite 64-bit floating-point number, e.g. "3.14" or "1e-3".
+	NumericString_NUMERIC_STRING_FLOAT NumericString = 2
+)
+
+// Enum value maps for NumericString.
+var (
+	NumericString_name = map[int32]string{
+		0: "NUMERIC_STRING_UNSPECIFIED",
+		1: "NUMERIC_STRING_INT",
+		2: "NUMERIC_STRING_FLOAT",
+	}
+	NumericString_value = map[string]int32{
+		"NUMERIC_STRING_UNSPECIFIED": 0,
+		"NUMERIC_STRING_INT":         1,
+		"NUMERIC_STRING_FLOAT":       2,
+	}
+)
+
+func (x NumericString) Enum() *NumericString {
+	p := new(NumericString)
+	*p = x
+	return p
+}
+
+func (x NumericString) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NumericString) Descriptor() protoreflect.EnumDescriptor {
+	return file_validator_proto_enumTypes[1].Descriptor()
+}
+
+func (NumericString) Type() protoreflect.EnumType {
+	return &file_validator_proto_enumTypes[1]
+}
+
+func (x NumericString) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *NumericString) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = NumericString(num)
+	return nil
+}
+
+// Deprecated: Use NumericString.Descriptor instead.
+func (NumericString) EnumDescriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{1}
+}
+
+// HashFormat format of a digest or password hash
+type HashFormat int32
+
+const (
+	HashFormat_HASH_FORMAT_UNSPECIFIED HashFormat = 0
+	// bcrypt hash, e.g. $2b$12$ followed by 53 bcrypt base64 characters ($2a$, $2b$ and $2y$ prefixes).
+	HashFormat_HASH_FORMAT_BCRYPT HashFormat = 1
+	// Argon2 PHC string, e.g. $argon2id$v=19$m=65536,t=3,p=4$salt$hash.
+	HashFormat_HASH_FORMAT_ARGON2 HashFormat = 2
+	// SHA-256 digest as 64 hexadecimal characters.
+	HashFormat_HASH_FORMAT_SHA256_HEX HashFormat = 3
+	// SHA-512 digest as 128 hexadecimal characters.
+	HashFormat_HASH_FORMAT_SHA512_HEX HashFormat = 4
+)
+
+// Enum value maps for HashFormat.
+var (
+	HashFormat_name = map[int32]string{
+		0: "HASH_FORMAT_UNSPECIFIED",
+		1: "HASH_FORMAT_BCRYPT",
+		2: "HASH_FORMAT_ARGON2",
+		3: "HASH_FORMAT_SHA256_HEX",
+		4: "HASH_FORMAT_SHA512_HEX",
+	}
+	HashFormat_value = map[string]int32{
+		"HASH_FORMAT_UNSPECIFIED": 0,
+		"HASH_FORMAT_BCRYPT":      1,
+		"HASH_FORMAT_ARGON2":      2,
+		"HASH_FORMAT_SHA256_HEX":  3,
+		"HASH_FORMAT_SHA512_HEX":  4,
+	}
+)
+
+func (x HashFormat) Enum() *HashFormat {
+	p := new(HashFormat)
+	*p = x
+	return p
+}
+
+func (x HashFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_validator_proto_enumTypes[2].Descriptor()
+}
+
+func (HashFormat) Type() protoreflect.EnumType {
+	return &file_validator_proto_enumTypes[2]
+}
+
+func (x HashFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *HashFormat) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = HashFormat(num)
+	return nil
+}
+
+// Deprecated: Use HashFormat.Descriptor instead.
+func (HashFormat) EnumDescriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{2}
+}
+
+// LengthUnit unit of string lengths
+type LengthUnit int32
+
+const (
+	// UTF-8 encoded bytes.
+	LengthUnit_LENGTH_UNIT_BYTES LengthUnit = 0
+	// Unicode code points.
+	LengthUnit_LENGTH_UNIT_RUNES LengthUnit = 1
+	// User-perceived characters (extended grapheme clusters, UAX #29), e.g. an emoji with modifiers counts once.
+	// Meant for user-facing text limits such as display names.
+	LengthUnit_LENGTH_UNIT_GRAPHEMES LengthUnit = 2
+)
+
+// Enum value maps for LengthUnit.
+var (
+	LengthUnit_name = map[int32]string{
+		0: "LENGTH_UNIT_BYTES",
+		1: "LENGTH_UNIT_RUNES",
+		2: "LENGTH_UNIT_GRAPHEMES",
+	}
+	LengthUnit_value = map[string]int32{
+		"LENGTH_UNIT_BYTES":     0,
+		"LENGTH_UNIT_RUNES":     1,
+		"LENGTH_UNIT_GRAPHEMES": 2,
+	}
+)
+
+func (x LengthUnit) Enum() *LengthUnit {
+	p := new(LengthUnit)
+	*p = x
+	return p
+}
+
+func (x LengthUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LengthUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_validator_proto_enumTypes[3].Descriptor()
+}
+
+func (LengthUnit) Type() protoreflect.EnumType {
+	return &file_validator_proto_enumTypes[3]
+}
+
+func (x LengthUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *LengthUnit) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = LengthUnit(num)
+	return nil
+}
+
+// Deprecated: Use LengthUnit.Descriptor instead.
+func (LengthUnit) EnumDescriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{3}
+}
+
+// Severity severity of a violation
+type Severity int32
+
+const (
+	Severity_SEVERITY_UNSPECIFIED Severity = 0
+	// Violation of an enforced rule, failing the validation.
+	Severity_SEVERITY_ERROR Severity = 1
+	// Violation of a rule evaluated in shadow mode, not failing the validation.
+	Severity_SEVERITY_WARNING Severity = 2
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "SEVERITY_ERROR",
+		2: "SEVERITY_WARNING",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"SEVERITY_ERROR":       1,
+		"SEVERITY_WARNING":     2,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_validator_proto_enumTypes[4].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_validator_proto_enumTypes[4]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Severity) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = Severity(num)
+	return nil
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{4}
+}
+
 type FieldValidator struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Uses a Golang RE2-syntax regex to match the field contents.
+	// Uses a Golang RE2-syntax regex to match the field contents, the raw bytes of a bytes field.
+	// An invalid regex rejects every value, Register and Compile report it up front.
 	Regex *string `protobuf:"bytes,1,opt,name=regex" json:"regex,omitempty"`
 	// Field value of integer strictly greater than this value.
 	IntGt *int64 `protobuf:"varint,2,opt,name=int_gt,json=intGt" json:"int_gt,omitempty"`
 	// Field value of integer strictly smaller than this value.
 	IntLt *int64 `protobuf:"varint,3,opt,name=int_lt,json=intLt" json:"int_lt,omitempty"`
+	// User-friendly text of any violation of the rules of the field, returned as is by ValidError.HumanMessage(),
+	// compatible with the human_error of mwitkow/go-proto-validators. Prefer error_message for placeholders.
+	HumanError *string `protobuf:"bytes,5,opt,name=human_error,json=humanError" json:"human_error,omitempty"`
 	// Field value of double strictly greater than this value.
 	// Note that this value can only take on a valid floating point
 	// value. Use together with float_epsilon if you need something more specific.
@@ -56,9 +380,9 @@ type FieldValidator struct {
 	FloatLte *float64 `protobuf:"fixed64,10,opt,name=float_lte,json=floatLte" json:"float_lte,omitempty"`
 	// Used for string fields, requires the string to be not empty (i.e different from "").
 	StringNotEmpty *bool `protobuf:"varint,11,opt,name=string_not_empty,json=stringNotEmpty" json:"string_not_empty,omitempty"`
-	// Repeated field with at least this number of elements.
+	// Repeated field with at least this number of elements, or map field with at least this number of entries.
 	RepeatedCountMin *int64 `protobuf:"varint,12,opt,name=repeated_count_min,json=repeatedCountMin" json:"repeated_count_min,omitempty"`
-	// Repeated field with at most this number of elements.
+	// Repeated field with at most this number of elements, or map field with at most this number of entries.
 	RepeatedCountMax *int64 `protobuf:"varint,13,opt,name=repeated_count_max,json=repeatedCountMax" json:"repeated_count_max,omitempty"`
 	// Field value of length greater than this value.
 	LengthGt *int64 `protobuf:"varint,14,opt,name=length_gt,json=lengthGt" json:"length_gt,omitempty"`
@@ -66,8 +390,133 @@ type FieldValidator struct {
 	LengthLt *int64 `protobuf:"varint,15,opt,name=length_lt,json=lengthLt" json:"length_lt,omitempty"`
 	// Field value of length strictly equal to this value.
 	LengthEq *int64 `protobuf:"varint,16,opt,name=length_eq,json=lengthEq" json:"length_eq,omitempty"`
-	// Requires that the value is in the enum.
+	// Requires that the value is in the enum. Applies to every element of a repeated enum.
 	IsInEnum *bool `protobuf:"varint,17,opt,name=is_in_enum,json=isInEnum" json:"is_in_enum,omitempty"`
+	// Requires the string to be a valid IPv4 or IPv6 address.
+	Ip *bool `protobuf:"varint,19,opt,name=ip" json:"ip,omitempty"`
+	// Requires the string to be a valid IPv4 address.
+	Ipv4 *bool `protobuf:"varint,20,opt,name=ipv4" json:"ipv4,omitempty"`
+	// Requires the string to be a valid IPv6 address.
+	Ipv6 *bool `protobuf:"varint,21,opt,name=ipv6" json:"ipv6,omitempty"`
+	// Requires the string to be a private IP address (RFC 1918 for IPv4, RFC 4193 ULA for IPv6).
+	IpPrivate *bool `protobuf:"varint,22,opt,name=ip_private,json=ipPrivate" json:"ip_private,omitempty"`
+	// Requires the string to be a public IP address, i.e. a global unicast address
+	// that is not private. Loopback, link-local and unspecified addresses are rejected.
+	IpPublic *bool `protobuf:"varint,23,opt,name=ip_public,json=ipPublic" json:"ip_public,omitempty"`
+	// Requires the integer to be a valid port number, i.e. in the range 1-65535.
+	IntPort *bool `protobuf:"varint,24,opt,name=int_port,json=intPort" json:"int_port,omitempty"`
+	// Used together with int_port, additionally accepts 0 (e.g. "pick any free port").
+	IntPortAllowZero *bool `protobuf:"varint,25,opt,name=int_port_allow_zero,json=intPortAllowZero" json:"int_port_allow_zero,omitempty"`
+	// Schema version (e.g. "1.4" or "v2") from which the rule applies, inclusive.
+	// Compared with the version set by WithSchemaVersion.
+	SinceVersion *string `protobuf:"bytes,26,opt,name=since_version,json=sinceVersion" json:"since_version,omitempty"`
+	// Schema version from which the rule no longer applies, exclusive.
+	// A rule with until_version is skipped if no schema version is set, i.e. the newest schema is assumed.
+	UntilVersion *string `protobuf:"bytes,27,opt,name=until_version,json=untilVersion" json:"until_version,omitempty"`
+	// Rules merged onto this rule when their since_version/until_version scope matches the schema version,
+	// e.g. a stricter length_lt for newer API versions. Their lists (e.g. enum_in) replace those of this rule.
+	Versioned []*FieldValidator `protobuf:"bytes,28,rep,name=versioned" json:"versioned,omitempty"`
+	// Evaluates the rule in shadow mode: violations are reported to the OnViolation hook
+	// but never fail the validation, to roll out new constraints safely.
+	Shadow *bool `protobuf:"varint,29,opt,name=shadow" json:"shadow,omitempty"`
+	// Requires every path of a google.protobuf.FieldMask to be well-formed, i.e. dot-separated field names.
+	FieldMask *bool `protobuf:"varint,30,opt,name=field_mask,json=fieldMask" json:"field_mask,omitempty"`
+	// Used together with field_mask, additionally requires every path to refer to an existing field
+	// of the message with this full name (e.g. "example.Order"). Only the last field of a path may be repeated.
+	FieldMaskTarget *string `protobuf:"bytes,31,opt,name=field_mask_target,json=fieldMaskTarget" json:"field_mask_target,omitempty"`
+	// Requires the enum value to be one of these numbers. Applies to every element of a repeated enum.
+	EnumIn []int32 `protobuf:"varint,32,rep,name=enum_in,json=enumIn" json:"enum_in,omitempty"`
+	// Requires the enum value to be none of these numbers. Applies to every element of a repeated enum.
+	EnumNotIn []int32 `protobuf:"varint,33,rep,name=enum_not_in,json=enumNotIn" json:"enum_not_in,omitempty"`
+	// Name of a Go function registered with RegisterFunc or WithFunc, called with the value at validation time,
+	// as an escape hatch for checks that can't be expressed declaratively. Applies to every element of a repeated field.
+	// It is evaluated after the other rules of the field. An unregistered name rejects every value,
+	// Register and Compile report it up front.
+	GoFunc *string `protobuf:"bytes,34,opt,name=go_func,json=goFunc" json:"go_func,omitempty"`
+	// Unit in which length_gt, length_lt and length_eq count the length of a string, bytes by default.
+	// Lengths of bytes fields are always counted in bytes.
+	LengthUnit *LengthUnit `protobuf:"varint,35,opt,name=length_unit,json=lengthUnit,enum=validator.LengthUnit" json:"length_unit,omitempty"`
+	// Requires the named singular scalar field of the elements of a repeated message field to be unique across elements,
+	// e.g. repeated_unique_by: "sku" on repeated Item items. Elements with the field unset are skipped.
+	// A name that is not a singular scalar field of the elements rejects every non-empty list, Register and Compile report it up front.
+	RepeatedUniqueBy *string `protobuf:"bytes,36,opt,name=repeated_unique_by,json=repeatedUniqueBy" json:"repeated_unique_by,omitempty"`
+	// Skips the field, including its sub-messages, when the call carries a trust token verified by the validator
+	// (see ContextWithTrustToken), e.g. for internal hops re-validating huge blobs already validated at the edge.
+	PreValidated *bool `protobuf:"varint,37,opt,name=pre_validated,json=preValidated" json:"pre_validated,omitempty"`
+	// Validation groups of the rule: a rule with groups is enforced only when one of them is active
+	// (see WithGroups and CallGroups), a rule without groups is always enforced.
+	Groups []string `protobuf:"bytes,38,rep,name=groups" json:"groups,omitempty"`
+	// Evaluation priority of the field: fields of higher priority are validated first, 0 by default.
+	// Useful to fail fast on cheap or likely failing fields when violations are not aggregated.
+	Priority *int32 `protobuf:"varint,39,opt,name=priority" json:"priority,omitempty"`
+	// Requires the string to look like a digest or password hash of one of these formats, e.g. to verify
+	// credentials were hashed upstream and never arrive in plaintext. The value is never echoed in violations.
+	HashFormat []HashFormat `protobuf:"varint,40,rep,name=hash_format,json=hashFormat,enum=validator.HashFormat" json:"hash_format,omitempty"`
+	// Requires the string to be a time of day HH:MM or HH:MM:SS on a 24-hour clock, e.g. 09:30, parsed so 25:61 is rejected.
+	StringTimeOfDay *bool `protobuf:"varint,41,opt,name=string_time_of_day,json=stringTimeOfDay" json:"string_time_of_day,omitempty"`
+	// Requires the string to be an English day of the week, full or abbreviated, case-insensitive, e.g. Monday or mon.
+	StringDayOfWeek *bool `protobuf:"varint,42,opt,name=string_day_of_week,json=stringDayOfWeek" json:"string_day_of_week,omitempty"`
+	// Parses the string as a number and applies the int_* or float_* rules to the parsed value,
+	// for APIs transporting numbers as strings. Unparsable values and values overflowing 64 bits are rejected.
+	NumericString *NumericString `protobuf:"varint,43,opt,name=numeric_string,json=numericString,enum=validator.NumericString" json:"numeric_string,omitempty"`
+	// Verifies IDs of the form <body>-<checksum> with the named checksum algorithm:
+	// "crc32" (8 hex digits of the IEEE CRC-32 of the body), "mod97" (2 digits of ISO 7064 MOD 97-10)
+	// or a name registered with RegisterChecksum / WithChecksum. An unregistered name rejects every value.
+	StringChecksum *string `protobuf:"bytes,44,opt,name=string_checksum,json=stringChecksum" json:"string_checksum,omitempty"`
+	// Requires the elements of a repeated numeric, google.protobuf.Timestamp or google.protobuf.Duration field to be ordered,
+	// e.g. the points of a time series. The first element out of order is reported.
+	RepeatedMonotonic *Monotonic `protobuf:"varint,45,opt,name=repeated_monotonic,json=repeatedMonotonic,enum=validator.Monotonic" json:"repeated_monotonic,omitempty"`
+	// Validates a postal code in the format of a country, given as an ISO 3166-1 alpha-2 code, e.g. "US" or "GB".
+	// Codes of countries missing from the embedded table are legal.
+	StringPostalCode *string `protobuf:"bytes,46,opt,name=string_postal_code,json=stringPostalCode" json:"string_postal_code,omitempty"`
+	// Name of a sibling string field holding the ISO 3166-1 alpha-2 code of the country of the postal code,
+	// taking precedence over string_postal_code when set. Postal codes of an unknown country are legal.
+	PostalCodeCountryField *string `protobuf:"bytes,47,opt,name=postal_code_country_field,json=postalCodeCountryField" json:"postal_code_country_field,omitempty"`
+	// Requires the string keys of a map to match the regex, e.g. "^[a-z0-9-]{1,63}$" for label maps.
+	MapKeyRegex *string `protobuf:"bytes,48,opt,name=map_key_regex,json=mapKeyRegex" json:"map_key_regex,omitempty"`
+	// Requires the keys of a map to be one of the values, integer and bool keys are compared in decimal / "true" / "false" form.
+	MapKeyIn []string `protobuf:"bytes,49,rep,name=map_key_in,json=mapKeyIn" json:"map_key_in,omitempty"`
+	// Rules of fields nested in the message of the field, enforced in addition to their annotated rules,
+	// to constrain fields of imported types that can't be annotated directly.
+	Nested []*NestedRule `protobuf:"bytes,50,rep,name=nested" json:"nested,omitempty"`
+	// Maximum encoded size in bytes (proto.Size) of a message field, or of every element of a repeated message field,
+	// to protect storage rows and queues from oversized sub-payloads.
+	MsgMaxBytes *int64 `protobuf:"varint,51,opt,name=msg_max_bytes,json=msgMaxBytes" json:"msg_max_bytes,omitempty"`
+	// Rejects strings too similar to an entry of a denylist provided at runtime with WithDenylistSource,
+	// e.g. to prevent impersonation of staff accounts in usernames and display names.
+	// Every value is rejected without a denylist source or when the denylist can't be fetched.
+	StringNotSimilar *SimilarityRule `protobuf:"bytes,52,opt,name=string_not_similar,json=stringNotSimilar" json:"string_not_similar,omitempty"`
+	// Replaces the generated message of any violation of the rules of the field, e.g. "{{value}} is not a valid SKU".
+	// {{value}} is replaced by the violating value and {{rule}} by the violated rule, e.g. "Regex".
+	ErrorMessage *string `protobuf:"bytes,53,opt,name=error_message,json=errorMessage" json:"error_message,omitempty"`
+	// Field value of unsigned integer strictly greater than this value, compared without conversion to int64.
+	UintGt *uint64 `protobuf:"varint,54,opt,name=uint_gt,json=uintGt" json:"uint_gt,omitempty"`
+	// Field value of unsigned integer strictly smaller than this value, compared without conversion to int64.
+	UintLt *uint64 `protobuf:"varint,55,opt,name=uint_lt,json=uintLt" json:"uint_lt,omitempty"`
+	// Field value of unsigned integer greater or equal to this value, compared without conversion to int64.
+	UintGte *uint64 `protobuf:"varint,56,opt,name=uint_gte,json=uintGte" json:"uint_gte,omitempty"`
+	// Field value of unsigned integer smaller or equal to this value, compared without conversion to int64.
+	UintLte *uint64 `protobuf:"varint,57,opt,name=uint_lte,json=uintLte" json:"uint_lte,omitempty"`
+	// Field value of integer greater or equal to this value.
+	IntGte *int64 `protobuf:"varint,58,opt,name=int_gte,json=intGte" json:"int_gte,omitempty"`
+	// Field value of integer smaller or equal to this value.
+	IntLte *int64 `protobuf:"varint,59,opt,name=int_lte,json=intLte" json:"int_lte,omitempty"`
+	// Amount rules of a google.type.Money field, or of a decimal amount held by a string or google.type.Decimal field.
+	Money *MoneyRule `protobuf:"bytes,60,opt,name=money" json:"money,omitempty"`
+	// Requires a google.type.LatLng to hold a latitude in [-90, 90] and a longitude in [-180, 180].
+	LatLng *bool `protobuf:"varint,61,opt,name=lat_lng,json=latLng" json:"lat_lng,omitempty"`
+	// Calendar rules of a google.type.Date field.
+	Date *DateRule `protobuf:"bytes,62,opt,name=date" json:"date,omitempty"`
+	// Clock rules of a google.type.TimeOfDay field.
+	TimeOfDay *TimeOfDayRule `protobuf:"bytes,63,opt,name=time_of_day,json=timeOfDay" json:"time_of_day,omitempty"`
+	// Requires a string or bytes to start with this prefix, e.g. "sk_".
+	StringPrefix *string `protobuf:"bytes,64,opt,name=string_prefix,json=stringPrefix" json:"string_prefix,omitempty"`
+	// Requires a string or bytes to end with this suffix, e.g. ".example.com".
+	StringSuffix *string `protobuf:"bytes,65,opt,name=string_suffix,json=stringSuffix" json:"string_suffix,omitempty"`
+	// Requires a string to contain this substring.
+	StringContains *string `protobuf:"bytes,66,opt,name=string_contains,json=stringContains" json:"string_contains,omitempty"`
+	// Requires a string not to contain this substring, e.g. "..".
+	StringNotContains *string `protobuf:"bytes,67,opt,name=string_not_contains,json=stringNotContains" json:"string_not_contains,omitempty"`
 }
 
 func (x *FieldValidator) Reset() {
@@ -123,6 +572,13 @@ func (x *FieldValidator) GetIntLt() int64 {
 	return 0
 }
 
+func (x *FieldValidator) GetHumanError() string {
+	if x != nil && x.HumanError != nil {
+		return *x.HumanError
+	}
+	return ""
+}
+
 func (x *FieldValidator) GetFloatGt() float64 {
 	if x != nil && x.FloatGt != nil {
 		return *x.FloatGt
@@ -207,104 +663,1701 @@ func (x *FieldValidator) GetIsInEnum() bool {
 	return false
 }
 
-var file_validator_proto_extTypes = []protoimpl.ExtensionInfo{
-	{
-		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
-		ExtensionType: (*FieldValidator)(nil),
-		Field:         65020,
-		Name:          "validator.field",
-		Tag:           "bytes,65020,opt,name=field",
-		Filename:      "validator.proto",
-	},
+func (x *FieldValidator) GetIp() bool {
+	if x != nil && x.Ip != nil {
+		return *x.Ip
+	}
+	return false
 }
 
-// Extension fields to descriptorpb.FieldOptions.
-var (
-	// optional validator.FieldValidator field = 65020;
-	E_Field = &file_validator_proto_extTypes[0]
-)
+func (x *FieldValidator) GetIpv4() bool {
+	if x != nil && x.Ipv4 != nil {
+		return *x.Ipv4
+	}
+	return false
+}
 
-var File_validator_proto protoreflect.FileDescriptor
+func (x *FieldValidator) GetIpv6() bool {
+	if x != nil && x.Ipv6 != nil {
+		return *x.Ipv6
+	}
+	return false
+}
 
-var file_validator_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0x20, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe4,
-	0x03, 0x0a, 0x0e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
-	0x72, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x6e, 0x74, 0x5f, 0x67,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x74, 0x47, 0x74, 0x12, 0x15,
-	0x0a, 0x06, 0x69, 0x6e, 0x74, 0x5f, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x69, 0x6e, 0x74, 0x4c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x67,
-	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x47, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x07, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x4c, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66,
-	0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x65, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x0c, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x45, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e,
-	0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x67, 0x74, 0x65, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x47, 0x74, 0x65, 0x12, 0x1b, 0x0a,
-	0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x6c, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x4c, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4e, 0x6f, 0x74, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x10, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d,
-	0x69, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
-	0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x61, 0x78,
-	0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x67, 0x74, 0x18, 0x0e, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x47, 0x74, 0x12, 0x1b, 0x0a,
-	0x09, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x6c, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x08, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x4c, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65,
-	0x6e, 0x67, 0x74, 0x68, 0x5f, 0x65, 0x71, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c,
-	0x65, 0x6e, 0x67, 0x74, 0x68, 0x45, 0x71, 0x12, 0x1c, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x69, 0x6e,
-	0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x49,
-	0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x3a, 0x50, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfc, 0xfb,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
-	0x72, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
-	0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x3b, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+func (x *FieldValidator) GetIpPrivate() bool {
+	if x != nil && x.IpPrivate != nil {
+		return *x.IpPrivate
+	}
+	return false
 }
 
-var (
-	file_validator_proto_rawDescOnce sync.Once
-	file_validator_proto_rawDescData = file_validator_proto_rawDesc
-)
+func (x *FieldValidator) GetIpPublic() bool {
+	if x != nil && x.IpPublic != nil {
+		return *x.IpPublic
+	}
+	return false
+}
 
-func file_validator_proto_rawDescGZIP() []byte {
-	file_validator_proto_rawDescOnce.Do(func() {
-		file_validator_proto_rawDescData = protoimpl.X.CompressGZIP(file_validator_proto_rawDescData)
-	})
-	return file_validator_proto_rawDescData
+func (x *FieldValidator) GetIntPort() bool {
+	if x != nil && x.IntPort != nil {
+		return *x.IntPort
+	}
+	return false
 }
 
-var file_validator_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_validator_proto_goTypes = []interface{}{
-	(*FieldValidator)(nil),            // 0: validator.FieldValidator
-	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+func (x *FieldValidator) GetIntPortAllowZero() bool {
+	if x != nil && x.IntPortAllowZero != nil {
+		return *x.IntPortAllowZero
+	}
+	return false
 }
-var file_validator_proto_depIdxs = []int32{
-	1, // 0: validator.field:extendee -> google.protobuf.FieldOptions
-	0, // 1: validator.field:type_name -> validator.FieldValidator
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	1, // [1:2] is the sub-list for extension type_name
-	0, // [0:1] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *FieldValidator) GetSinceVersion() string {
+	if x != nil && x.SinceVersion != nil {
+		return *x.SinceVersion
+	}
+	return ""
 }
 
-func init() { file_validator_proto_init() }
-func file_validator_proto_init() {
-	if File_validator_proto != nil {
-		return
+func (x *FieldValidator) GetUntilVersion() string {
+	if x != nil && x.UntilVersion != nil {
+		return *x.UntilVersion
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_validator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FieldValidator); i {
+	return ""
+}
+
+func (x *FieldValidator) GetVersioned() []*FieldValidator {
+	if x != nil {
+		return x.Versioned
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetShadow() bool {
+	if x != nil && x.Shadow != nil {
+		return *x.Shadow
+	}
+	return false
+}
+
+func (x *FieldValidator) GetFieldMask() bool {
+	if x != nil && x.FieldMask != nil {
+		return *x.FieldMask
+	}
+	return false
+}
+
+func (x *FieldValidator) GetFieldMaskTarget() string {
+	if x != nil && x.FieldMaskTarget != nil {
+		return *x.FieldMaskTarget
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetEnumIn() []int32 {
+	if x != nil {
+		return x.EnumIn
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetEnumNotIn() []int32 {
+	if x != nil {
+		return x.EnumNotIn
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetGoFunc() string {
+	if x != nil && x.GoFunc != nil {
+		return *x.GoFunc
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetLengthUnit() LengthUnit {
+	if x != nil && x.LengthUnit != nil {
+		return *x.LengthUnit
+	}
+	return LengthUnit_LENGTH_UNIT_BYTES
+}
+
+func (x *FieldValidator) GetRepeatedUniqueBy() string {
+	if x != nil && x.RepeatedUniqueBy != nil {
+		return *x.RepeatedUniqueBy
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetPreValidated() bool {
+	if x != nil && x.PreValidated != nil {
+		return *x.PreValidated
+	}
+	return false
+}
+
+func (x *FieldValidator) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetHashFormat() []HashFormat {
+	if x != nil {
+		return x.HashFormat
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetStringTimeOfDay() bool {
+	if x != nil && x.StringTimeOfDay != nil {
+		return *x.StringTimeOfDay
+	}
+	return false
+}
+
+func (x *FieldValidator) GetStringDayOfWeek() bool {
+	if x != nil && x.StringDayOfWeek != nil {
+		return *x.StringDayOfWeek
+	}
+	return false
+}
+
+func (x *FieldValidator) GetNumericString() NumericString {
+	if x != nil && x.NumericString != nil {
+		return *x.NumericString
+	}
+	return NumericString_NUMERIC_STRING_UNSPECIFIED
+}
+
+func (x *FieldValidator) GetStringChecksum() string {
+	if x != nil && x.StringChecksum != nil {
+		return *x.StringChecksum
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetRepeatedMonotonic() Monotonic {
+	if x != nil && x.RepeatedMonotonic != nil {
+		return *x.RepeatedMonotonic
+	}
+	return Monotonic_MONOTONIC_UNSPECIFIED
+}
+
+func (x *FieldValidator) GetStringPostalCode() string {
+	if x != nil && x.StringPostalCode != nil {
+		return *x.StringPostalCode
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetPostalCodeCountryField() string {
+	if x != nil && x.PostalCodeCountryField != nil {
+		return *x.PostalCodeCountryField
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetMapKeyRegex() string {
+	if x != nil && x.MapKeyRegex != nil {
+		return *x.MapKeyRegex
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetMapKeyIn() []string {
+	if x != nil {
+		return x.MapKeyIn
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetNested() []*NestedRule {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetMsgMaxBytes() int64 {
+	if x != nil && x.MsgMaxBytes != nil {
+		return *x.MsgMaxBytes
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetStringNotSimilar() *SimilarityRule {
+	if x != nil {
+		return x.StringNotSimilar
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetUintGt() uint64 {
+	if x != nil && x.UintGt != nil {
+		return *x.UintGt
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetUintLt() uint64 {
+	if x != nil && x.UintLt != nil {
+		return *x.UintLt
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetUintGte() uint64 {
+	if x != nil && x.UintGte != nil {
+		return *x.UintGte
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetUintLte() uint64 {
+	if x != nil && x.UintLte != nil {
+		return *x.UintLte
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetIntGte() int64 {
+	if x != nil && x.IntGte != nil {
+		return *x.IntGte
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetIntLte() int64 {
+	if x != nil && x.IntLte != nil {
+		return *x.IntLte
+	}
+	return 0
+}
+
+func (x *FieldValidator) GetMoney() *MoneyRule {
+	if x != nil {
+		return x.Money
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetLatLng() bool {
+	if x != nil && x.LatLng != nil {
+		return *x.LatLng
+	}
+	return false
+}
+
+func (x *FieldValidator) GetDate() *DateRule {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetTimeOfDay() *TimeOfDayRule {
+	if x != nil {
+		return x.TimeOfDay
+	}
+	return nil
+}
+
+func (x *FieldValidator) GetStringPrefix() string {
+	if x != nil && x.StringPrefix != nil {
+		return *x.StringPrefix
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetStringSuffix() string {
+	if x != nil && x.StringSuffix != nil {
+		return *x.StringSuffix
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetStringContains() string {
+	if x != nil && x.StringContains != nil {
+		return *x.StringContains
+	}
+	return ""
+}
+
+func (x *FieldValidator) GetStringNotContains() string {
+	if x != nil && x.StringNotContains != nil {
+		return *x.StringNotContains
+	}
+	return ""
+}
+
+// DateRule calendar rules, any set rule requires a full valid date (e.g. no February 30 nor a year 0)
+type DateRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Earliest date, inclusive, e.g. "2000-01-01".
+	Min *string `protobuf:"bytes,1,opt,name=min" json:"min,omitempty"`
+	// Latest date, inclusive, e.g. "2099-12-31".
+	Max *string `protobuf:"bytes,2,opt,name=max" json:"max,omitempty"`
+	// Rejects dates before the current date of the validator clock (see WithClock), in the location of the clock.
+	NotBeforeToday *bool `protobuf:"varint,3,opt,name=not_before_today,json=notBeforeToday" json:"not_before_today,omitempty"`
+}
+
+func (x *DateRule) Reset() {
+	*x = DateRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DateRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateRule) ProtoMessage() {}
+
+func (x *DateRule) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateRule.ProtoReflect.Descriptor instead.
+func (*DateRule) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DateRule) GetMin() string {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return ""
+}
+
+func (x *DateRule) GetMax() string {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return ""
+}
+
+func (x *DateRule) GetNotBeforeToday() bool {
+	if x != nil && x.NotBeforeToday != nil {
+		return *x.NotBeforeToday
+	}
+	return false
+}
+
+// TimeOfDayRule clock rules, any set rule requires a valid time of day, i.e. hours 0-23, minutes 0-59,
+// seconds 0-60 (leap second) and nanos 0-999999999
+type TimeOfDayRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Earliest time of day, inclusive, "HH:MM" or "HH:MM:SS", e.g. "08:00".
+	Min *string `protobuf:"bytes,1,opt,name=min" json:"min,omitempty"`
+	// Latest time of day, inclusive, "HH:MM" or "HH:MM:SS", e.g. "18:30".
+	Max *string `protobuf:"bytes,2,opt,name=max" json:"max,omitempty"`
+}
+
+func (x *TimeOfDayRule) Reset() {
+	*x = TimeOfDayRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeOfDayRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeOfDayRule) ProtoMessage() {}
+
+func (x *TimeOfDayRule) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeOfDayRule.ProtoReflect.Descriptor instead.
+func (*TimeOfDayRule) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TimeOfDayRule) GetMin() string {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return ""
+}
+
+func (x *TimeOfDayRule) GetMax() string {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return ""
+}
+
+// MoneyRule amount and currency rules, amounts are decimal strings (e.g. "10.50") compared exactly
+type MoneyRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Allowed ISO 4217 currency codes of a google.type.Money, e.g. "EUR".
+	CurrencyIn []string `protobuf:"bytes,1,rep,name=currency_in,json=currencyIn" json:"currency_in,omitempty"`
+	// Minimum amount, inclusive.
+	Min *string `protobuf:"bytes,2,opt,name=min" json:"min,omitempty"`
+	// Maximum amount, inclusive.
+	Max *string `protobuf:"bytes,3,opt,name=max" json:"max,omitempty"`
+	// Rejects negative amounts.
+	NonNegative *bool `protobuf:"varint,4,opt,name=non_negative,json=nonNegative" json:"non_negative,omitempty"`
+}
+
+func (x *MoneyRule) Reset() {
+	*x = MoneyRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoneyRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoneyRule) ProtoMessage() {}
+
+func (x *MoneyRule) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoneyRule.ProtoReflect.Descriptor instead.
+func (*MoneyRule) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MoneyRule) GetCurrencyIn() []string {
+	if x != nil {
+		return x.CurrencyIn
+	}
+	return nil
+}
+
+func (x *MoneyRule) GetMin() string {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return ""
+}
+
+func (x *MoneyRule) GetMax() string {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return ""
+}
+
+func (x *MoneyRule) GetNonNegative() bool {
+	if x != nil && x.NonNegative != nil {
+		return *x.NonNegative
+	}
+	return false
+}
+
+// SimilarityRule denylist similarity rule
+type SimilarityRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the denylist passed to the DenylistSource.
+	Denylist *string `protobuf:"bytes,1,opt,name=denylist" json:"denylist,omitempty"`
+	// Maximum Levenshtein distance (in runes) of a rejected string from an entry, 0 rejects exact matches only.
+	MaxDistance *int32 `protobuf:"varint,2,opt,name=max_distance,json=maxDistance" json:"max_distance,omitempty"`
+	// Compares strings case-insensitively.
+	IgnoreCase *bool `protobuf:"varint,3,opt,name=ignore_case,json=ignoreCase" json:"ignore_case,omitempty"`
+}
+
+func (x *SimilarityRule) Reset() {
+	*x = SimilarityRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimilarityRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarityRule) ProtoMessage() {}
+
+func (x *SimilarityRule) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarityRule.ProtoReflect.Descriptor instead.
+func (*SimilarityRule) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SimilarityRule) GetDenylist() string {
+	if x != nil && x.Denylist != nil {
+		return *x.Denylist
+	}
+	return ""
+}
+
+func (x *SimilarityRule) GetMaxDistance() int32 {
+	if x != nil && x.MaxDistance != nil {
+		return *x.MaxDistance
+	}
+	return 0
+}
+
+func (x *SimilarityRule) GetIgnoreCase() bool {
+	if x != nil && x.IgnoreCase != nil {
+		return *x.IgnoreCase
+	}
+	return false
+}
+
+// NestedRule rule of a field nested in the message of the annotated field
+type NestedRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Dot-separated proto names of the path from the message of the annotated field, e.g. "address.country".
+	// Every element but the last is a singular message field, the rule is skipped if one of them is not set.
+	FieldPath *string         `protobuf:"bytes,1,opt,name=field_path,json=fieldPath" json:"field_path,omitempty"`
+	Rule      *FieldValidator `protobuf:"bytes,2,opt,name=rule" json:"rule,omitempty"`
+}
+
+func (x *NestedRule) Reset() {
+	*x = NestedRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NestedRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NestedRule) ProtoMessage() {}
+
+func (x *NestedRule) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NestedRule.ProtoReflect.Descriptor instead.
+func (*NestedRule) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NestedRule) GetFieldPath() string {
+	if x != nil && x.FieldPath != nil {
+		return *x.FieldPath
+	}
+	return ""
+}
+
+func (x *NestedRule) GetRule() *FieldValidator {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// RuleSet rules overlaid at runtime on top of the proto annotations,
+// e.g. stricter limits for free-tier tenants.
+type RuleSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the overlay, used to select it per validation call.
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Overlaid rules keyed by message full name.
+	Messages map[string]*MessageRules `protobuf:"bytes,2,rep,name=messages" json:"messages,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Evaluates the overlaid rules in shadow mode: the annotated rules are still enforced,
+	// violations of the overlaid rules are only reported to the OnViolation hook.
+	Shadow *bool `protobuf:"varint,3,opt,name=shadow" json:"shadow,omitempty"`
+}
+
+func (x *RuleSet) Reset() {
+	*x = RuleSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuleSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSet) ProtoMessage() {}
+
+func (x *RuleSet) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSet.ProtoReflect.Descriptor instead.
+func (*RuleSet) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RuleSet) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *RuleSet) GetMessages() map[string]*MessageRules {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *RuleSet) GetShadow() bool {
+	if x != nil && x.Shadow != nil {
+		return *x.Shadow
+	}
+	return false
+}
+
+type MessageRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Overlaid rules keyed by field name. Fields set here replace the same fields of the annotated rule,
+	// other annotated fields are kept.
+	Fields map[string]*FieldValidator `protobuf:"bytes,1,rep,name=fields" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (x *MessageRules) Reset() {
+	*x = MessageRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageRules) ProtoMessage() {}
+
+func (x *MessageRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageRules.ProtoReflect.Descriptor instead.
+func (*MessageRules) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MessageRules) GetFields() map[string]*FieldValidator {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// MethodValidator validation of the requests of an RPC method, e.g. validating the same message differently for Create and Update
+type MethodValidator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Validation groups active for the requests of the method, replacing those of WithGroups when not empty.
+	Groups []string `protobuf:"bytes,1,rep,name=groups" json:"groups,omitempty"`
+	// Name of the rule overlay selected for the requests of the method.
+	Overlay *string `protobuf:"bytes,2,opt,name=overlay" json:"overlay,omitempty"`
+}
+
+func (x *MethodValidator) Reset() {
+	*x = MethodValidator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MethodValidator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodValidator) ProtoMessage() {}
+
+func (x *MethodValidator) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodValidator.ProtoReflect.Descriptor instead.
+func (*MethodValidator) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MethodValidator) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *MethodValidator) GetOverlay() string {
+	if x != nil && x.Overlay != nil {
+		return *x.Overlay
+	}
+	return ""
+}
+
+// MsgValidator rules of a message as a whole, enforced when the message is validated
+type MsgValidator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Names of the singular message fields that must be set, e.g. the required nested address of an order.
+	MsgExists []string `protobuf:"bytes,1,rep,name=msg_exists,json=msgExists" json:"msg_exists,omitempty"`
+	// Sets of fields of which at least one field must be set (a list non empty, a scalar non zero),
+	// e.g. a contact reachable by email or phone.
+	AtLeastOneOf []*FieldSet `protobuf:"bytes,2,rep,name=at_least_one_of,json=atLeastOneOf" json:"at_least_one_of,omitempty"`
+}
+
+func (x *MsgValidator) Reset() {
+	*x = MsgValidator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MsgValidator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MsgValidator) ProtoMessage() {}
+
+func (x *MsgValidator) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MsgValidator.ProtoReflect.Descriptor instead.
+func (*MsgValidator) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MsgValidator) GetMsgExists() []string {
+	if x != nil {
+		return x.MsgExists
+	}
+	return nil
+}
+
+func (x *MsgValidator) GetAtLeastOneOf() []*FieldSet {
+	if x != nil {
+		return x.AtLeastOneOf
+	}
+	return nil
+}
+
+// FieldSet names of fields of a message
+type FieldSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields []string `protobuf:"bytes,1,rep,name=fields" json:"fields,omitempty"`
+}
+
+func (x *FieldSet) Reset() {
+	*x = FieldSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldSet) ProtoMessage() {}
+
+func (x *FieldSet) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldSet.ProtoReflect.Descriptor instead.
+func (*FieldSet) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FieldSet) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// ValidationResult result of the validation of a message, e.g. to persist it or to send it to another service
+type ValidationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Full name of the validated message.
+	Message    *string                       `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Violations []*ValidationResult_Violation `protobuf:"bytes,2,rep,name=violations" json:"violations,omitempty"`
+	// Whether violations were dropped by the cap of WithMaxViolations.
+	Truncated *bool `protobuf:"varint,3,opt,name=truncated" json:"truncated,omitempty"`
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidationResult) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetViolations() []*ValidationResult_Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetTruncated() bool {
+	if x != nil && x.Truncated != nil {
+		return *x.Truncated
+	}
+	return false
+}
+
+// Violation violation of a rule
+type ValidationResult_Violation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the violating value in the path format of the validator, e.g. "items[0].sku".
+	Path *string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// Violated rule, e.g. "Regex".
+	Rule *string `protobuf:"bytes,2,opt,name=rule" json:"rule,omitempty"`
+	// Value of the rule, formatted, e.g. the regular expression.
+	Constraint *string `protobuf:"bytes,3,opt,name=constraint" json:"constraint,omitempty"`
+	// Violating value, formatted, e.g. the string length for length rules.
+	Actual *string `protobuf:"bytes,4,opt,name=actual" json:"actual,omitempty"`
+	// Human readable description, the error_message or human_error of the field if set.
+	Message  *string   `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	Severity *Severity `protobuf:"varint,6,opt,name=severity,enum=validator.Severity" json:"severity,omitempty"`
+	// Number of elements of the repeated field failing the rule when collapsed, 1 otherwise.
+	Count *int32 `protobuf:"varint,7,opt,name=count" json:"count,omitempty"`
+	// Layer the violated rule comes from, e.g. "overlay(lax)".
+	Provenance *string `protobuf:"bytes,8,opt,name=provenance" json:"provenance,omitempty"`
+}
+
+func (x *ValidationResult_Violation) Reset() {
+	*x = ValidationResult_Violation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationResult_Violation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult_Violation) ProtoMessage() {}
+
+func (x *ValidationResult_Violation) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult_Violation.ProtoReflect.Descriptor instead.
+func (*ValidationResult_Violation) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *ValidationResult_Violation) GetPath() string {
+	if x != nil && x.Path != nil {
+		return *x.Path
+	}
+	return ""
+}
+
+func (x *ValidationResult_Violation) GetRule() string {
+	if x != nil && x.Rule != nil {
+		return *x.Rule
+	}
+	return ""
+}
+
+func (x *ValidationResult_Violation) GetConstraint() string {
+	if x != nil && x.Constraint != nil {
+		return *x.Constraint
+	}
+	return ""
+}
+
+func (x *ValidationResult_Violation) GetActual() string {
+	if x != nil && x.Actual != nil {
+		return *x.Actual
+	}
+	return ""
+}
+
+func (x *ValidationResult_Violation) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *ValidationResult_Violation) GetSeverity() Severity {
+	if x != nil && x.Severity != nil {
+		return *x.Severity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
+func (x *ValidationResult_Violation) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *ValidationResult_Violation) GetProvenance() string {
+	if x != nil && x.Provenance != nil {
+		return *x.Provenance
+	}
+	return ""
+}
+
+var file_validator_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldValidator)(nil),
+		Field:         65020,
+		Name:          "validator.field",
+		Tag:           "bytes,65020,opt,name=field",
+		Filename:      "validator.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*MethodValidator)(nil),
+		Field:         65021,
+		Name:          "validator.method",
+		Tag:           "bytes,65021,opt,name=method",
+		Filename:      "validator.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*MsgValidator)(nil),
+		Field:         65022,
+		Name:          "validator.message",
+		Tag:           "bytes,65022,opt,name=message",
+		Filename:      "validator.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional validator.FieldValidator field = 65020;
+	E_Field = &file_validator_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional validator.MethodValidator method = 65021;
+	E_Method = &file_validator_proto_extTypes[1]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional validator.MsgValidator message = 65022;
+	E_Message = &file_validator_proto_extTypes[2]
+)
+
+var File_validator_proto protoreflect.FileDescriptor
+
+var file_validator_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0x20, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcc,
+	0x12, 0x0a, 0x0e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x6e, 0x74, 0x5f, 0x67,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x74, 0x47, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x69, 0x6e, 0x74, 0x5f, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x69, 0x6e, 0x74, 0x4c, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x75, 0x6d, 0x61, 0x6e, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x75, 0x6d, 0x61,
+	0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f,
+	0x67, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x47,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x6c, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x4c, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x65, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x45, 0x70, 0x73, 0x69, 0x6c, 0x6f,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x67, 0x74, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x47, 0x74, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x6c, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x4c, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4e, 0x6f, 0x74,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x10, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x4d, 0x69, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x10, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x61,
+	0x78, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x67, 0x74, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x47, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x6c, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x4c, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x65, 0x71, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x45, 0x71, 0x12, 0x1c, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x69,
+	0x6e, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73,
+	0x49, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x70, 0x76, 0x34, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69, 0x70, 0x76, 0x34, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x70,
+	0x76, 0x36, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69, 0x70, 0x76, 0x36, 0x12, 0x1d,
+	0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x69, 0x70, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x69, 0x70, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x69, 0x70, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e,
+	0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x13, 0x69, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x10, 0x69, 0x6e, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x5a, 0x65, 0x72, 0x6f, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x75, 0x6e, 0x74,
+	0x69, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37,
+	0x0a, 0x09, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x18, 0x1c, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x64, 0x6f,
+	0x77, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x12,
+	0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x1e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x2a,
+	0x0a, 0x11, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x4d, 0x61, 0x73, 0x6b, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x6e,
+	0x75, 0x6d, 0x5f, 0x69, 0x6e, 0x18, 0x20, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x65, 0x6e, 0x75,
+	0x6d, 0x49, 0x6e, 0x12, 0x1e, 0x0a, 0x0b, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x6e, 0x6f, 0x74, 0x5f,
+	0x69, 0x6e, 0x18, 0x21, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x65, 0x6e, 0x75, 0x6d, 0x4e, 0x6f,
+	0x74, 0x49, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x6f, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x18, 0x22,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x6f, 0x46, 0x75, 0x6e, 0x63, 0x12, 0x36, 0x0a, 0x0b,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x23, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x15, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x0a, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x55, 0x6e, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x62, 0x79, 0x18, 0x24, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x71, 0x75, 0x65,
+	0x42, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x25, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x18, 0x26, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x27, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x0b, 0x68,
+	0x61, 0x73, 0x68, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x28, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x48, 0x61, 0x73,
+	0x68, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x68, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x12, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x29, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x44, 0x61, 0x79,
+	0x12, 0x2b, 0x0a, 0x12, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x61, 0x79, 0x5f, 0x6f,
+	0x66, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x44, 0x61, 0x79, 0x4f, 0x66, 0x57, 0x65, 0x65, 0x6b, 0x12, 0x3f, 0x0a,
+	0x0e, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18,
+	0x2b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x4e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52,
+	0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x27,
+	0x0a, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x43, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x6d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x18, 0x2d, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x52, 0x11, 0x72, 0x65, 0x70, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69, 0x63, 0x12, 0x2c, 0x0a, 0x12,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x39, 0x0a, 0x19, 0x70, 0x6f,
+	0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x70,
+	0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x70, 0x5f, 0x6b, 0x65, 0x79,
+	0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x30, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61,
+	0x70, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x0a, 0x6d, 0x61, 0x70,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x6e, 0x18, 0x31, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d,
+	0x61, 0x70, 0x4b, 0x65, 0x79, 0x49, 0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x18, 0x32, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x06,
+	0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x73, 0x67, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x33, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d,
+	0x73, 0x67, 0x4d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x12, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x73, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72,
+	0x18, 0x34, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x52, 0x75, 0x6c,
+	0x65, 0x52, 0x10, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4e, 0x6f, 0x74, 0x53, 0x69, 0x6d, 0x69,
+	0x6c, 0x61, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x35, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x69, 0x6e, 0x74,
+	0x5f, 0x67, 0x74, 0x18, 0x36, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x75, 0x69, 0x6e, 0x74, 0x47,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x69, 0x6e, 0x74, 0x5f, 0x6c, 0x74, 0x18, 0x37, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x75, 0x69, 0x6e, 0x74, 0x4c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x69,
+	0x6e, 0x74, 0x5f, 0x67, 0x74, 0x65, 0x18, 0x38, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x75, 0x69,
+	0x6e, 0x74, 0x47, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x69, 0x6e, 0x74, 0x5f, 0x6c, 0x74,
+	0x65, 0x18, 0x39, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x75, 0x69, 0x6e, 0x74, 0x4c, 0x74, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x5f, 0x67, 0x74, 0x65, 0x18, 0x3a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x47, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6e, 0x74,
+	0x5f, 0x6c, 0x74, 0x65, 0x18, 0x3b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x4c,
+	0x74, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x18, 0x3c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x6f,
+	0x6e, 0x65, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x12, 0x17,
+	0x0a, 0x07, 0x6c, 0x61, 0x74, 0x5f, 0x6c, 0x6e, 0x67, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x6c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x3e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x38, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x64, 0x61, 0x79, 0x18,
+	0x3f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x44, 0x61, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x44, 0x61, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x40, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78,
+	0x18, 0x41, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x75,
+	0x66, 0x66, 0x69, 0x78, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x42, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x2e, 0x0a,
+	0x13, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x73, 0x18, 0x43, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x4e, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x58, 0x0a,
+	0x08, 0x44, 0x61, 0x74, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x28, 0x0a,
+	0x10, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x74, 0x6f, 0x64, 0x61,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x54, 0x6f, 0x64, 0x61, 0x79, 0x22, 0x33, 0x0a, 0x0d, 0x54, 0x69, 0x6d, 0x65, 0x4f,
+	0x66, 0x44, 0x61, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x73, 0x0a, 0x09,
+	0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x49, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x21,
+	0x0a, 0x0c, 0x6e, 0x6f, 0x6e, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6e, 0x6f, 0x6e, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x22, 0x70, 0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x52,
+	0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x61, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x43,
+	0x61, 0x73, 0x65, 0x22, 0x5a, 0x0a, 0x0a, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x75, 0x6c,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x2d, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22,
+	0xc9, 0x01, 0x0a, 0x07, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x3c, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x75,
+	0x6c, 0x65, 0x53, 0x65, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x68, 0x61, 0x64, 0x6f, 0x77, 0x1a, 0x54, 0x0a, 0x0d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa1, 0x01, 0x0a, 0x0c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x06,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x54, 0x0a, 0x0b, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x43, 0x0a, 0x0f, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x76,
+	0x65, 0x72, 0x6c, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x76, 0x65,
+	0x72, 0x6c, 0x61, 0x79, 0x22, 0x69, 0x0a, 0x0c, 0x4d, 0x73, 0x67, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x5f, 0x65, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x73, 0x67, 0x45, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x0f, 0x61, 0x74, 0x5f, 0x6c, 0x65, 0x61, 0x73, 0x74, 0x5f,
+	0x6f, 0x6e, 0x65, 0x5f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65,
+	0x74, 0x52, 0x0c, 0x61, 0x74, 0x4c, 0x65, 0x61, 0x73, 0x74, 0x4f, 0x6e, 0x65, 0x4f, 0x66, 0x22,
+	0x22, 0x0a, 0x08, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x22, 0x80, 0x03, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x45, 0x0a, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x2e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x76,
+	0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x1a, 0xec, 0x01, 0x0a, 0x09, 0x56, 0x69, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x75, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x2f, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2a, 0x5e, 0x0a, 0x09, 0x4d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f,
+	0x6e, 0x69, 0x63, 0x12, 0x19, 0x0a, 0x15, 0x4d, 0x4f, 0x4e, 0x4f, 0x54, 0x4f, 0x4e, 0x49, 0x43,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18,
+	0x0a, 0x14, 0x4d, 0x4f, 0x4e, 0x4f, 0x54, 0x4f, 0x4e, 0x49, 0x43, 0x5f, 0x49, 0x4e, 0x43, 0x52,
+	0x45, 0x41, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x4f, 0x4e, 0x4f,
+	0x54, 0x4f, 0x4e, 0x49, 0x43, 0x5f, 0x4e, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x43, 0x52, 0x45, 0x41,
+	0x53, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x2a, 0x61, 0x0a, 0x0d, 0x4e, 0x75, 0x6d, 0x65, 0x72, 0x69,
+	0x63, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x1a, 0x4e, 0x55, 0x4d, 0x45, 0x52,
+	0x49, 0x43, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4e, 0x55, 0x4d, 0x45, 0x52,
+	0x49, 0x43, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x49, 0x4e, 0x54, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x4e, 0x55, 0x4d, 0x45, 0x52, 0x49, 0x43, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e,
+	0x47, 0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x02, 0x2a, 0x91, 0x01, 0x0a, 0x0a, 0x48, 0x61,
+	0x73, 0x68, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x41, 0x53, 0x48,
+	0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x42, 0x43, 0x52, 0x59, 0x50, 0x54, 0x10, 0x01, 0x12, 0x16, 0x0a,
+	0x12, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x41, 0x52, 0x47,
+	0x4f, 0x4e, 0x32, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x5f, 0x48, 0x45, 0x58, 0x10,
+	0x03, 0x12, 0x1a, 0x0a, 0x16, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54,
+	0x5f, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x5f, 0x48, 0x45, 0x58, 0x10, 0x04, 0x2a, 0x55, 0x0a,
+	0x0a, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x15, 0x0a, 0x11, 0x4c,
+	0x45, 0x4e, 0x47, 0x54, 0x48, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x42, 0x59, 0x54, 0x45, 0x53,
+	0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x45, 0x4e, 0x47, 0x54, 0x48, 0x5f, 0x55, 0x4e, 0x49,
+	0x54, 0x5f, 0x52, 0x55, 0x4e, 0x45, 0x53, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x45, 0x4e,
+	0x47, 0x54, 0x48, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x47, 0x52, 0x41, 0x50, 0x48, 0x45, 0x4d,
+	0x45, 0x53, 0x10, 0x02, 0x2a, 0x4e, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45,
+	0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x14,
+	0x0a, 0x10, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49,
+	0x4e, 0x47, 0x10, 0x02, 0x3a, 0x50, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfc, 0xfb, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x3a, 0x54, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0xfd, 0xfb, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x3a, 0x54, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfe, 0xfb, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x73, 0x67,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x3b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72,
+}
+
+var (
+	file_validator_proto_rawDescOnce sync.Once
+	file_validator_proto_rawDescData = file_validator_proto_rawDesc
+)
+
+func file_validator_proto_rawDescGZIP() []byte {
+	file_validator_proto_rawDescOnce.Do(func() {
+		file_validator_proto_rawDescData = protoimpl.X.CompressGZIP(file_validator_proto_rawDescData)
+	})
+	return file_validator_proto_rawDescData
+}
+
+var file_validator_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_validator_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_validator_proto_goTypes = []interface{}{
+	(Monotonic)(0),                      // 0: validator.Monotonic
+	(NumericString)(0),                  // 1: validator.NumericString
+	(HashFormat)(0),                     // 2: validator.HashFormat
+	(LengthUnit)(0),                     // 3: validator.LengthUnit
+	(Severity)(0),                       // 4: validator.Severity
+	(*FieldValidator)(nil),              // 5: validator.FieldValidator
+	(*DateRule)(nil),                    // 6: validator.DateRule
+	(*TimeOfDayRule)(nil),               // 7: validator.TimeOfDayRule
+	(*MoneyRule)(nil),                   // 8: validator.MoneyRule
+	(*SimilarityRule)(nil),              // 9: validator.SimilarityRule
+	(*NestedRule)(nil),                  // 10: validator.NestedRule
+	(*RuleSet)(nil),                     // 11: validator.RuleSet
+	(*MessageRules)(nil),                // 12: validator.MessageRules
+	(*MethodValidator)(nil),             // 13: validator.MethodValidator
+	(*MsgValidator)(nil),                // 14: validator.MsgValidator
+	(*FieldSet)(nil),                    // 15: validator.FieldSet
+	(*ValidationResult)(nil),            // 16: validator.ValidationResult
+	nil,                                 // 17: validator.RuleSet.MessagesEntry
+	nil,                                 // 18: validator.MessageRules.FieldsEntry
+	(*ValidationResult_Violation)(nil),  // 19: validator.ValidationResult.Violation
+	(*descriptorpb.FieldOptions)(nil),   // 20: google.protobuf.FieldOptions
+	(*descriptorpb.MethodOptions)(nil),  // 21: google.protobuf.MethodOptions
+	(*descriptorpb.MessageOptions)(nil), // 22: google.protobuf.MessageOptions
+}
+var file_validator_proto_depIdxs = []int32{
+	5,  // 0: validator.FieldValidator.versioned:type_name -> validator.FieldValidator
+	3,  // 1: validator.FieldValidator.length_unit:type_name -> validator.LengthUnit
+	2,  // 2: validator.FieldValidator.hash_format:type_name -> validator.HashFormat
+	1,  // 3: validator.FieldValidator.numeric_string:type_name -> validator.NumericString
+	0,  // 4: validator.FieldValidator.repeated_monotonic:type_name -> validator.Monotonic
+	10, // 5: validator.FieldValidator.nested:type_name -> validator.NestedRule
+	9,  // 6: validator.FieldValidator.string_not_similar:type_name -> validator.SimilarityRule
+	8,  // 7: validator.FieldValidator.money:type_name -> validator.MoneyRule
+	6,  // 8: validator.FieldValidator.date:type_name -> validator.DateRule
+	7,  // 9: validator.FieldValidator.time_of_day:type_name -> validator.TimeOfDayRule
+	5,  // 10: validator.NestedRule.rule:type_name -> validator.FieldValidator
+	17, // 11: validator.RuleSet.messages:type_name -> validator.RuleSet.MessagesEntry
+	18, // 12: validator.MessageRules.fields:type_name -> validator.MessageRules.FieldsEntry
+	15, // 13: validator.MsgValidator.at_least_one_of:type_name -> validator.FieldSet
+	19, // 14: validator.ValidationResult.violations:type_name -> validator.ValidationResult.Violation
+	12, // 15: validator.RuleSet.MessagesEntry.value:type_name -> validator.MessageRules
+	5,  // 16: validator.MessageRules.FieldsEntry.value:type_name -> validator.FieldValidator
+	4,  // 17: validator.ValidationResult.Violation.severity:type_name -> validator.Severity
+	20, // 18: validator.field:extendee -> google.protobuf.FieldOptions
+	21, // 19: validator.method:extendee -> google.protobuf.MethodOptions
+	22, // 20: validator.message:extendee -> google.protobuf.MessageOptions
+	5,  // 21: validator.field:type_name -> validator.FieldValidator
+	13, // 22: validator.method:type_name -> validator.MethodValidator
+	14, // 23: validator.message:type_name -> validator.MsgValidator
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	21, // [21:24] is the sub-list for extension type_name
+	18, // [18:21] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_validator_proto_init() }
+func file_validator_proto_init() {
+	if File_validator_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_validator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldValidator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DateRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeOfDayRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoneyRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimilarityRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NestedRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuleSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MethodValidator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgValidator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidationResult_Violation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -321,13 +2374,14 @@ func file_validator_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_validator_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
-			NumExtensions: 1,
+			NumEnums:      5,
+			NumMessages:   15,
+			NumExtensions: 3,
 			NumServices:   0,
 		},
 		GoTypes:           file_validator_proto_goTypes,
 		DependencyIndexes: file_validator_proto_depIdxs,
+		EnumInfos:         file_validator_proto_enumTypes,
 		MessageInfos:      file_validator_proto_msgTypes,
 		ExtensionInfos:    file_validator_proto_extTypes,
 	}.Build()
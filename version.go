@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"strconv"
+	"strings"
+)
+
+// WithSchemaVersion validate against the rules in scope of schema version,
+// see since_version/until_version. If unset the newest schema is assumed.
+func WithSchemaVersion(version string) Option {
+	return func(v *Validator) {
+		v.schemaVersion = version
+	}
+}
+
+// scopeRule resolve the rule in scope of the schema version, nil if the rule does not apply
+func (v *Validator) scopeRule(rule *FieldValidator) *FieldValidator {
+	if rule == nil || !v.inScope(rule) {
+		return nil
+	}
+	if len(rule.Versioned) == 0 {
+		return rule
+	}
+
+	scoped := proto.Clone(rule).(*FieldValidator)
+	scoped.Versioned = nil
+	for _, versioned := range rule.Versioned {
+		if v.inScope(versioned) {
+			//the lists of a versioned rule replace those of the rule as in overlayRule, proto.Merge would append to them
+			clearOverlaid(scoped.ProtoReflect(), versioned.ProtoReflect())
+			proto.Merge(scoped, versioned)
+		}
+	}
+	return scoped
+}
+
+// inScope whether the schema version is in the since_version/until_version scope of the rule
+func (v *Validator) inScope(rule *FieldValidator) bool {
+	if v.schemaVersion == "" {
+		return rule.UntilVersion == nil
+	}
+	if rule.SinceVersion != nil && compareVersion(v.schemaVersion, *rule.SinceVersion) < 0 {
+		return false
+	}
+	if rule.UntilVersion != nil && compareVersion(v.schemaVersion, *rule.UntilVersion) >= 0 {
+		return false
+	}
+	return true
+}
+
+// compareVersion compare dotted versions part by part, numerically when both parts are numbers.
+// A leading "v" is ignored and missing parts count as 0.
+func compareVersion(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		pa, pb := "0", "0"
+		if i < len(as) {
+			pa = as[i]
+		}
+		if i < len(bs) {
+			pb = bs[i]
+		}
+
+		na, errA := strconv.ParseInt(pa, 10, 64)
+		nb, errB := strconv.ParseInt(pb, 10, 64)
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case pa != pb:
+			return strings.Compare(pa, pb)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package version; import "validator.proto"; import "google/type/types.proto";
+enum Plan { PLAN_UNSPECIFIED = 0; PLAN_FREE = 1; PLAN_PRO = 2; PLAN_ENTERPRISE = 3; }
+message Account {
+  Plan plan = 1 [(validator.field) = {enum_in: [1, 2, 3], versioned: [{since_version: "2", enum_in: [1, 2]}, {since_version: "3", enum_in: [2]}]}];
+  string name = 2 [(validator.field) = {length_gt: 1, versioned: [{until_version: "2", length_gt: 0}]}];
+  google.type.Money price = 3 [(validator.field) = {money: {currency_in: ["EUR", "USD"]}, versioned: [{since_version: "2", money: {currency_in: ["EUR"]}}]}];
+}`)
+	legal := map[string]interface{}{"plan": "PLAN_PRO", "name": "ab", "price": map[string]string{"currencyCode": "EUR", "units": "1"}}
+	for _, c := range []struct {
+		name, version, field string
+		value                interface{}
+		rule                 string
+	}{
+		{"newest", "", "plan", "PLAN_PRO", ""},
+		{"newest list replaced", "", "plan", "PLAN_FREE", "EnumIn"},
+		{"v1 list", "1", "plan", "PLAN_ENTERPRISE", ""},
+		{"v2 list replaced", "2", "plan", "PLAN_ENTERPRISE", "EnumIn"},
+		{"v2 list", "2", "plan", "PLAN_FREE", ""},
+		{"v3 list replaced", "3.1", "plan", "PLAN_FREE", "EnumIn"},
+		{"v1 scalar", "1", "name", "a", ""},
+		{"v2 scalar", "2", "name", "a", "LengthGt"},
+		{"v1 nested list", "1", "price", map[string]string{"currencyCode": "USD", "units": "1"}, ""},
+		{"v2 nested list replaced", "2", "price", map[string]string{"currencyCode": "USD", "units": "1"}, "MoneyCurrency"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fields := make(map[string]interface{}, len(legal))
+			for name, value := range legal {
+				fields[name] = value
+			}
+			fields[c.field] = c.value
+			data, err := json.Marshal(fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = New(WithSchemaVersion(c.version)).Validate(newMsg(t, fd, "Account", string(data)))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.field, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
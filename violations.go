@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// Violation plain description of a violation, decoupled from the error interface,
+// e.g. to build API responses and logs
+type Violation struct {
+	// Path path of the violating value, in the format of the validator (see WithPathFormat)
+	Path string `json:"path"`
+	// RuleKind rule name, e.g. "Regex"
+	RuleKind string `json:"rule"`
+	// Constraint value of the rule, e.g. the regular expression
+	Constraint interface{} `json:"constraint"`
+	// Actual violating value, e.g. the string length for length rules
+	Actual interface{} `json:"actual"`
+	// Message human readable description
+	Message string `json:"message"`
+	// Count number of elements of the repeated field failing the rule when collapsed, see WithCollapsedElements
+	Count int `json:"count,omitempty"`
+	// Provenance layer the violated rule comes from, e.g. an overlay
+	Provenance Provenance `json:"provenance"`
+}
+
+// Violations verify a proto message with the default validator and list every violation
+func Violations(msg proto.Message) ([]Violation, error) {
+	return std().Violations(msg)
+}
+
+// Violations verify a proto message and list every violation, empty if it is legal.
+// The error reports a failure other than a violation.
+func (v *Validator) Violations(msg proto.Message) ([]Violation, error) {
+	if msg == nil {
+		return nil, nil
+	}
+	var errs []error
+	if err := v.run(&validator{
+		Validator: v,
+		msg:       msg.ProtoReflect(),
+		errs:      &errs,
+	}); err != nil {
+		errs = append(errs, err)
+	}
+
+	violations := make([]Violation, 0, len(errs))
+	var other []error
+	for _, err := range errs {
+		var e *ValidError
+		if !errors.As(err, &e) {
+			other = append(other, err)
+			continue
+		}
+		violations = append(violations, e.Violation())
+	}
+	return violations, errors.Join(other...)
+}
+
+// Violation plain description of the violation
+func (e *ValidError) Violation() Violation {
+	return Violation{
+		Path:       e.Path(),
+		RuleKind:   e.validKey,
+		Constraint: e.validValue,
+		Actual:     e.fieldValue,
+		Message:    e.Message(),
+		Count:      e.Count(),
+		Provenance: e.Provenance(),
+	}
+}
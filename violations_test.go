@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"reflect"
+	"testing"
+)
+
+func TestViolations(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package violations; import "validator.proto";
+message Item { string sku = 1 [(validator.field) = {regex: "^[A-Z]+$"}]; }
+message Order {
+  int64 count = 1 [(validator.field) = {int_gt: 0}];
+  repeated Item items = 2;
+  string note = 3 [(validator.field) = {go_func: "panic"}];
+}`)
+	panicking := func(context.Context, protoreflect.FieldDescriptor, protoreflect.Value) error {
+		panic("boom")
+	}
+	noop := func(context.Context, protoreflect.FieldDescriptor, protoreflect.Value) error { return nil }
+	for _, c := range []struct {
+		name, json string
+		opts       []Option
+		violations []Violation
+		internal   bool
+	}{
+		{"legal", `{"count":"1","items":[{"sku":"A"}]}`, []Option{WithFunc("panic", noop)}, []Violation{}, false},
+		{"every violation", `{"items":[{"sku":"A"},{"sku":"b"}]}`, []Option{WithFunc("panic", noop)}, []Violation{
+			{Path: "count", RuleKind: "IntGt", Constraint: int64(0), Actual: int64(0), Message: "count violates IntGt(0), got 0", Count: 1, Provenance: Provenance{Layer: LayerAnnotation}},
+			{Path: "items[1].sku", RuleKind: "Regex", Constraint: "^[A-Z]+$", Actual: "b", Message: "items[1].sku violates Regex(^[A-Z]+$), got b", Count: 1, Provenance: Provenance{Layer: LayerAnnotation}},
+		}, false},
+		{"path format", `{"count":"1","items":[{"sku":"b"}]}`, []Option{WithFunc("panic", noop), WithPathFormat(PathJSONPointer)}, []Violation{
+			{Path: "/items/0/sku", RuleKind: "Regex", Constraint: "^[A-Z]+$", Actual: "b", Message: "/items/0/sku violates Regex(^[A-Z]+$), got b", Count: 1, Provenance: Provenance{Layer: LayerAnnotation}},
+		}, false},
+		{"internal error", `{"note":"x"}`, []Option{WithFunc("panic", panicking)}, nil, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			violations, err := New(c.opts...).Violations(newMsg(t, fd, "Order", c.json))
+			var internal *InternalValidationError
+			if errors.As(err, &internal) != c.internal || !c.internal && err != nil {
+				t.Fatal(err)
+			}
+			if c.internal {
+				return
+			}
+			if !reflect.DeepEqual(violations, c.violations) {
+				t.Fatalf("%#v", violations)
+			}
+		})
+	}
+	if violations, err := New().Violations(nil); violations != nil || err != nil {
+		t.Fatal(violations, err)
+	}
+}
+
+func TestViolationJSON(t *testing.T) {
+	data, err := json.Marshal(Violation{Path: "items[1].sku", RuleKind: "Regex", Constraint: "^[A-Z]+$", Actual: "b", Message: "m", Count: 1, Provenance: Provenance{Layer: LayerAnnotation}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"path":"items[1].sku","rule":"Regex","constraint":"^[A-Z]+$","actual":"b","message":"m","count":1,"provenance":{"layer":"annotation"}}`
+	if string(data) != want {
+		t.Fatal(string(data))
+	}
+}
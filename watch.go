@@ -0,0 +1,131 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoadRuleSet load a RuleSet from a JSON or YAML (.yaml, .yml) file, in the protobuf JSON mapping.
+// The file name without extension is used if the rule set has no name.
+func LoadRuleSet(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		if data, err = yaml.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("[proto valid]parse rule set[%s]: %w", path, err)
+		}
+	}
+
+	rules := &RuleSet{}
+	if err := protojson.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("[proto valid]parse rule set[%s]: %w", path, err)
+	}
+	if rules.Name == nil {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		rules.Name = &name
+	}
+	return rules, nil
+}
+
+// fileStamp modification stamp of a watched file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// RuleWatcher poll rule set files and swap the reloaded overlays into a validator.
+// A file failing to reload is logged and its previous overlay is kept.
+type RuleWatcher struct {
+	v        *Validator
+	paths    []string
+	stamps   map[string]fileStamp
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// WatchRuleSets load rule set files as overlays of v, then poll them every interval and reload them on change.
+// interval must be positive.
+func (v *Validator) WatchRuleSets(interval time.Duration, paths ...string) (*RuleWatcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("[proto valid]watch rule sets: non-positive interval[%s]", interval)
+	}
+	w := &RuleWatcher{
+		v:      v,
+		paths:  paths,
+		stamps: make(map[string]fileStamp, len(paths)),
+		stop:   make(chan struct{}),
+	}
+	for _, path := range paths {
+		if err := w.load(path); err != nil {
+			return nil, err
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.poll()
+			}
+		}
+	}()
+	return w, nil
+}
+
+// poll reload the changed files
+func (w *RuleWatcher) poll() {
+	for _, path := range w.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			w.v.warnf("[pb valid]stat rule set[%s] err: %s", path, err)
+			continue
+		}
+		if stamp := w.stamps[path]; stamp.modTime.Equal(info.ModTime()) && stamp.size == info.Size() {
+			continue
+		}
+		if err := w.load(path); err != nil {
+			w.v.warnf("[pb valid]reload rule set[%s] err: %s", path, err)
+			w.v.fault(&Fault{Kind: FaultConfig, Err: fmt.Errorf("reload rule set[%s]: %w", path, err)})
+		}
+	}
+}
+
+// load load a file and swap its overlay into the validator
+func (w *RuleWatcher) load(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	rules, err := LoadRuleSet(path)
+	if err != nil {
+		return err
+	}
+	w.v.SetOverlay(rules)
+	w.stamps[path] = fileStamp{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+	return nil
+}
+
+// Close stop watching, the loaded overlays are kept
+func (w *RuleWatcher) Close() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+}
@@ -0,0 +1,32 @@
+//go:build !tinygo && !purereflect
+
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchRuleSets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strict.yaml")
+	if err := os.WriteFile(path, []byte("messages: {}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if w, err := New().WatchRuleSets(interval, path); err == nil {
+			w.Close()
+			t.Fatalf("interval %s: want error", interval)
+		}
+	}
+	v := New()
+	w, err := v.WatchRuleSets(10*time.Millisecond, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if v.getOverlay("strict") == nil {
+		t.Fatal("overlay not loaded")
+	}
+}
@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// wireAction how a field is handled when scanning the wire format
+type wireAction int
+
+const (
+	//wireSkip the field bears no rule, it is not decoded
+	wireSkip wireAction = iota
+	//wireKeep the field bears rules, it is decoded
+	wireKeep
+	//wireFilter the field bears no rule but its sub-message does, only the fields of the sub-message bearing rules are decoded
+	wireFilter
+)
+
+// ValidateWire verify the binary encoding of a message of type md with the default validator, see Validator.ValidateWire
+func ValidateWire(md protoreflect.MessageDescriptor, data []byte) error {
+	return std().ValidateWire(md, data)
+}
+
+// ValidateWire verify the binary encoding of a message of type md without decoding it entirely.
+// The wire format is scanned with protowire: fields bearing no rule are skipped without being decoded,
+// and sub-messages are materialized only for the fields bearing rules.
+// It is a performance tier for gateways validating payloads they forward as bytes:
+// the violations are the ones of decoding then validating the message,
+// except that malformed bytes of skipped fields are only checked for framing.
+// Rules of overlays attached to a context are not applied.
+func (v *Validator) ValidateWire(md protoreflect.MessageDescriptor, data []byte) error {
+	filtered, err := v.filterWire(md, data, nil)
+	if err != nil {
+		return fmt.Errorf("[proto valid]decode message[%s]: %w", md.FullName(), err)
+	}
+	msg := dynamicpb.NewMessage(md)
+	if err := (proto.UnmarshalOptions{Resolver: v.resolver}).Unmarshal(filtered, msg); err != nil {
+		return fmt.Errorf("[proto valid]decode message[%s]: %w", md.FullName(), err)
+	}
+	return v.Validate(msg)
+}
+
+// filterWire append to b the fields of data needed to validate a message of type md
+func (v *Validator) filterWire(md protoreflect.MessageDescriptor, data []byte, b []byte) ([]byte, error) {
+	actions := v.wireActions(md)
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		m := protowire.ConsumeFieldValue(num, typ, data[n:])
+		if m < 0 {
+			return nil, protowire.ParseError(m)
+		}
+		switch actions[num] {
+		case wireKeep:
+			b = append(b, data[:n+m]...)
+		case wireFilter:
+			if typ != protowire.BytesType {
+				//groups are kept as is
+				b = append(b, data[:n+m]...)
+				break
+			}
+			value, _ := protowire.ConsumeBytes(data[n:])
+			sub, err := v.filterWire(md.Fields().ByNumber(num).Message(), value, nil)
+			if err != nil {
+				return nil, err
+			}
+			b = protowire.AppendTag(b, num, typ)
+			b = protowire.AppendBytes(b, sub)
+		}
+		data = data[n+m:]
+	}
+	return b, nil
+}
+
+// wireActions how the fields of a message of type md are handled when scanning the wire format, cached per validator
+func (v *Validator) wireActions(md protoreflect.MessageDescriptor) map[protowire.Number]wireAction {
+	if x, ok := v.wires.Load(md); ok {
+		return x.(map[protowire.Number]wireAction)
+	}
+	prog := v.progs.Get(md, v, nil, v.groups)
+	actions := make(map[protowire.Number]wireAction, len(prog.fields))
+	for _, fp := range prog.fields {
+		field := fp.field
+		switch {
+		case fp.rule != nil || fp.shadow != nil || fp.decodeErr != nil || v.hasDefaults(field) || v.hasOpenEnumPolicy(field):
+			actions[field.Number()] = wireKeep
+		case field.IsMap() || field.Message().FullName() == anyFullName:
+			//map entries and Any payloads are validated as a whole
+			actions[field.Number()] = wireKeep
+		default:
+			actions[field.Number()] = wireFilter
+		}
+		for _, rule := range []*FieldValidator{fp.rule, fp.shadow} {
+			if country := postalCodeCountryField(field, rule.GetPostalCodeCountryField()); country != nil {
+				//the sibling holding the country of a postal code
+				actions[country.Number()] = wireKeep
+			}
+		}
+	}
+	if mp := prog.message; mp != nil {
+		//fields of the message-level rules, only their presence matters
+		for _, fields := range append([][]protoreflect.FieldDescriptor{mp.exists}, mp.atLeastOneOf...) {
+			for _, field := range fields {
+				if actions[field.Number()] == wireSkip {
+					actions[field.Number()] = wireKeep
+				}
+			}
+		}
+	}
+	x, _ := v.wires.LoadOrStore(md, actions)
+	return x.(map[protowire.Number]wireAction)
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"strings"
+	"testing"
+)
+
+const wireProto = `syntax = "proto3"; package wire; import "validator.proto";
+message Meta { string trace = 1; string region = 2 [(validator.field) = {length_eq: 2}]; }
+message Attachment { string name = 1; bytes data = 2; }
+message Event {
+  string id = 1 [(validator.field) = {length_gt: 0}];
+  Meta meta = 2;
+  repeated Attachment attachments = 3;
+  map<string, string> labels = 4;
+  string body = 5;
+}`
+
+// wireEvent encoding of an event with large fields bearing no rule
+func wireEvent(t testing.TB, fd protoreflect.FileDescriptor, id, region string) []byte {
+	body := strings.Repeat("x", 4096)
+	data, err := proto.Marshal(newMsg(t, fd, "Event", `{"id":"`+id+`","meta":{"trace":"`+body+`","region":"`+region+`"},`+
+		`"attachments":[{"name":"a","data":"`+strings.Repeat("eHh4", 1024)+`"},{"name":"b"}],`+
+		`"labels":{"k1":"v1","k2":"v2"},"body":"`+body+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestValidateWire(t *testing.T) {
+	fd := compileProto(t, wireProto)
+	md := fd.Messages().ByName("Event")
+	for _, c := range []struct {
+		name, id, region, path, rule string
+	}{
+		{"legal", "1", "eu", "", ""},
+		{"field", "", "eu", "id", "LengthGt"},
+		{"sub-message field", "1", "eur", "meta.region", "LengthEq"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := New().ValidateWire(md, wireEvent(t, fd, c.id, c.region))
+			if c.rule == "" && err != nil || c.rule != "" && !MatchViolation(err, c.path, c.rule) {
+				t.Fatal(err)
+			}
+		})
+	}
+	if err := New().ValidateWire(md, []byte{0x0a, 0x05}); err == nil {
+		t.Fatal("truncated message accepted")
+	}
+}
+
+func BenchmarkValidateWire(b *testing.B) {
+	fd := compileProto(b, wireProto)
+	md := fd.Messages().ByName("Event")
+	data := wireEvent(b, fd, "1", "eu")
+	v := New()
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := v.ValidateWire(md, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidateDecoded baseline of BenchmarkValidateWire: decode the whole message, then validate it
+func BenchmarkValidateDecoded(b *testing.B) {
+	fd := compileProto(b, wireProto)
+	md := fd.Messages().ByName("Event")
+	data := wireEvent(b, fd, "1", "eu")
+	v := New()
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		msg := dynamicpb.NewMessage(md)
+		if err := proto.Unmarshal(data, msg); err != nil {
+			b.Fatal(err)
+		}
+		if err := v.Validate(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
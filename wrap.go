@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// ValidateAll verify a proto message with the default validator, collecting every violation
+func ValidateAll(msg proto.Message) error {
+	return std().ValidateAll(msg)
+}
+
+// ValidateAll verify a proto message, collecting every violation instead of stopping at the first one.
+// The returned error joins the *ValidError of every violation, see errors.Join.
+func (v *Validator) ValidateAll(msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	var errs []error
+	if err := v.run(&validator{
+		Validator: v,
+		msg:       msg.ProtoReflect(),
+		errs:      &errs,
+	}); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// Wrapped proto message with the Validate() error and ValidateAll() error methods of
+// protoc-gen-validate generated code, to plug into middlewares expecting them (e.g. grpc_middleware/validator)
+type Wrapped struct {
+	v   *Validator
+	msg proto.Message
+}
+
+// Wrap wrap a proto message to be verified by the default validator
+func Wrap(msg proto.Message) *Wrapped {
+	return std().Wrap(msg)
+}
+
+// Wrap wrap a proto message to be verified by the validator
+func (v *Validator) Wrap(msg proto.Message) *Wrapped {
+	return &Wrapped{
+		v:   v,
+		msg: msg,
+	}
+}
+
+// Message the wrapped proto message
+func (w *Wrapped) Message() proto.Message {
+	return w.msg
+}
+
+// Validate verify the wrapped message, stopping at the first violation
+func (w *Wrapped) Validate() error {
+	return w.v.Validate(w.msg)
+}
+
+// ValidateAll verify the wrapped message, collecting every violation
+func (w *Wrapped) ValidateAll() error {
+	return w.v.ValidateAll(w.msg)
+}
@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	fd := compileProto(t, `syntax = "proto3"; package wrap; import "validator.proto";
+message Item {
+  string sku = 1 [(validator.field) = {length_gt: 1}];
+  string name = 2 [(validator.field) = {length_gt: 1}];
+}`)
+	//the methods of protoc-gen-validate generated code
+	type pgvValidator interface {
+		Validate() error
+		ValidateAll() error
+	}
+	msg := newMsg(t, fd, "Item", `{"sku":"a","name":"a"}`)
+	var wrapped pgvValidator = Wrap(msg)
+	if Wrap(msg).Message() != msg {
+		t.Fatal("message not kept")
+	}
+
+	//errors wrapped again by a middleware still hold the violations
+	err := fmt.Errorf("invalid request: %w", wrapped.Validate())
+	var verr *ValidError
+	if !errors.As(err, &verr) || verr.Path() != "sku" || verr.Rule() != "LengthGt" {
+		t.Fatal(err)
+	}
+	if !MatchViolation(err, "sku", "LengthGt") || MatchViolation(err, "name", "LengthGt") {
+		t.Fatal(err)
+	}
+	err = fmt.Errorf("invalid request: %w", wrapped.ValidateAll())
+	if !errors.As(err, &verr) || !MatchViolation(err, "sku", "LengthGt") || !MatchViolation(err, "name", "LengthGt") || len(ValidErrors(err)) != 2 {
+		t.Fatal(err)
+	}
+	if !errors.Is(err, RuleError("LengthGt")) {
+		t.Fatal(err)
+	}
+
+	//the options of the validator apply
+	if err := New(WithRuleFilter(func(_ protoreflect.FieldDescriptor, kind string) bool { return kind != "LengthGt" })).Wrap(msg).ValidateAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Wrap(newMsg(t, fd, "Item", `{"sku":"ab","name":"ab"}`)).Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Wrap(nil).ValidateAll(); err != nil {
+		t.Fatal(err)
+	}
+}